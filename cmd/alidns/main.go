@@ -15,12 +15,12 @@ import (
 	"fmt"
 	"os"
 
-	"alidns/internal/cli"
+	"github.com/Joey-Kot/alidns/internal/cli"
 )
 
 func main() {
 	if err := cli.Run(os.Args[1:], cli.NewDefaultDeps(os.Stdout, os.Stderr)); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -0,0 +1,155 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alibabacloud-go/tea/tea"
+	"golang.org/x/net/publicsuffix"
+)
+
+const acmeRecordType = "TXT"
+
+type ACMEInput struct {
+	FQDN  string
+	Value string
+}
+
+type ACMEResult struct {
+	DomainName string
+	RR         string
+	Value      string
+	RecordIds  []string
+	// Changed is false when present found the value already in place or
+	// cleanup found nothing to remove.
+	Changed   bool
+	RequestId string `json:",omitempty"`
+}
+
+// SplitFQDN splits fqdn into its registered domain and the RR relative to
+// it. The apex maps to "@".
+func SplitFQDN(fqdn string) (domainName, rr string, err error) {
+	name, err := ToASCII(strings.TrimSuffix(strings.TrimSpace(fqdn), "."))
+	if err != nil {
+		return "", "", err
+	}
+	name = strings.ToLower(name)
+	if name == "" {
+		return "", "", fmt.Errorf("empty FQDN")
+	}
+
+	domainName, err = publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot determine registered domain of %q: %w", fqdn, err)
+	}
+	if name == domainName {
+		return domainName, "@", nil
+	}
+	return domainName, strings.TrimSuffix(name, "."+domainName), nil
+}
+
+// ACMEPresent publishes a DNS-01 challenge value as a TXT record. Other TXT
+// values at the same RR are left in place so that several challenges for the
+// same name (e.g. apex and wildcard) can coexist. A disabled record that
+// already carries the value is enabled.
+func (s *Service) ACMEPresent(ctx context.Context, in ACMEInput) (*ACMEResult, error) {
+	result, err := newACMEResult(in)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.findRecords(ctx, result.DomainName, result.RR, acmeRecordType, in.Value)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		for _, record := range existing {
+			recordID := tea.StringValue(record.RecordId)
+			result.RecordIds = append(result.RecordIds, recordID)
+			if recordEnabled(record) {
+				continue
+			}
+			resp, err := s.SetStatus(ctx, recordID, true)
+			if err != nil {
+				return nil, err
+			}
+			result.Changed = true
+			if resp != nil {
+				result.RequestId = tea.StringValue(resp.RequestId)
+			}
+		}
+		return result, nil
+	}
+
+	resp, err := s.Add(ctx, AddInput{
+		DomainName: result.DomainName,
+		Name:       result.RR,
+		Type:       acmeRecordType,
+		Value:      in.Value,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Changed = true
+	if resp != nil {
+		result.RecordIds = append(result.RecordIds, tea.StringValue(resp.RecordId))
+		result.RequestId = tea.StringValue(resp.RequestId)
+	}
+	return result, nil
+}
+
+// ACMECleanup removes the TXT record carrying exactly the challenge value and
+// nothing else.
+func (s *Service) ACMECleanup(ctx context.Context, in ACMEInput) (*ACMEResult, error) {
+	result, err := newACMEResult(in)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.findRecords(ctx, result.DomainName, result.RR, acmeRecordType, in.Value)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return result, nil
+	}
+
+	requestID, err := s.deleteRecords(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	result.Changed = true
+	result.RequestId = requestID
+	for _, record := range existing {
+		result.RecordIds = append(result.RecordIds, tea.StringValue(record.RecordId))
+	}
+	return result, nil
+}
+
+func newACMEResult(in ACMEInput) (*ACMEResult, error) {
+	if in.Value == "" {
+		return nil, fmt.Errorf("empty challenge value")
+	}
+	domainName, rr, err := SplitFQDN(in.FQDN)
+	if err != nil {
+		return nil, err
+	}
+	return &ACMEResult{
+		DomainName: domainName,
+		RR:         rr,
+		Value:      in.Value,
+		RecordIds:  []string{},
+	}, nil
+}
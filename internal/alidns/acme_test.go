@@ -0,0 +1,116 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"testing"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestSplitFQDN(t *testing.T) {
+	cases := []struct {
+		fqdn   string
+		domain string
+		rr     string
+	}{
+		{"_acme-challenge.www.example.com", "example.com", "_acme-challenge.www"},
+		{"_acme-challenge.example.com.", "example.com", "_acme-challenge"},
+		{"Example.COM", "example.com", "@"},
+		{"_acme-challenge.shop.example.com.cn", "example.com.cn", "_acme-challenge.shop"},
+	}
+	for _, tc := range cases {
+		domain, rr, err := SplitFQDN(tc.fqdn)
+		if err != nil {
+			t.Fatalf("SplitFQDN(%q) returned error: %v", tc.fqdn, err)
+		}
+		if domain != tc.domain || rr != tc.rr {
+			t.Fatalf("SplitFQDN(%q) = %q, %q; want %q, %q", tc.fqdn, domain, rr, tc.domain, tc.rr)
+		}
+	}
+}
+
+func TestACMEPresentKeepsOtherValues(t *testing.T) {
+	api := &fakeAPI{
+		queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+			{RecordId: tea.String("r-1"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String("wildcard-token")},
+		},
+		addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-2"), RequestId: tea.String("req-1")},
+	}
+	svc := NewService(api)
+
+	result, err := svc.ACMEPresent(context.Background(), ACMEInput{FQDN: "_acme-challenge.example.com", Value: "apex-token"})
+	if err != nil {
+		t.Fatalf("ACMEPresent returned error: %v", err)
+	}
+	if !result.Changed || len(result.RecordIds) != 1 || result.RecordIds[0] != "r-2" {
+		t.Fatalf("unexpected present result: %+v", result)
+	}
+	if api.delReq != nil || len(api.deleteReq) != 0 {
+		t.Fatal("present must not delete existing TXT values")
+	}
+	req := api.addReq
+	if tea.StringValue(req.DomainName) != "example.com" || tea.StringValue(req.RR) != "_acme-challenge" || tea.StringValue(req.Type) != "TXT" || tea.StringValue(req.Value) != "apex-token" {
+		t.Fatalf("unexpected add request: %+v", req)
+	}
+}
+
+func TestACMEPresentIsIdempotent(t *testing.T) {
+	api := &fakeAPI{queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		{RecordId: tea.String("r-1"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String(`"token"`)},
+	}}
+	svc := NewService(api)
+
+	result, err := svc.ACMEPresent(context.Background(), ACMEInput{FQDN: "_acme-challenge.example.com", Value: "token"})
+	if err != nil {
+		t.Fatalf("ACMEPresent returned error: %v", err)
+	}
+	if result.Changed || api.addReq != nil {
+		t.Fatalf("present should not add an existing value: %+v", result)
+	}
+}
+
+func TestACMECleanupRemovesOnlyExactValue(t *testing.T) {
+	api := &fakeAPI{queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		{RecordId: tea.String("r-1"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String("token")},
+		{RecordId: tea.String("r-2"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String("token-2")},
+	}}
+	svc := NewService(api)
+
+	result, err := svc.ACMECleanup(context.Background(), ACMEInput{FQDN: "_acme-challenge.example.com", Value: "token"})
+	if err != nil {
+		t.Fatalf("ACMECleanup returned error: %v", err)
+	}
+	if !result.Changed || len(api.deleteReq) != 1 || tea.StringValue(api.deleteReq[0].RecordId) != "r-1" {
+		t.Fatalf("unexpected cleanup: result=%+v deletes=%+v", result, api.deleteReq)
+	}
+}
+
+func TestACMEPresentEnablesDisabledRecord(t *testing.T) {
+	api := &fakeAPI{queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		{RecordId: tea.String("r-1"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String("token"), Status: tea.String("DISABLE")},
+	}}
+	svc := NewService(api)
+
+	result, err := svc.ACMEPresent(context.Background(), ACMEInput{FQDN: "_acme-challenge.example.com", Value: "token"})
+	if err != nil {
+		t.Fatalf("ACMEPresent returned error: %v", err)
+	}
+	if !result.Changed || api.addReq != nil {
+		t.Fatalf("present should enable the existing record instead of adding: %+v", result)
+	}
+	if len(api.statusReq) != 1 || tea.StringValue(api.statusReq[0].RecordId) != "r-1" || tea.StringValue(api.statusReq[0].Status) != "Enable" {
+		t.Fatalf("unexpected status requests: %+v", api.statusReq)
+	}
+}
@@ -18,8 +18,60 @@ import (
 )
 
 type DNSAPI interface {
+	AddCustomLine(ctx context.Context, req *alidns20150109.AddCustomLineRequest) (*alidns20150109.AddCustomLineResponseBody, error)
+	AddDomainGroup(ctx context.Context, req *alidns20150109.AddDomainGroupRequest) (*alidns20150109.AddDomainGroupResponseBody, error)
 	AddDomainRecord(ctx context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error)
+	ChangeDomainGroup(ctx context.Context, req *alidns20150109.ChangeDomainGroupRequest) (*alidns20150109.ChangeDomainGroupResponseBody, error)
+	DeleteCustomLines(ctx context.Context, req *alidns20150109.DeleteCustomLinesRequest) (*alidns20150109.DeleteCustomLinesResponseBody, error)
+	DeleteDomainGroup(ctx context.Context, req *alidns20150109.DeleteDomainGroupRequest) (*alidns20150109.DeleteDomainGroupResponseBody, error)
+	DeleteDomainRecord(ctx context.Context, req *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error)
 	DeleteSubDomainRecords(ctx context.Context, req *alidns20150109.DeleteSubDomainRecordsRequest) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error)
+	DescribeCustomLines(ctx context.Context, req *alidns20150109.DescribeCustomLinesRequest) ([]*alidns20150109.DescribeCustomLinesResponseBodyCustomLines, error)
+	DescribeDNSSLBSubDomains(ctx context.Context, req *alidns20150109.DescribeDNSSLBSubDomainsRequest) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error)
+	DescribeDomainDnssecInfo(ctx context.Context, req *alidns20150109.DescribeDomainDnssecInfoRequest) (*alidns20150109.DescribeDomainDnssecInfoResponseBody, error)
+	DescribeDomainGroups(ctx context.Context, req *alidns20150109.DescribeDomainGroupsRequest) ([]*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error)
+	DescribeDomainLogs(ctx context.Context, req *alidns20150109.DescribeDomainLogsRequest) ([]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog, error)
+	DescribeDomainRecordInfo(ctx context.Context, req *alidns20150109.DescribeDomainRecordInfoRequest) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error)
+	DescribeDomainStatistics(ctx context.Context, req *alidns20150109.DescribeDomainStatisticsRequest) ([]*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic, error)
+	DescribeDomainStatisticsSummary(ctx context.Context, req *alidns20150109.DescribeDomainStatisticsSummaryRequest) ([]*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic, error)
+	DescribeDomains(ctx context.Context, req *alidns20150109.DescribeDomainsRequest) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error)
 	DescribeDomainRecords(ctx context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error)
+	DescribeRecordLogs(ctx context.Context, req *alidns20150109.DescribeRecordLogsRequest) ([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, error)
+	DescribeRecordStatistics(ctx context.Context, req *alidns20150109.DescribeRecordStatisticsRequest) ([]*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic, error)
+	DescribeRecordStatisticsSummary(ctx context.Context, req *alidns20150109.DescribeRecordStatisticsSummaryRequest) ([]*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic, error)
+	DescribeSupportLines(ctx context.Context, req *alidns20150109.DescribeSupportLinesRequest) ([]*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine, error)
+	SetDNSSLBStatus(ctx context.Context, req *alidns20150109.SetDNSSLBStatusRequest) (*alidns20150109.SetDNSSLBStatusResponseBody, error)
+	SetDomainDnssecStatus(ctx context.Context, req *alidns20150109.SetDomainDnssecStatusRequest) (*alidns20150109.SetDomainDnssecStatusResponseBody, error)
+	SetDomainRecordStatus(ctx context.Context, req *alidns20150109.SetDomainRecordStatusRequest) (*alidns20150109.SetDomainRecordStatusResponseBody, error)
+	UpdateDNSSLBWeight(ctx context.Context, req *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error)
+	UpdateDomainGroup(ctx context.Context, req *alidns20150109.UpdateDomainGroupRequest) (*alidns20150109.UpdateDomainGroupResponseBody, error)
 	UpdateDomainRecord(ctx context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error)
+	UpdateDomainRecordRemark(ctx context.Context, req *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error)
+}
+
+// GTMAPI is the part of the Global Traffic Manager API behind the gtm
+// commands. It is served by the same endpoint as DNSAPI.
+type GTMAPI interface {
+	AddDnsGtmAccessStrategy(ctx context.Context, req *alidns20150109.AddDnsGtmAccessStrategyRequest) (*alidns20150109.AddDnsGtmAccessStrategyResponseBody, error)
+	AddDnsGtmAddressPool(ctx context.Context, req *alidns20150109.AddDnsGtmAddressPoolRequest) (*alidns20150109.AddDnsGtmAddressPoolResponseBody, error)
+	DeleteDnsGtmAccessStrategy(ctx context.Context, req *alidns20150109.DeleteDnsGtmAccessStrategyRequest) (*alidns20150109.DeleteDnsGtmAccessStrategyResponseBody, error)
+	DeleteDnsGtmAddressPool(ctx context.Context, req *alidns20150109.DeleteDnsGtmAddressPoolRequest) (*alidns20150109.DeleteDnsGtmAddressPoolResponseBody, error)
+	DescribeDnsGtmAccessStrategies(ctx context.Context, req *alidns20150109.DescribeDnsGtmAccessStrategiesRequest) ([]*alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy, error)
+	DescribeDnsGtmInstance(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstanceRequest) (*alidns20150109.DescribeDnsGtmInstanceResponseBody, error)
+	DescribeDnsGtmInstanceAddressPool(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolRequest) (*alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody, error)
+	DescribeDnsGtmInstanceAddressPools(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolsRequest) ([]*alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool, error)
+	DescribeDnsGtmInstances(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstancesRequest) ([]*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances, error)
+	DescribeDnsGtmMonitorConfig(ctx context.Context, req *alidns20150109.DescribeDnsGtmMonitorConfigRequest) (*alidns20150109.DescribeDnsGtmMonitorConfigResponseBody, error)
+	SetDnsGtmMonitorStatus(ctx context.Context, req *alidns20150109.SetDnsGtmMonitorStatusRequest) (*alidns20150109.SetDnsGtmMonitorStatusResponseBody, error)
+	UpdateDnsGtmMonitor(ctx context.Context, req *alidns20150109.UpdateDnsGtmMonitorRequest) (*alidns20150109.UpdateDnsGtmMonitorResponseBody, error)
+}
+
+// PvtzAPI is a DNSAPI served by PrivateZone. Zones stand in for domains and
+// only the record-level methods are supported; the others return an error.
+// The zone methods cover what has no DNSAPI counterpart.
+type PvtzAPI interface {
+	DNSAPI
+	BindZoneVpc(ctx context.Context, req *BindZoneVpcRequest) (*BindZoneVpcResponseBody, error)
+	DescribeZoneInfo(ctx context.Context, zoneID string) (*PvtzZone, error)
+	DescribeZones(ctx context.Context) ([]*PvtzZone, error)
 }
@@ -13,6 +13,7 @@ package alidns
 
 import (
 	"fmt"
+	"time"
 
 	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
 	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
@@ -20,7 +21,63 @@ import (
 	"github.com/aliyun/credentials-go/credentials"
 )
 
+// defaultEndpoint is the Alidns API endpoint; it serves every region.
+const defaultEndpoint = "alidns.cn-hangzhou.aliyuncs.com"
+
+// ClientConfig adjusts CreateClientWithConfig. Zero fields keep the SDK
+// defaults.
+type ClientConfig struct {
+	Endpoint       string
+	ConnectTimeout time.Duration
+	ReadTimeout    time.Duration
+}
+
 func CreateClient(accessKeyID, accessKeySecret string) (*alidns20150109.Client, error) {
+	return CreateClientWithConfig(accessKeyID, accessKeySecret, ClientConfig{})
+}
+
+func CreateClientWithConfig(accessKeyID, accessKeySecret string, cfg ClientConfig) (*alidns20150109.Client, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	openapiConfig, err := newOpenAPIConfig(accessKeyID, accessKeySecret, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ConnectTimeout > 0 {
+		openapiConfig.ConnectTimeout = tea.Int(int(cfg.ConnectTimeout.Milliseconds()))
+	}
+	if cfg.ReadTimeout > 0 {
+		openapiConfig.ReadTimeout = tea.Int(int(cfg.ReadTimeout.Milliseconds()))
+	}
+
+	client, err := alidns20150109.NewClient(openapiConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alidns client: %w", err)
+	}
+
+	return client, nil
+}
+
+// CreatePvtzClient returns a generic OpenAPI client pointed at PrivateZone.
+// There is no typed SDK for it in this module, so NewPvtzSDKClient sends the
+// RPC actions by name.
+func CreatePvtzClient(accessKeyID, accessKeySecret string) (*openapi.Client, error) {
+	openapiConfig, err := newOpenAPIConfig(accessKeyID, accessKeySecret, "pvtz.aliyuncs.com")
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := openapi.NewClient(openapiConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pvtz client: %w", err)
+	}
+
+	return client, nil
+}
+
+func newOpenAPIConfig(accessKeyID, accessKeySecret, endpoint string) (*openapi.Config, error) {
 	if accessKeyID == "" {
 		return nil, fmt.Errorf("AccessKeyId is required")
 	}
@@ -38,15 +95,8 @@ func CreateClient(accessKeyID, accessKeySecret string) (*alidns20150109.Client,
 		return nil, fmt.Errorf("failed to create credential using config: %w", err)
 	}
 
-	openapiConfig := &openapi.Config{
+	return &openapi.Config{
 		Credential: akCredential,
-		Endpoint:   tea.String("alidns.cn-hangzhou.aliyuncs.com"),
-	}
-
-	client, err := alidns20150109.NewClient(openapiConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create alidns client: %w", err)
-	}
-
-	return client, nil
+		Endpoint:   tea.String(endpoint),
+	}, nil
 }
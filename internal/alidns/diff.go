@@ -0,0 +1,318 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type Record = alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+
+// RecordDiff lists what has to change to turn one set of records into
+// another. Records are matched by RR, type, line and value; a record whose
+// value changed therefore shows up as removed plus added.
+type RecordDiff struct {
+	Added   []*Record
+	Removed []*Record
+	Changed []*RecordChange
+}
+
+// RecordChange is a record present on both sides with differing attributes.
+type RecordChange struct {
+	Before *Record
+	After  *Record
+	Fields []string
+}
+
+func (d *RecordDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffRecords compares the current records with the desired ones.
+func DiffRecords(current, desired []*Record) *RecordDiff {
+	diff := &RecordDiff{Added: []*Record{}, Removed: []*Record{}, Changed: []*RecordChange{}}
+
+	currentByKey := indexRecords(current)
+	desiredByKey := indexRecords(desired)
+
+	for _, key := range slices.Sorted(maps.Keys(desiredByKey)) {
+		after := desiredByKey[key]
+		before, ok := currentByKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, after)
+			continue
+		}
+		if fields := changedFields(before, after); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, &RecordChange{Before: before, After: after, Fields: fields})
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(currentByKey)) {
+		if _, ok := desiredByKey[key]; !ok {
+			diff.Removed = append(diff.Removed, currentByKey[key])
+		}
+	}
+	return diff
+}
+
+// DiffStep is one record mutation made by ApplyDiff. Before is nil for a
+// created record and After is nil for a deleted one.
+type DiffStep struct {
+	Before    *Record
+	After     *Record
+	RecordID  string
+	RequestID string
+}
+
+// ApplyDiff replays diff against domainName so that names keep resolving
+// while it runs: a removed record with an added counterpart at the same RR,
+// type and line is updated in place, the other added records are created and
+// the changed ones updated, and only then are the remaining removed records
+// deleted. An added record that cannot coexist with a removed one (a CNAME
+// and any other type at the same RR and line) is created after the deletes.
+//
+// applied, if not nil, is called for every record mutation that took effect,
+// including one whose later calls failed.
+func (s *Service) ApplyDiff(ctx context.Context, domainName string, diff *RecordDiff, applied func(DiffStep)) error {
+	report := func(step DiffStep) {
+		if applied != nil {
+			applied(step)
+		}
+	}
+	replaced, added, removed := pairReplacements(diff)
+
+	var deferred []*Record
+	for _, record := range added {
+		if slices.ContainsFunc(removed, func(old *Record) bool { return conflicts(record, old) }) {
+			deferred = append(deferred, record)
+			continue
+		}
+		if err := s.applyAdd(ctx, domainName, record, report); err != nil {
+			return err
+		}
+	}
+	for _, change := range append(replaced, diff.Changed...) {
+		if err := s.applyChange(ctx, change, report); err != nil {
+			return err
+		}
+	}
+	for _, record := range removed {
+		resp, err := s.DeleteRecord(ctx, tea.StringValue(record.RecordId))
+		if err != nil {
+			return fmt.Errorf("delete %s: %w", describeRecord(record), err)
+		}
+		step := DiffStep{Before: record, RecordID: tea.StringValue(record.RecordId)}
+		if resp != nil {
+			step.RequestID = tea.StringValue(resp.RequestId)
+		}
+		report(step)
+	}
+	for _, record := range deferred {
+		if err := s.applyAdd(ctx, domainName, record, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyAdd(ctx context.Context, domainName string, record *Record, report func(DiffStep)) error {
+	resp, err := s.Add(ctx, AddInput{
+		DomainName: domainName,
+		Name:       tea.StringValue(record.RR),
+		Type:       tea.StringValue(record.Type),
+		Value:      tea.StringValue(record.Value),
+		TTL:        tea.Int64Value(record.TTL),
+		Priority:   tea.Int64Value(record.Priority),
+		Line:       tea.StringValue(record.Line),
+		Remark:     tea.StringValue(record.Remark),
+	})
+	if resp == nil {
+		if err != nil {
+			return fmt.Errorf("add %s: %w", describeRecord(record), err)
+		}
+		return nil
+	}
+	// A failed remark still leaves the record behind; report it first so
+	// that it is journaled and a retried restore does not add it again.
+	step := DiffStep{After: record, RecordID: tea.StringValue(resp.RecordId), RequestID: tea.StringValue(resp.RequestId)}
+	report(step)
+	if err != nil {
+		return fmt.Errorf("add %s: %w", describeRecord(record), err)
+	}
+	if !recordEnabled(record) {
+		if _, err := s.SetStatus(ctx, step.RecordID, false); err != nil {
+			return fmt.Errorf("disable %s: %w", describeRecord(record), err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) applyChange(ctx context.Context, change *RecordChange, report func(DiffStep)) error {
+	before, after := change.Before, change.After
+	step := DiffStep{Before: before, After: after, RecordID: tea.StringValue(before.RecordId)}
+	applied := false
+	defer func() {
+		if applied {
+			report(step)
+		}
+	}()
+
+	if slices.ContainsFunc(change.Fields, func(field string) bool { return field == "Value" || field == "TTL" || field == "Priority" }) {
+		resp, err := s.Update(ctx, UpdateInput{
+			RecordID: step.RecordID,
+			Name:     tea.StringValue(after.RR),
+			Type:     tea.StringValue(after.Type),
+			Value:    tea.StringValue(after.Value),
+			TTL:      tea.Int64Value(after.TTL),
+			Priority: tea.Int64Value(after.Priority),
+			Line:     tea.StringValue(after.Line),
+		})
+		if err != nil {
+			return fmt.Errorf("update %s: %w", describeRecord(after), err)
+		}
+		applied = true
+		if resp != nil {
+			step.RequestID = tea.StringValue(resp.RequestId)
+		}
+	}
+	if slices.Contains(change.Fields, "Remark") {
+		resp, err := s.SetRemark(ctx, step.RecordID, tea.StringValue(after.Remark))
+		if err != nil {
+			return fmt.Errorf("set remark of %s: %w", describeRecord(after), err)
+		}
+		applied = true
+		if resp != nil {
+			step.RequestID = tea.StringValue(resp.RequestId)
+		}
+	}
+	if slices.Contains(change.Fields, "Status") {
+		resp, err := s.SetStatus(ctx, step.RecordID, recordEnabled(after))
+		if err != nil {
+			return fmt.Errorf("set status of %s: %w", describeRecord(after), err)
+		}
+		applied = true
+		if resp != nil {
+			step.RequestID = tea.StringValue(resp.RequestId)
+		}
+	}
+	return nil
+}
+
+// pairReplacements matches removed and added records at the same RR, type
+// and line, in order, into in-place changes of the value. The records left
+// unmatched are returned as they are.
+func pairReplacements(diff *RecordDiff) (replaced []*RecordChange, added, removed []*Record) {
+	pending := map[string][]*Record{}
+	for _, record := range diff.Removed {
+		key := setKey(record)
+		pending[key] = append(pending[key], record)
+	}
+	for _, record := range diff.Added {
+		key := setKey(record)
+		if len(pending[key]) == 0 {
+			added = append(added, record)
+			continue
+		}
+		before := pending[key][0]
+		pending[key] = pending[key][1:]
+		replaced = append(replaced, &RecordChange{
+			Before: before,
+			After:  record,
+			Fields: append([]string{"Value"}, changedFields(before, record)...),
+		})
+	}
+	for _, record := range diff.Removed {
+		key := setKey(record)
+		if slices.Contains(pending[key], record) {
+			removed = append(removed, record)
+		}
+	}
+	return replaced, added, removed
+}
+
+// conflicts reports whether record cannot be added while old exists: a CNAME
+// excludes every other type at the same RR and line.
+func conflicts(record, old *Record) bool {
+	if !strings.EqualFold(tea.StringValue(record.RR), tea.StringValue(old.RR)) ||
+		defaultString(tea.StringValue(record.Line), defaultLine) != defaultString(tea.StringValue(old.Line), defaultLine) {
+		return false
+	}
+	rType, oldType := strings.ToUpper(tea.StringValue(record.Type)), strings.ToUpper(tea.StringValue(old.Type))
+	return rType != oldType && (rType == "CNAME" || oldType == "CNAME")
+}
+
+func indexRecords(records []*Record) map[string]*Record {
+	index := make(map[string]*Record, len(records))
+	for _, record := range records {
+		if record != nil {
+			index[recordKey(record)] = record
+		}
+	}
+	return index
+}
+
+func recordKey(r *Record) string {
+	rType := strings.ToUpper(tea.StringValue(r.Type))
+	return setKey(r) + "\x00" + comparableValue(rType, tea.StringValue(r.Value))
+}
+
+// setKey identifies the RR set of r: its RR, type and line.
+func setKey(r *Record) string {
+	return strings.Join([]string{
+		strings.ToLower(tea.StringValue(r.RR)),
+		strings.ToUpper(tea.StringValue(r.Type)),
+		defaultString(tea.StringValue(r.Line), defaultLine),
+	}, "\x00")
+}
+
+// comparableValue normalizes the spellings Alidns accepts for the same value.
+func comparableValue(rType, value string) string {
+	switch rType {
+	case "TXT":
+		return strings.Trim(value, `"`)
+	case "CNAME", "MX", "NS", "SRV":
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	}
+	return value
+}
+
+func changedFields(before, after *Record) []string {
+	var fields []string
+	if tea.Int64Value(before.TTL) != tea.Int64Value(after.TTL) {
+		fields = append(fields, "TTL")
+	}
+	if tea.Int64Value(before.Priority) != tea.Int64Value(after.Priority) {
+		fields = append(fields, "Priority")
+	}
+	if recordEnabled(before) != recordEnabled(after) {
+		fields = append(fields, "Status")
+	}
+	// A desired state without a remark does not manage remarks.
+	if after.Remark != nil && tea.StringValue(before.Remark) != tea.StringValue(after.Remark) {
+		fields = append(fields, "Remark")
+	}
+	return fields
+}
+
+func recordEnabled(r *Record) bool {
+	return r.Status == nil || strings.EqualFold(tea.StringValue(r.Status), "ENABLE")
+}
+
+func describeRecord(r *Record) string {
+	return fmt.Sprintf("%s %s %s", tea.StringValue(r.RR), tea.StringValue(r.Type), tea.StringValue(r.Value))
+}
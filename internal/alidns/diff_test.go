@@ -0,0 +1,183 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func testRecord(id, rr, rType, value string, ttl int64, status string) *Record {
+	return &Record{
+		RecordId: tea.String(id),
+		RR:       tea.String(rr),
+		Type:     tea.String(rType),
+		Value:    tea.String(value),
+		TTL:      tea.Int64(ttl),
+		Line:     tea.String("default"),
+		Status:   tea.String(status),
+	}
+}
+
+func TestDiffRecordsMatchesByIdentity(t *testing.T) {
+	current := []*Record{
+		testRecord("1", "www", "A", "1.1.1.1", 600, "ENABLE"),
+		testRecord("2", "www", "A", "2.2.2.2", 600, "ENABLE"),
+		testRecord("3", "_acme", "TXT", `"token"`, 600, "ENABLE"),
+		testRecord("4", "mail", "CNAME", "mx.example.net.", 600, "ENABLE"),
+	}
+	desired := []*Record{
+		testRecord("a", "WWW", "a", "1.1.1.1", 300, "DISABLE"),
+		testRecord("b", "_acme", "TXT", "token", 600, "ENABLE"),
+		testRecord("c", "mail", "CNAME", "MX.example.net", 600, "ENABLE"),
+		testRecord("d", "api", "A", "3.3.3.3", 600, "ENABLE"),
+	}
+
+	diff := DiffRecords(current, desired)
+
+	if len(diff.Added) != 1 || tea.StringValue(diff.Added[0].RR) != "api" {
+		t.Fatalf("unexpected added records: %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || tea.StringValue(diff.Removed[0].RecordId) != "2" {
+		t.Fatalf("unexpected removed records: %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("expected one changed record, got %+v", diff.Changed)
+	}
+	change := diff.Changed[0]
+	if tea.StringValue(change.Before.RecordId) != "1" || len(change.Fields) != 2 || change.Fields[0] != "TTL" || change.Fields[1] != "Status" {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+	if !DiffRecords(current, current).Empty() {
+		t.Fatal("diff of identical sets should be empty")
+	}
+}
+
+func TestServiceApplyDiffReplaysChanges(t *testing.T) {
+	api := &fakeAPI{
+		addResp:    &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("new")},
+		updateResp: &alidns20150109.UpdateDomainRecordResponseBody{},
+	}
+	svc := NewService(api)
+
+	diff := &RecordDiff{
+		Added:   []*Record{testRecord("", "api", "A", "3.3.3.3", 600, "DISABLE")},
+		Removed: []*Record{testRecord("2", "www", "A", "2.2.2.2", 600, "ENABLE")},
+		Changed: []*RecordChange{{
+			Before: testRecord("1", "www", "A", "1.1.1.1", 600, "ENABLE"),
+			After:  testRecord("", "www", "A", "1.1.1.1", 300, "ENABLE"),
+			Fields: []string{"TTL"},
+		}},
+	}
+	if err := svc.ApplyDiff(context.Background(), "example.com", diff, nil); err != nil {
+		t.Fatalf("ApplyDiff returned error: %v", err)
+	}
+
+	if len(api.deleteReq) != 1 || tea.StringValue(api.deleteReq[0].RecordId) != "2" {
+		t.Fatalf("unexpected delete requests: %+v", api.deleteReq)
+	}
+	if tea.StringValue(api.addReq.RR) != "api" || tea.StringValue(api.addReq.DomainName) != "example.com" {
+		t.Fatalf("unexpected add request: %+v", api.addReq)
+	}
+	if len(api.statusReq) != 1 || tea.StringValue(api.statusReq[0].RecordId) != "new" || tea.StringValue(api.statusReq[0].Status) != "Disable" {
+		t.Fatalf("added record should be disabled: %+v", api.statusReq)
+	}
+	if tea.StringValue(api.updateReq.RecordId) != "1" || tea.Int64Value(api.updateReq.TTL) != 300 {
+		t.Fatalf("unexpected update request: %+v", api.updateReq)
+	}
+}
+
+func TestServiceApplyDiffAddsBeforeDeleting(t *testing.T) {
+	api := &fakeAPI{
+		addResp:    &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("new"), RequestId: tea.String("add")},
+		updateResp: &alidns20150109.UpdateDomainRecordResponseBody{RequestId: tea.String("update")},
+	}
+	var ops []string
+	svc := NewService(Wrap(api, Observe(func(op string, _ time.Duration, _ error) {
+		ops = append(ops, op)
+	})))
+
+	diff := &RecordDiff{
+		Added: []*Record{
+			testRecord("", "mail", "CNAME", "mx.example.net", 600, "ENABLE"),
+			testRecord("", "new", "A", "3.3.3.3", 600, "ENABLE"),
+			testRecord("", "www", "A", "2.2.2.2", 600, "ENABLE"),
+		},
+		Removed: []*Record{
+			testRecord("m", "mail", "A", "4.4.4.4", 600, "ENABLE"),
+			testRecord("o", "old", "A", "5.5.5.5", 600, "ENABLE"),
+			testRecord("w", "www", "A", "1.1.1.1", 600, "ENABLE"),
+		},
+	}
+	var steps []DiffStep
+	if err := svc.ApplyDiff(context.Background(), "example.com", diff, func(step DiffStep) { steps = append(steps, step) }); err != nil {
+		t.Fatalf("ApplyDiff returned error: %v", err)
+	}
+
+	want := []string{"AddDomainRecord", "UpdateDomainRecord", "DeleteDomainRecord", "DeleteDomainRecord", "AddDomainRecord"}
+	if !reflect.DeepEqual(ops, want) {
+		t.Fatalf("calls = %v, want %v", ops, want)
+	}
+	if tea.StringValue(api.updateReq.RecordId) != "w" || tea.StringValue(api.updateReq.Value) != "2.2.2.2" {
+		t.Fatalf("www should be updated in place: %+v", api.updateReq)
+	}
+	if len(api.deleteReq) != 2 || tea.StringValue(api.deleteReq[0].RecordId) != "m" || tea.StringValue(api.deleteReq[1].RecordId) != "o" {
+		t.Fatalf("unexpected delete requests: %+v", api.deleteReq)
+	}
+	if tea.StringValue(api.addReq.Type) != "CNAME" {
+		t.Fatalf("the conflicting CNAME should be added last: %+v", api.addReq)
+	}
+
+	if len(steps) != 5 {
+		t.Fatalf("expected one step per mutation, got %+v", steps)
+	}
+	if steps[0].Before != nil || steps[0].RecordID != "new" || steps[0].RequestID != "add" {
+		t.Fatalf("unexpected add step: %+v", steps[0])
+	}
+	if steps[1].Before == nil || steps[1].After == nil || steps[1].RecordID != "w" || steps[1].RequestID != "update" {
+		t.Fatalf("unexpected update step: %+v", steps[1])
+	}
+	if steps[2].After != nil || steps[2].RecordID != "m" || steps[2].RequestID != "del-m" {
+		t.Fatalf("unexpected delete step: %+v", steps[2])
+	}
+}
+
+// failingRemarkAPI adds records but rejects remark changes.
+type failingRemarkAPI struct {
+	*fakeAPI
+}
+
+func (failingRemarkAPI) UpdateDomainRecordRemark(context.Context, *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	return nil, errors.New("remark too long")
+}
+
+func TestServiceApplyDiffReportsAddWhoseRemarkFailed(t *testing.T) {
+	api := &fakeAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("new"), RequestId: tea.String("add")}}
+	svc := NewService(failingRemarkAPI{api})
+
+	record := testRecord("", "www", "A", "1.1.1.1", 600, "ENABLE")
+	record.Remark = tea.String("owner: ops")
+	var steps []DiffStep
+	err := svc.ApplyDiff(context.Background(), "example.com", &RecordDiff{Added: []*Record{record}}, func(step DiffStep) { steps = append(steps, step) })
+	if err == nil {
+		t.Fatal("expected the remark failure to be returned")
+	}
+	if len(steps) != 1 || steps[0].RecordID != "new" {
+		t.Fatalf("the added record should still be reported: %+v", steps)
+	}
+}
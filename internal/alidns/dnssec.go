@@ -0,0 +1,107 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type DNSSECInfo = alidns20150109.DescribeDomainDnssecInfoResponseBody
+
+// DSRecord is the delegation signer data a registrar publishes in the
+// parent zone to vouch for the domain's signing key.
+type DSRecord struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	Digest     string
+}
+
+// String renders the record data the way it appears in a zone file after
+// the DS type, e.g. "2371 13 2 C1A0...".
+func (d DSRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", d.KeyTag, d.Algorithm, d.DigestType, d.Digest)
+}
+
+// ParseDSRecord parses either a full DS resource record such as
+// "example.com. 3600 IN DS 2371 13 2 C1A0..." or just its data.
+func ParseDSRecord(s string) (DSRecord, error) {
+	fields := strings.Fields(s)
+	for i, field := range fields {
+		if strings.EqualFold(field, "DS") {
+			fields = fields[i+1:]
+			break
+		}
+	}
+	if len(fields) < 4 {
+		return DSRecord{}, fmt.Errorf("invalid DS record %q", s)
+	}
+	keyTag, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return DSRecord{}, fmt.Errorf("invalid DS key tag %q", fields[0])
+	}
+	algorithm, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return DSRecord{}, fmt.Errorf("invalid DS algorithm %q", fields[1])
+	}
+	digestType, err := strconv.ParseUint(fields[2], 10, 8)
+	if err != nil {
+		return DSRecord{}, fmt.Errorf("invalid DS digest type %q", fields[2])
+	}
+	// Long digests may be split into several fields.
+	digest := strings.ToUpper(strings.Join(fields[3:], ""))
+	if _, err := hex.DecodeString(digest); err != nil {
+		return DSRecord{}, fmt.Errorf("invalid DS digest %q", digest)
+	}
+	return DSRecord{
+		KeyTag:     uint16(keyTag),
+		Algorithm:  uint8(algorithm),
+		DigestType: uint8(digestType),
+		Digest:     digest,
+	}, nil
+}
+
+// DNSSEC returns the DNSSEC state of domainName and, when enabled, its key
+// and DS data.
+func (s *Service) DNSSEC(ctx context.Context, domainName string) (*DNSSECInfo, error) {
+	if err := asciiNames(&domainName); err != nil {
+		return nil, err
+	}
+	return s.api.DescribeDomainDnssecInfo(ctx, &alidns20150109.DescribeDomainDnssecInfoRequest{
+		Lang:       tea.String("en"),
+		DomainName: tea.String(domainName),
+	})
+}
+
+// SetDNSSEC turns signing of domainName on or off. Only paid editions can
+// enable it.
+func (s *Service) SetDNSSEC(ctx context.Context, domainName string, on bool) (*alidns20150109.SetDomainDnssecStatusResponseBody, error) {
+	if err := asciiNames(&domainName); err != nil {
+		return nil, err
+	}
+	status := "OFF"
+	if on {
+		status = "ON"
+	}
+	return s.api.SetDomainDnssecStatus(ctx, &alidns20150109.SetDomainDnssecStatusRequest{
+		Lang:       tea.String("en"),
+		DomainName: tea.String(domainName),
+		Status:     tea.String(status),
+	})
+}
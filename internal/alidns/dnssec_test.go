@@ -0,0 +1,39 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import "testing"
+
+func TestParseDSRecord(t *testing.T) {
+	want := DSRecord{KeyTag: 2371, Algorithm: 13, DigestType: 2, Digest: "C1A0424B97A049F1"}
+	for _, in := range []string{
+		"example.com. 3600 IN DS 2371 13 2 C1A0424B97A049F1",
+		"2371 13 2 c1a0424b 97a049f1",
+	} {
+		got, err := ParseDSRecord(in)
+		if err != nil {
+			t.Fatalf("ParseDSRecord(%q) returned error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseDSRecord(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+	if got := want.String(); got != "2371 13 2 C1A0424B97A049F1" {
+		t.Fatalf("String() = %q", got)
+	}
+
+	for _, bad := range []string{"", "example.com. IN DS 2371 13 2", "2371 13 2 XYZ", "70000 13 2 C1A0"} {
+		if _, err := ParseDSRecord(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"errors"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// ErrorCode returns the API error code carried by err, such as
+// Throttling.User, or "" when err did not come from the API.
+func ErrorCode(err error) string {
+	var coded interface{ GetCode() *string }
+	if errors.As(err, &coded) {
+		return tea.StringValue(coded.GetCode())
+	}
+	var sdkErr *tea.SDKError
+	if errors.As(err, &sdkErr) {
+		return tea.StringValue(sdkErr.Code)
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import "strings"
+
+// MatchFQDN splits fqdn at the longest of domains it belongs to. The apex
+// maps to "@"; wildcard labels such as "*.dev" are kept as the RR. Names are
+// compared in their A-label form and returned that way.
+func MatchFQDN(fqdn string, domains []string) (domainName, rr string, ok bool) {
+	name, err := ToASCII(strings.TrimSuffix(strings.TrimSpace(fqdn), "."))
+	if err != nil {
+		return "", "", false
+	}
+	name = strings.ToLower(name)
+	for _, domain := range domains {
+		d, err := ToASCII(strings.TrimSuffix(domain, "."))
+		if err != nil {
+			continue
+		}
+		d = strings.ToLower(d)
+		if d == "" || len(d) <= len(domainName) {
+			continue
+		}
+		switch {
+		case name == d:
+			domainName, rr = d, "@"
+		case strings.HasSuffix(name, "."+d):
+			domainName, rr = d, strings.TrimSuffix(name, "."+d)
+		}
+	}
+	return domainName, rr, domainName != ""
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import "testing"
+
+func TestMatchFQDNPicksLongestDomain(t *testing.T) {
+	domains := []string{"example.com", "dev.example.com", "example.org"}
+	tests := []struct {
+		fqdn   string
+		domain string
+		rr     string
+	}{
+		{fqdn: "www.example.com", domain: "example.com", rr: "www"},
+		{fqdn: "WWW.Dev.Example.com.", domain: "dev.example.com", rr: "www"},
+		{fqdn: "dev.example.com", domain: "dev.example.com", rr: "@"},
+		{fqdn: "*.dev.example.com", domain: "dev.example.com", rr: "*"},
+		{fqdn: "*.api.example.com", domain: "example.com", rr: "*.api"},
+	}
+	for _, tt := range tests {
+		domain, rr, ok := MatchFQDN(tt.fqdn, domains)
+		if !ok || domain != tt.domain || rr != tt.rr {
+			t.Errorf("MatchFQDN(%q) = %q, %q, %v; want %q, %q", tt.fqdn, domain, rr, ok, tt.domain, tt.rr)
+		}
+	}
+
+	if _, _, ok := MatchFQDN("www.notexample.com", domains); ok {
+		t.Fatal("a label suffix must not match a different domain")
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"fmt"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type DomainGroup = alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup
+
+// Groups returns every domain group in the account, walking all result
+// pages.
+func (s *Service) Groups(ctx context.Context) ([]*DomainGroup, error) {
+	all := []*DomainGroup{}
+	for page := int64(1); ; page++ {
+		groups, err := s.api.DescribeDomainGroups(ctx, &alidns20150109.DescribeDomainGroupsRequest{
+			Lang:       tea.String("en"),
+			PageNumber: tea.Int64(page),
+			PageSize:   tea.Int64(domainPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, groups...)
+		if int64(len(groups)) < domainPageSize {
+			return all, nil
+		}
+	}
+}
+
+// Group finds a group by name, or by ID when no name matches.
+func (s *Service) Group(ctx context.Context, nameOrID string) (*DomainGroup, error) {
+	groups, err := s.Groups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if tea.StringValue(group.GroupName) == nameOrID {
+			return group, nil
+		}
+	}
+	for _, group := range groups {
+		if tea.StringValue(group.GroupId) == nameOrID {
+			return group, nil
+		}
+	}
+	return nil, fmt.Errorf("domain group %q not found", nameOrID)
+}
+
+func (s *Service) AddGroup(ctx context.Context, name string) (*alidns20150109.AddDomainGroupResponseBody, error) {
+	return s.api.AddDomainGroup(ctx, &alidns20150109.AddDomainGroupRequest{
+		Lang:      tea.String("en"),
+		GroupName: tea.String(name),
+	})
+}
+
+func (s *Service) RenameGroup(ctx context.Context, group, newName string) (*alidns20150109.UpdateDomainGroupResponseBody, error) {
+	g, err := s.Group(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return s.api.UpdateDomainGroup(ctx, &alidns20150109.UpdateDomainGroupRequest{
+		Lang:      tea.String("en"),
+		GroupId:   g.GroupId,
+		GroupName: tea.String(newName),
+	})
+}
+
+func (s *Service) DeleteGroup(ctx context.Context, group string) (*alidns20150109.DeleteDomainGroupResponseBody, error) {
+	g, err := s.Group(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return s.api.DeleteDomainGroup(ctx, &alidns20150109.DeleteDomainGroupRequest{
+		Lang:    tea.String("en"),
+		GroupId: g.GroupId,
+	})
+}
+
+// MoveDomain puts domainName into group.
+func (s *Service) MoveDomain(ctx context.Context, domainName, group string) (*alidns20150109.ChangeDomainGroupResponseBody, error) {
+	if err := asciiNames(&domainName); err != nil {
+		return nil, err
+	}
+	g, err := s.Group(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return s.api.ChangeDomainGroup(ctx, &alidns20150109.ChangeDomainGroupRequest{
+		Lang:       tea.String("en"),
+		DomainName: tea.String(domainName),
+		GroupId:    g.GroupId,
+	})
+}
+
+// GroupDomains returns every domain in group.
+func (s *Service) GroupDomains(ctx context.Context, group string) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	g, err := s.Group(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	return s.domains(ctx, tea.StringValue(g.GroupId))
+}
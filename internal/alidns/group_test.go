@@ -0,0 +1,56 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"testing"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestServiceGroupOperationsResolveGroupName(t *testing.T) {
+	api := &fakeAPI{groupResp: []*DomainGroup{
+		{GroupId: tea.String("g-1"), GroupName: tea.String("retail")},
+		{GroupId: tea.String("g-2"), GroupName: tea.String("finance")},
+	}}
+	svc := NewService(api)
+	ctx := context.Background()
+
+	if _, err := svc.MoveDomain(ctx, "example.com", "finance"); err != nil {
+		t.Fatalf("MoveDomain returned error: %v", err)
+	}
+	move := api.groupReq[0].(*alidns20150109.ChangeDomainGroupRequest)
+	if tea.StringValue(move.DomainName) != "example.com" || tea.StringValue(move.GroupId) != "g-2" {
+		t.Fatalf("unexpected move request: %+v", move)
+	}
+
+	if _, err := svc.RenameGroup(ctx, "g-1", "shop"); err != nil {
+		t.Fatalf("RenameGroup returned error: %v", err)
+	}
+	rename := api.groupReq[1].(*alidns20150109.UpdateDomainGroupRequest)
+	if tea.StringValue(rename.GroupId) != "g-1" || tea.StringValue(rename.GroupName) != "shop" {
+		t.Fatalf("unexpected rename request: %+v", rename)
+	}
+
+	if _, err := svc.GroupDomains(ctx, "retail"); err != nil {
+		t.Fatalf("GroupDomains returned error: %v", err)
+	}
+	if tea.StringValue(api.domainReq[0].GroupId) != "g-1" {
+		t.Fatalf("domains should be filtered by group: %+v", api.domainReq[0])
+	}
+
+	if _, err := svc.DeleteGroup(ctx, "missing"); err == nil {
+		t.Fatal("expected an error for an unknown group")
+	}
+}
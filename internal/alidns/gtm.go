@@ -0,0 +1,381 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const gtmPageSize int32 = 100
+
+const (
+	defaultGTMMode        = "SMART"
+	defaultGTMLbaStrategy = "ALL_RR"
+	defaultGTMStrategy    = "GEO"
+	defaultGTMInterval    = 60
+	defaultGTMTimeout     = 5000
+	defaultGTMEvaluations = 1
+)
+
+type GTMInstance = alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances
+
+type GTMPool = alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool
+
+type GTMStrategy = alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy
+
+// GTMService manages Global Traffic Manager instances: address pools, the
+// access strategies that route DNS sources to them, and the health monitors
+// that take failed addresses out of rotation.
+type GTMService struct {
+	api GTMAPI
+}
+
+func NewGTMService(api GTMAPI) *GTMService {
+	return &GTMService{api: api}
+}
+
+// GTMAddress is one address of a pool.
+type GTMAddress struct {
+	Addr string
+	// Weight only matters for RATIO pools. Zero means 1.
+	Weight int32
+	// Mode is SMART, ONLINE or OFFLINE. Empty means SMART.
+	Mode string
+	// Lines are the source regions the address belongs to. Empty means
+	// default.
+	Lines  []string
+	Remark string
+}
+
+type GTMMonitorNode struct {
+	ISPCode  string
+	CityCode string
+}
+
+// GTMMonitorInput configures a health check. Zero fields take defaults when
+// adding, or keep their current value when updating.
+type GTMMonitorInput struct {
+	// Protocol is HTTP, HTTPS, PING or TCP.
+	Protocol string
+	// Interval is in seconds, Timeout in milliseconds.
+	Interval        int32
+	Timeout         int32
+	EvaluationCount int32
+	// ExtendInfo is the protocol specific JSON, e.g. {"port":80,"path":"/"}.
+	ExtendInfo string
+	Nodes      []GTMMonitorNode
+}
+
+type GTMPoolInput struct {
+	InstanceID string
+	Name       string
+	// Type is IPV4, IPV6 or DOMAIN.
+	Type string
+	// LbaStrategy is ALL_RR or RATIO. Empty means ALL_RR.
+	LbaStrategy string
+	Addrs       []GTMAddress
+	// Monitor enables health checks on the pool when set.
+	Monitor *GTMMonitorInput
+}
+
+// GTMPoolWeight references a pool from an access strategy.
+type GTMPoolWeight struct {
+	ID     string
+	Weight int32
+}
+
+type GTMStrategyInput struct {
+	InstanceID string
+	Name       string
+	// Mode is GEO or LATENCY. Empty means GEO.
+	Mode string
+	// Lines are the DNS request sources routed by the strategy. Empty means
+	// default.
+	Lines []string
+	// PoolType is IPV4, IPV6 or DOMAIN and applies to both pool sets.
+	PoolType    string
+	LbaStrategy string
+	// MinAvailable is the number of healthy addresses below which traffic
+	// fails over. Zero means 1.
+	MinAvailable  int32
+	Pools         []GTMPoolWeight
+	FailoverPools []GTMPoolWeight
+}
+
+// Instances returns the GTM instances whose name or ID contains keyword,
+// walking all result pages.
+func (s *GTMService) Instances(ctx context.Context, keyword string) ([]*GTMInstance, error) {
+	all := []*GTMInstance{}
+	for page := int32(1); ; page++ {
+		req := &alidns20150109.DescribeDnsGtmInstancesRequest{
+			Lang:       tea.String("en"),
+			PageNumber: tea.Int32(page),
+			PageSize:   tea.Int32(gtmPageSize),
+		}
+		if keyword != "" {
+			req.Keyword = tea.String(keyword)
+		}
+		instances, err := s.api.DescribeDnsGtmInstances(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, instances...)
+		if int32(len(instances)) < gtmPageSize {
+			return all, nil
+		}
+	}
+}
+
+func (s *GTMService) Instance(ctx context.Context, instanceID string) (*alidns20150109.DescribeDnsGtmInstanceResponseBody, error) {
+	return s.api.DescribeDnsGtmInstance(ctx, &alidns20150109.DescribeDnsGtmInstanceRequest{
+		Lang:       tea.String("en"),
+		InstanceId: tea.String(instanceID),
+	})
+}
+
+// Pools returns every address pool of an instance.
+func (s *GTMService) Pools(ctx context.Context, instanceID string) ([]*GTMPool, error) {
+	all := []*GTMPool{}
+	for page := int32(1); ; page++ {
+		pools, err := s.api.DescribeDnsGtmInstanceAddressPools(ctx, &alidns20150109.DescribeDnsGtmInstanceAddressPoolsRequest{
+			Lang:       tea.String("en"),
+			InstanceId: tea.String(instanceID),
+			PageNumber: tea.Int32(page),
+			PageSize:   tea.Int32(gtmPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, pools...)
+		if int32(len(pools)) < gtmPageSize {
+			return all, nil
+		}
+	}
+}
+
+// Pool returns an address pool together with its addresses and their
+// health.
+func (s *GTMService) Pool(ctx context.Context, poolID string) (*alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody, error) {
+	return s.api.DescribeDnsGtmInstanceAddressPool(ctx, &alidns20150109.DescribeDnsGtmInstanceAddressPoolRequest{
+		Lang:       tea.String("en"),
+		AddrPoolId: tea.String(poolID),
+	})
+}
+
+func (s *GTMService) AddPool(ctx context.Context, in GTMPoolInput) (*alidns20150109.AddDnsGtmAddressPoolResponseBody, error) {
+	if len(in.Addrs) == 0 {
+		return nil, fmt.Errorf("address pool %q has no addresses", in.Name)
+	}
+	req := &alidns20150109.AddDnsGtmAddressPoolRequest{
+		Lang:          tea.String("en"),
+		InstanceId:    tea.String(in.InstanceID),
+		Name:          tea.String(in.Name),
+		Type:          tea.String(strings.ToUpper(in.Type)),
+		LbaStrategy:   tea.String(strings.ToUpper(defaultString(in.LbaStrategy, defaultGTMLbaStrategy))),
+		MonitorStatus: tea.String("CLOSE"),
+	}
+	for _, addr := range in.Addrs {
+		info, err := gtmAttributeInfo(addr.Lines)
+		if err != nil {
+			return nil, err
+		}
+		req.Addr = append(req.Addr, &alidns20150109.AddDnsGtmAddressPoolRequestAddr{
+			Addr:          tea.String(addr.Addr),
+			AttributeInfo: tea.String(info),
+			LbaWeight:     tea.Int32(defaultInt32(addr.Weight, 1)),
+			Mode:          tea.String(strings.ToUpper(defaultString(addr.Mode, defaultGTMMode))),
+			Remark:        optionalString(addr.Remark),
+		})
+	}
+	if m := in.Monitor; m != nil {
+		if len(m.Nodes) == 0 {
+			return nil, fmt.Errorf("health check of address pool %q needs at least one monitoring node", in.Name)
+		}
+		req.MonitorStatus = tea.String("OPEN")
+		req.ProtocolType = tea.String(strings.ToUpper(m.Protocol))
+		req.Interval = tea.Int32(defaultInt32(m.Interval, defaultGTMInterval))
+		req.Timeout = tea.Int32(defaultInt32(m.Timeout, defaultGTMTimeout))
+		req.EvaluationCount = tea.Int32(defaultInt32(m.EvaluationCount, defaultGTMEvaluations))
+		req.MonitorExtendInfo = optionalString(m.ExtendInfo)
+		for _, node := range m.Nodes {
+			req.IspCityNode = append(req.IspCityNode, &alidns20150109.AddDnsGtmAddressPoolRequestIspCityNode{
+				IspCode:  tea.String(node.ISPCode),
+				CityCode: tea.String(node.CityCode),
+			})
+		}
+	}
+	return s.api.AddDnsGtmAddressPool(ctx, req)
+}
+
+func (s *GTMService) DeletePool(ctx context.Context, poolID string) (*alidns20150109.DeleteDnsGtmAddressPoolResponseBody, error) {
+	return s.api.DeleteDnsGtmAddressPool(ctx, &alidns20150109.DeleteDnsGtmAddressPoolRequest{
+		Lang:       tea.String("en"),
+		AddrPoolId: tea.String(poolID),
+	})
+}
+
+// Strategies returns the access strategies of an instance. An empty mode
+// returns those of the instance's current strategy mode.
+func (s *GTMService) Strategies(ctx context.Context, instanceID, mode string) ([]*GTMStrategy, error) {
+	all := []*GTMStrategy{}
+	for page := int32(1); ; page++ {
+		req := &alidns20150109.DescribeDnsGtmAccessStrategiesRequest{
+			Lang:       tea.String("en"),
+			InstanceId: tea.String(instanceID),
+			PageNumber: tea.Int32(page),
+			PageSize:   tea.Int32(gtmPageSize),
+		}
+		if mode != "" {
+			req.StrategyMode = tea.String(strings.ToUpper(mode))
+		}
+		strategies, err := s.api.DescribeDnsGtmAccessStrategies(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, strategies...)
+		if int32(len(strategies)) < gtmPageSize {
+			return all, nil
+		}
+	}
+}
+
+func (s *GTMService) AddStrategy(ctx context.Context, in GTMStrategyInput) (*alidns20150109.AddDnsGtmAccessStrategyResponseBody, error) {
+	if len(in.Pools) == 0 {
+		return nil, fmt.Errorf("access strategy %q has no address pools", in.Name)
+	}
+	lines, err := json.Marshal(defaultLines(in.Lines))
+	if err != nil {
+		return nil, err
+	}
+	poolType := tea.String(strings.ToUpper(in.PoolType))
+	lba := tea.String(strings.ToUpper(defaultString(in.LbaStrategy, defaultGTMLbaStrategy)))
+	minAvailable := tea.Int32(defaultInt32(in.MinAvailable, 1))
+	req := &alidns20150109.AddDnsGtmAccessStrategyRequest{
+		Lang:                       tea.String("en"),
+		InstanceId:                 tea.String(in.InstanceID),
+		StrategyName:               tea.String(in.Name),
+		StrategyMode:               tea.String(strings.ToUpper(defaultString(in.Mode, defaultGTMStrategy))),
+		Lines:                      tea.String(string(lines)),
+		DefaultAddrPoolType:        poolType,
+		DefaultLbaStrategy:         lba,
+		DefaultMinAvailableAddrNum: minAvailable,
+	}
+	for _, pool := range in.Pools {
+		req.DefaultAddrPool = append(req.DefaultAddrPool, &alidns20150109.AddDnsGtmAccessStrategyRequestDefaultAddrPool{
+			Id:        tea.String(pool.ID),
+			LbaWeight: tea.Int32(defaultInt32(pool.Weight, 1)),
+		})
+	}
+	if len(in.FailoverPools) > 0 {
+		req.FailoverAddrPoolType = poolType
+		req.FailoverLbaStrategy = lba
+		req.FailoverMinAvailableAddrNum = minAvailable
+		for _, pool := range in.FailoverPools {
+			req.FailoverAddrPool = append(req.FailoverAddrPool, &alidns20150109.AddDnsGtmAccessStrategyRequestFailoverAddrPool{
+				Id:        tea.String(pool.ID),
+				LbaWeight: tea.Int32(defaultInt32(pool.Weight, 1)),
+			})
+		}
+	}
+	return s.api.AddDnsGtmAccessStrategy(ctx, req)
+}
+
+func (s *GTMService) DeleteStrategy(ctx context.Context, strategyID string) (*alidns20150109.DeleteDnsGtmAccessStrategyResponseBody, error) {
+	return s.api.DeleteDnsGtmAccessStrategy(ctx, &alidns20150109.DeleteDnsGtmAccessStrategyRequest{
+		Lang:       tea.String("en"),
+		StrategyId: tea.String(strategyID),
+	})
+}
+
+func (s *GTMService) Monitor(ctx context.Context, monitorID string) (*alidns20150109.DescribeDnsGtmMonitorConfigResponseBody, error) {
+	return s.api.DescribeDnsGtmMonitorConfig(ctx, &alidns20150109.DescribeDnsGtmMonitorConfigRequest{
+		Lang:            tea.String("en"),
+		MonitorConfigId: tea.String(monitorID),
+	})
+}
+
+func (s *GTMService) SetMonitorStatus(ctx context.Context, monitorID string, open bool) (*alidns20150109.SetDnsGtmMonitorStatusResponseBody, error) {
+	status := "CLOSE"
+	if open {
+		status = "OPEN"
+	}
+	return s.api.SetDnsGtmMonitorStatus(ctx, &alidns20150109.SetDnsGtmMonitorStatusRequest{
+		Lang:            tea.String("en"),
+		MonitorConfigId: tea.String(monitorID),
+		Status:          tea.String(status),
+	})
+}
+
+// UpdateMonitor changes a health check. The API replaces the whole
+// configuration, so fields left zero in the input are filled from the
+// current one.
+func (s *GTMService) UpdateMonitor(ctx context.Context, monitorID string, in GTMMonitorInput) (*alidns20150109.UpdateDnsGtmMonitorResponseBody, error) {
+	current, err := s.Monitor(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("health check %q not found", monitorID)
+	}
+	req := &alidns20150109.UpdateDnsGtmMonitorRequest{
+		Lang:              tea.String("en"),
+		MonitorConfigId:   tea.String(monitorID),
+		ProtocolType:      tea.String(strings.ToUpper(defaultString(in.Protocol, tea.StringValue(current.ProtocolType)))),
+		Interval:          tea.Int32(defaultInt32(in.Interval, tea.Int32Value(current.Interval))),
+		Timeout:           tea.Int32(defaultInt32(in.Timeout, tea.Int32Value(current.Timeout))),
+		EvaluationCount:   tea.Int32(defaultInt32(in.EvaluationCount, tea.Int32Value(current.EvaluationCount))),
+		MonitorExtendInfo: tea.String(defaultString(in.ExtendInfo, tea.StringValue(current.MonitorExtendInfo))),
+	}
+	nodes := in.Nodes
+	if len(nodes) == 0 && current.IspCityNodes != nil {
+		for _, node := range current.IspCityNodes.IspCityNode {
+			nodes = append(nodes, GTMMonitorNode{ISPCode: tea.StringValue(node.IspCode), CityCode: tea.StringValue(node.CityCode)})
+		}
+	}
+	for _, node := range nodes {
+		req.IspCityNode = append(req.IspCityNode, &alidns20150109.UpdateDnsGtmMonitorRequestIspCityNode{
+			IspCode:  tea.String(node.ISPCode),
+			CityCode: tea.String(node.CityCode),
+		})
+	}
+	return s.api.UpdateDnsGtmMonitor(ctx, req)
+}
+
+// gtmAttributeInfo encodes the source regions of an address the way
+// AddDnsGtmAddressPool expects them.
+func gtmAttributeInfo(lines []string) (string, error) {
+	data, err := json.Marshal(struct {
+		LineCodes           []string `json:"lineCodes"`
+		LineCodeRectifyType string   `json:"lineCodeRectifyType"`
+	}{LineCodes: defaultLines(lines), LineCodeRectifyType: "AUTO"})
+	return string(data), err
+}
+
+func defaultLines(lines []string) []string {
+	if len(lines) == 0 {
+		return []string{defaultLine}
+	}
+	return lines
+}
+
+func defaultInt32(v, fallback int32) int32 {
+	if v == 0 {
+		return fallback
+	}
+	return v
+}
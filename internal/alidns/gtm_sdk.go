@@ -0,0 +1,156 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+)
+
+// NewGTMSDKClient serves GTMAPI from the same SDK client as DNSAPI.
+func NewGTMSDKClient(client *alidns20150109.Client) GTMAPI {
+	return &sdkClient{client: client}
+}
+
+func (s *sdkClient) AddDnsGtmAccessStrategy(_ context.Context, req *alidns20150109.AddDnsGtmAccessStrategyRequest) (*alidns20150109.AddDnsGtmAccessStrategyResponseBody, error) {
+	resp, err := s.client.AddDnsGtmAccessStrategyWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) AddDnsGtmAddressPool(_ context.Context, req *alidns20150109.AddDnsGtmAddressPoolRequest) (*alidns20150109.AddDnsGtmAddressPoolResponseBody, error) {
+	resp, err := s.client.AddDnsGtmAddressPoolWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DeleteDnsGtmAccessStrategy(_ context.Context, req *alidns20150109.DeleteDnsGtmAccessStrategyRequest) (*alidns20150109.DeleteDnsGtmAccessStrategyResponseBody, error) {
+	resp, err := s.client.DeleteDnsGtmAccessStrategyWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DeleteDnsGtmAddressPool(_ context.Context, req *alidns20150109.DeleteDnsGtmAddressPoolRequest) (*alidns20150109.DeleteDnsGtmAddressPoolResponseBody, error) {
+	resp, err := s.client.DeleteDnsGtmAddressPoolWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DescribeDnsGtmAccessStrategies(_ context.Context, req *alidns20150109.DescribeDnsGtmAccessStrategiesRequest) ([]*alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy, error) {
+	resp, err := s.client.DescribeDnsGtmAccessStrategiesWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.Strategies == nil || resp.Body.Strategies.Strategy == nil {
+		return []*alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy{}, nil
+	}
+	return resp.Body.Strategies.Strategy, nil
+}
+
+func (s *sdkClient) DescribeDnsGtmInstance(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceRequest) (*alidns20150109.DescribeDnsGtmInstanceResponseBody, error) {
+	resp, err := s.client.DescribeDnsGtmInstanceWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DescribeDnsGtmInstanceAddressPool(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolRequest) (*alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody, error) {
+	resp, err := s.client.DescribeDnsGtmInstanceAddressPoolWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DescribeDnsGtmInstanceAddressPools(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolsRequest) ([]*alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool, error) {
+	resp, err := s.client.DescribeDnsGtmInstanceAddressPoolsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.AddrPools == nil || resp.Body.AddrPools.AddrPool == nil {
+		return []*alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool{}, nil
+	}
+	return resp.Body.AddrPools.AddrPool, nil
+}
+
+func (s *sdkClient) DescribeDnsGtmInstances(_ context.Context, req *alidns20150109.DescribeDnsGtmInstancesRequest) ([]*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances, error) {
+	resp, err := s.client.DescribeDnsGtmInstancesWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.GtmInstances == nil {
+		return []*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances{}, nil
+	}
+	return resp.Body.GtmInstances, nil
+}
+
+func (s *sdkClient) DescribeDnsGtmMonitorConfig(_ context.Context, req *alidns20150109.DescribeDnsGtmMonitorConfigRequest) (*alidns20150109.DescribeDnsGtmMonitorConfigResponseBody, error) {
+	resp, err := s.client.DescribeDnsGtmMonitorConfigWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) SetDnsGtmMonitorStatus(_ context.Context, req *alidns20150109.SetDnsGtmMonitorStatusRequest) (*alidns20150109.SetDnsGtmMonitorStatusResponseBody, error) {
+	resp, err := s.client.SetDnsGtmMonitorStatusWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) UpdateDnsGtmMonitor(_ context.Context, req *alidns20150109.UpdateDnsGtmMonitorRequest) (*alidns20150109.UpdateDnsGtmMonitorResponseBody, error) {
+	resp, err := s.client.UpdateDnsGtmMonitorWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"testing"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type fakeGTMAPI struct {
+	reqs []any
+
+	monitorResp *alidns20150109.DescribeDnsGtmMonitorConfigResponseBody
+}
+
+func (f *fakeGTMAPI) AddDnsGtmAccessStrategy(_ context.Context, req *alidns20150109.AddDnsGtmAccessStrategyRequest) (*alidns20150109.AddDnsGtmAccessStrategyResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.AddDnsGtmAccessStrategyResponseBody{StrategyId: tea.String("s-1")}, nil
+}
+
+func (f *fakeGTMAPI) AddDnsGtmAddressPool(_ context.Context, req *alidns20150109.AddDnsGtmAddressPoolRequest) (*alidns20150109.AddDnsGtmAddressPoolResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.AddDnsGtmAddressPoolResponseBody{AddrPoolId: tea.String("p-1")}, nil
+}
+
+func (f *fakeGTMAPI) DeleteDnsGtmAccessStrategy(_ context.Context, req *alidns20150109.DeleteDnsGtmAccessStrategyRequest) (*alidns20150109.DeleteDnsGtmAccessStrategyResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.DeleteDnsGtmAccessStrategyResponseBody{}, nil
+}
+
+func (f *fakeGTMAPI) DeleteDnsGtmAddressPool(_ context.Context, req *alidns20150109.DeleteDnsGtmAddressPoolRequest) (*alidns20150109.DeleteDnsGtmAddressPoolResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.DeleteDnsGtmAddressPoolResponseBody{}, nil
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmAccessStrategies(_ context.Context, req *alidns20150109.DescribeDnsGtmAccessStrategiesRequest) ([]*alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy, error) {
+	f.reqs = append(f.reqs, req)
+	return nil, nil
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstance(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceRequest) (*alidns20150109.DescribeDnsGtmInstanceResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.DescribeDnsGtmInstanceResponseBody{InstanceId: req.InstanceId}, nil
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstanceAddressPool(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolRequest) (*alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody{AddrPoolId: req.AddrPoolId}, nil
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstanceAddressPools(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolsRequest) ([]*alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool, error) {
+	f.reqs = append(f.reqs, req)
+	return nil, nil
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstances(_ context.Context, req *alidns20150109.DescribeDnsGtmInstancesRequest) ([]*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances, error) {
+	f.reqs = append(f.reqs, req)
+	return nil, nil
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmMonitorConfig(_ context.Context, req *alidns20150109.DescribeDnsGtmMonitorConfigRequest) (*alidns20150109.DescribeDnsGtmMonitorConfigResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return f.monitorResp, nil
+}
+
+func (f *fakeGTMAPI) SetDnsGtmMonitorStatus(_ context.Context, req *alidns20150109.SetDnsGtmMonitorStatusRequest) (*alidns20150109.SetDnsGtmMonitorStatusResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.SetDnsGtmMonitorStatusResponseBody{}, nil
+}
+
+func (f *fakeGTMAPI) UpdateDnsGtmMonitor(_ context.Context, req *alidns20150109.UpdateDnsGtmMonitorRequest) (*alidns20150109.UpdateDnsGtmMonitorResponseBody, error) {
+	f.reqs = append(f.reqs, req)
+	return &alidns20150109.UpdateDnsGtmMonitorResponseBody{}, nil
+}
+
+func TestGTMAddPoolWithHealthCheck(t *testing.T) {
+	api := &fakeGTMAPI{}
+	svc := NewGTMService(api)
+
+	_, err := svc.AddPool(context.Background(), GTMPoolInput{
+		InstanceID: "gtm-1",
+		Name:       "hz",
+		Type:       "ipv4",
+		Addrs:      []GTMAddress{{Addr: "1.1.1.1"}, {Addr: "2.2.2.2", Weight: 3, Mode: "online"}},
+		Monitor:    &GTMMonitorInput{Protocol: "http", Nodes: []GTMMonitorNode{{ISPCode: "465", CityCode: "503"}}},
+	})
+	if err != nil {
+		t.Fatalf("AddPool returned error: %v", err)
+	}
+	req := api.reqs[0].(*alidns20150109.AddDnsGtmAddressPoolRequest)
+	if tea.StringValue(req.Type) != "IPV4" || tea.StringValue(req.LbaStrategy) != "ALL_RR" || tea.StringValue(req.MonitorStatus) != "OPEN" {
+		t.Fatalf("unexpected pool request: %+v", req)
+	}
+	if tea.StringValue(req.ProtocolType) != "HTTP" || tea.Int32Value(req.Interval) != 60 || len(req.IspCityNode) != 1 {
+		t.Fatalf("unexpected health check: %+v", req)
+	}
+	first, second := req.Addr[0], req.Addr[1]
+	if tea.StringValue(first.Mode) != "SMART" || tea.Int32Value(first.LbaWeight) != 1 ||
+		tea.StringValue(first.AttributeInfo) != `{"lineCodes":["default"],"lineCodeRectifyType":"AUTO"}` {
+		t.Fatalf("unexpected first address: %+v", first)
+	}
+	if tea.StringValue(second.Mode) != "ONLINE" || tea.Int32Value(second.LbaWeight) != 3 {
+		t.Fatalf("unexpected second address: %+v", second)
+	}
+
+	if _, err := svc.AddPool(context.Background(), GTMPoolInput{Name: "empty", Type: "IPV4"}); err == nil {
+		t.Fatal("expected a pool without addresses to be rejected")
+	}
+}
+
+func TestGTMAddStrategyWithFailover(t *testing.T) {
+	api := &fakeGTMAPI{}
+	svc := NewGTMService(api)
+
+	_, err := svc.AddStrategy(context.Background(), GTMStrategyInput{
+		InstanceID:    "gtm-1",
+		Name:          "cn",
+		Lines:         []string{"default", "telecom"},
+		PoolType:      "IPV4",
+		Pools:         []GTMPoolWeight{{ID: "p-1"}},
+		FailoverPools: []GTMPoolWeight{{ID: "p-2", Weight: 2}},
+	})
+	if err != nil {
+		t.Fatalf("AddStrategy returned error: %v", err)
+	}
+	req := api.reqs[0].(*alidns20150109.AddDnsGtmAccessStrategyRequest)
+	if tea.StringValue(req.Lines) != `["default","telecom"]` || tea.StringValue(req.StrategyMode) != "GEO" {
+		t.Fatalf("unexpected strategy request: %+v", req)
+	}
+	if len(req.FailoverAddrPool) != 1 || tea.Int32Value(req.FailoverAddrPool[0].LbaWeight) != 2 || tea.StringValue(req.FailoverAddrPoolType) != "IPV4" {
+		t.Fatalf("unexpected failover pools: %+v", req)
+	}
+}
+
+func TestGTMUpdateMonitorKeepsCurrentConfig(t *testing.T) {
+	api := &fakeGTMAPI{monitorResp: &alidns20150109.DescribeDnsGtmMonitorConfigResponseBody{
+		ProtocolType:      tea.String("HTTP"),
+		Interval:          tea.Int32(60),
+		Timeout:           tea.Int32(5000),
+		EvaluationCount:   tea.Int32(2),
+		MonitorExtendInfo: tea.String(`{"port":80}`),
+		IspCityNodes: &alidns20150109.DescribeDnsGtmMonitorConfigResponseBodyIspCityNodes{
+			IspCityNode: []*alidns20150109.DescribeDnsGtmMonitorConfigResponseBodyIspCityNodesIspCityNode{
+				{IspCode: tea.String("465"), CityCode: tea.String("503")},
+			},
+		},
+	}}
+	svc := NewGTMService(api)
+
+	if _, err := svc.UpdateMonitor(context.Background(), "m-1", GTMMonitorInput{Interval: 15}); err != nil {
+		t.Fatalf("UpdateMonitor returned error: %v", err)
+	}
+	req := api.reqs[1].(*alidns20150109.UpdateDnsGtmMonitorRequest)
+	if tea.Int32Value(req.Interval) != 15 || tea.StringValue(req.ProtocolType) != "HTTP" || tea.Int32Value(req.EvaluationCount) != 2 {
+		t.Fatalf("unexpected monitor update: %+v", req)
+	}
+	if len(req.IspCityNode) != 1 || tea.StringValue(req.IspCityNode[0].CityCode) != "503" || tea.StringValue(req.MonitorExtendInfo) != `{"port":80}` {
+		t.Fatalf("expected current nodes and extend info to be kept: %+v", req)
+	}
+}
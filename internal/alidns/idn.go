@@ -0,0 +1,82 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+const acePrefix = "xn--"
+
+// labelSeparators are the full stops IDNA treats as equivalent to ".".
+var labelSeparators = strings.NewReplacer("。", ".", "．", ".", "｡", ".")
+
+// ToASCII converts a domain name or RR to IDNA A-labels, validating every
+// internationalized label per IDNA2008. Plain ASCII labels are passed through
+// untouched so that "@", "*" and "_acme-challenge" keep working.
+func ToASCII(name string) (string, error) {
+	labels := strings.Split(labelSeparators.Replace(name), ".")
+	for i, label := range labels {
+		if isASCII(label) && !hasACEPrefix(label) {
+			continue
+		}
+		ascii, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return "", fmt.Errorf("invalid internationalized name %q: %w", name, err)
+		}
+		labels[i] = ascii
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// ToUnicode decodes the A-labels of a domain name or RR for display. Labels
+// that do not decode cleanly are kept as they are.
+func ToUnicode(name string) string {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if !hasACEPrefix(label) {
+			continue
+		}
+		if unicode, err := idna.Lookup.ToUnicode(label); err == nil {
+			labels[i] = unicode
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// asciiNames rewrites each name to A-labels in place.
+func asciiNames(names ...*string) error {
+	for _, name := range names {
+		ascii, err := ToASCII(*name)
+		if err != nil {
+			return err
+		}
+		*name = ascii
+	}
+	return nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func hasACEPrefix(label string) bool {
+	return len(label) >= len(acePrefix) && strings.EqualFold(label[:len(acePrefix)], acePrefix)
+}
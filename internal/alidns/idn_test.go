@@ -0,0 +1,54 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestToASCIIConvertsOnlyInternationalizedLabels(t *testing.T) {
+	tests := map[string]string{
+		"例子.中国":              "xn--fsqu00a.xn--fiqs8s",
+		"例子。中国":              "xn--fsqu00a.xn--fiqs8s",
+		"_acme-challenge.例子": "_acme-challenge.xn--fsqu00a",
+		"*.www":              "*.www",
+		"@":                  "@",
+	}
+	for in, want := range tests {
+		got, err := ToASCII(in)
+		if err != nil || got != want {
+			t.Errorf("ToASCII(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	if _, err := ToASCII("xn--a.example"); err == nil {
+		t.Fatal("an invalid A-label should be rejected")
+	}
+	if got := ToUnicode("_acme.xn--fsqu00a.xn--fiqs8s"); got != "_acme.例子.中国" {
+		t.Fatalf("unexpected ToUnicode result %q", got)
+	}
+}
+
+func TestServiceAddSendsALabels(t *testing.T) {
+	api := &fakeAPI{}
+	svc := NewService(api)
+
+	if _, err := svc.Add(context.Background(), AddInput{DomainName: "例子.中国", Name: "邮件", Type: "A", Value: "1.2.3.4"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if tea.StringValue(api.addReq.DomainName) != "xn--fsqu00a.xn--fiqs8s" || tea.StringValue(api.addReq.RR) != "xn--5nq051n" {
+		t.Fatalf("unexpected add request: %+v", api.addReq)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const customLinePageSize int64 = 100
+
+type Line = alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine
+
+type CustomLine = alidns20150109.DescribeCustomLinesResponseBodyCustomLines
+
+// IPSegment is an inclusive address range routed to a custom line.
+type IPSegment struct {
+	StartIP string
+	EndIP   string
+}
+
+type AddCustomLineInput struct {
+	DomainName string
+	Name       string
+	Segments   []IPSegment
+}
+
+// ParseIPSegments parses a comma separated list of ranges such as
+// "1.1.1.0-1.1.1.255,2.2.2.2". A single address is a range of one.
+func ParseIPSegments(s string) ([]IPSegment, error) {
+	var segments []IPSegment
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		start, end, found := strings.Cut(part, "-")
+		if !found {
+			end = start
+		}
+		from, err := netip.ParseAddr(strings.TrimSpace(start))
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP segment %q: %w", part, err)
+		}
+		to, err := netip.ParseAddr(strings.TrimSpace(end))
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP segment %q: %w", part, err)
+		}
+		if from.Is4() != to.Is4() || to.Less(from) {
+			return nil, fmt.Errorf("invalid IP segment %q: end must not precede start", part)
+		}
+		segments = append(segments, IPSegment{StartIP: from.String(), EndIP: to.String()})
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no IP segments given")
+	}
+	return segments, nil
+}
+
+// SupportLines returns the lines records of domainName may use, including
+// the custom lines defined for it.
+func (s *Service) SupportLines(ctx context.Context, domainName string) ([]*Line, error) {
+	if err := asciiNames(&domainName); err != nil {
+		return nil, err
+	}
+	return s.api.DescribeSupportLines(ctx, &alidns20150109.DescribeSupportLinesRequest{
+		Lang:       tea.String("en"),
+		DomainName: tea.String(domainName),
+	})
+}
+
+// CustomLines returns every custom line of domainName, walking all result
+// pages.
+func (s *Service) CustomLines(ctx context.Context, domainName string) ([]*CustomLine, error) {
+	if err := asciiNames(&domainName); err != nil {
+		return nil, err
+	}
+	all := []*CustomLine{}
+	for page := int64(1); ; page++ {
+		lines, err := s.api.DescribeCustomLines(ctx, &alidns20150109.DescribeCustomLinesRequest{
+			Lang:       tea.String("en"),
+			DomainName: tea.String(domainName),
+			PageNumber: tea.Int64(page),
+			PageSize:   tea.Int64(customLinePageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, lines...)
+		if int64(len(lines)) < customLinePageSize {
+			return all, nil
+		}
+	}
+}
+
+func (s *Service) AddCustomLine(ctx context.Context, in AddCustomLineInput) (*alidns20150109.AddCustomLineResponseBody, error) {
+	if err := asciiNames(&in.DomainName); err != nil {
+		return nil, err
+	}
+	req := &alidns20150109.AddCustomLineRequest{
+		Lang:       tea.String("en"),
+		DomainName: tea.String(in.DomainName),
+		LineName:   tea.String(in.Name),
+	}
+	for _, seg := range in.Segments {
+		req.IpSegment = append(req.IpSegment, &alidns20150109.AddCustomLineRequestIpSegment{
+			StartIp: tea.String(seg.StartIP),
+			EndIp:   tea.String(seg.EndIP),
+		})
+	}
+	return s.api.AddCustomLine(ctx, req)
+}
+
+// DeleteCustomLine removes the custom line of domainName with the given name,
+// code or ID.
+func (s *Service) DeleteCustomLine(ctx context.Context, domainName, line string) (*alidns20150109.DeleteCustomLinesResponseBody, error) {
+	lines, err := s.CustomLines(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lines {
+		id := strconv.FormatInt(tea.Int64Value(l.Id), 10)
+		if tea.StringValue(l.Name) == line || tea.StringValue(l.Code) == line || id == line {
+			return s.api.DeleteCustomLines(ctx, &alidns20150109.DeleteCustomLinesRequest{
+				Lang:    tea.String("en"),
+				LineIds: tea.String(id),
+			})
+		}
+	}
+	return nil, fmt.Errorf("custom line %q not found in %s", line, domainName)
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestParseIPSegments(t *testing.T) {
+	segments, err := ParseIPSegments("1.1.1.0-1.1.1.255, 2.2.2.2")
+	if err != nil {
+		t.Fatalf("ParseIPSegments returned error: %v", err)
+	}
+	want := []IPSegment{{"1.1.1.0", "1.1.1.255"}, {"2.2.2.2", "2.2.2.2"}}
+	if !slices.Equal(segments, want) {
+		t.Fatalf("segments = %+v, want %+v", segments, want)
+	}
+
+	for _, bad := range []string{"", "1.1.1.9-1.1.1.1", "1.1.1.1-::1", "example.com"} {
+		if _, err := ParseIPSegments(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestServiceDeleteCustomLineByName(t *testing.T) {
+	api := &fakeAPI{customLineResp: []*alidns20150109.DescribeCustomLinesResponseBodyCustomLines{
+		{Id: tea.Int64(7), Name: tea.String("office"), Code: tea.String("cust7")},
+		{Id: tea.Int64(8), Name: tea.String("lab"), Code: tea.String("cust8")},
+	}}
+	svc := NewService(api)
+
+	if _, err := svc.DeleteCustomLine(context.Background(), "example.com", "lab"); err != nil {
+		t.Fatalf("DeleteCustomLine returned error: %v", err)
+	}
+	req, ok := api.lineReq[len(api.lineReq)-1].(*alidns20150109.DeleteCustomLinesRequest)
+	if !ok || tea.StringValue(req.LineIds) != "8" {
+		t.Fatalf("unexpected delete request: %+v", api.lineReq)
+	}
+
+	if _, err := svc.DeleteCustomLine(context.Background(), "example.com", "missing"); err == nil {
+		t.Fatal("expected an unknown custom line to be rejected")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const logPageSize int64 = 100
+
+const (
+	LogLevelRecord = "record"
+	LogLevelDomain = "domain"
+)
+
+type LogsInput struct {
+	// Level selects the record logs of DomainName (LogLevelRecord) or the
+	// domain-level logs (LogLevelDomain) such as DNSSEC changes and group
+	// moves, of DomainName when it is set and of the whole account
+	// otherwise. Empty picks record logs when DomainName is set and
+	// domain-level logs when it is not.
+	Level      string
+	DomainName string
+	// KeyWord filters the record logs. Domain-level logs can only be
+	// filtered by KeyWord when DomainName is empty.
+	KeyWord string
+	// Since drops entries older than it. The zero time keeps everything.
+	Since time.Time
+	// Limit caps the number of entries. Zero means no limit.
+	Limit int
+}
+
+// LogEntry is one operation from either the record or the domain log.
+type LogEntry struct {
+	DomainName      string `json:",omitempty"`
+	Action          string
+	ActionTime      string
+	ActionTimestamp int64
+	ClientIp        string
+	Message         string
+}
+
+// Logs returns operation log entries, newest first, walking result pages
+// until Since or Limit is reached.
+func (s *Service) Logs(ctx context.Context, in LogsInput) ([]*LogEntry, error) {
+	if err := asciiNames(&in.DomainName); err != nil {
+		return nil, err
+	}
+	level := in.Level
+	if level == "" {
+		level = LogLevelDomain
+		if in.DomainName != "" {
+			level = LogLevelRecord
+		}
+	}
+	switch {
+	case level != LogLevelRecord && level != LogLevelDomain:
+		return nil, fmt.Errorf("unknown log level %q", in.Level)
+	case level == LogLevelRecord && in.DomainName == "":
+		return nil, fmt.Errorf("record logs need a domain name")
+	case level == LogLevelDomain && in.DomainName != "" && in.KeyWord != "":
+		return nil, fmt.Errorf("domain logs of one domain cannot be filtered by keyword")
+	}
+
+	var startDate *string
+	if !in.Since.IsZero() {
+		startDate = tea.String(in.Since.In(APIZone).Format(time.DateOnly))
+	}
+	var keyWord *string
+	if in.KeyWord != "" {
+		keyWord = tea.String(in.KeyWord)
+	}
+	if level == LogLevelDomain && in.DomainName != "" {
+		// DescribeDomainLogs has no domain parameter; its keyword matches
+		// domain names by substring, so the result is narrowed below.
+		keyWord = tea.String(in.DomainName)
+	}
+
+	entries := []*LogEntry{}
+	for page := int64(1); ; page++ {
+		var batch []*LogEntry
+		if level == LogLevelRecord {
+			logs, err := s.api.DescribeRecordLogs(ctx, &alidns20150109.DescribeRecordLogsRequest{
+				Lang:       tea.String("en"),
+				DomainName: tea.String(in.DomainName),
+				KeyWord:    keyWord,
+				StartDate:  startDate,
+				PageNumber: tea.Int64(page),
+				PageSize:   tea.Int64(logPageSize),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range logs {
+				batch = append(batch, &LogEntry{
+					DomainName:      in.DomainName,
+					Action:          tea.StringValue(l.Action),
+					ActionTime:      tea.StringValue(l.ActionTime),
+					ActionTimestamp: tea.Int64Value(l.ActionTimestamp),
+					ClientIp:        tea.StringValue(l.ClientIp),
+					Message:         tea.StringValue(l.Message),
+				})
+			}
+		} else {
+			logs, err := s.api.DescribeDomainLogs(ctx, &alidns20150109.DescribeDomainLogsRequest{
+				Lang:       tea.String("en"),
+				KeyWord:    keyWord,
+				StartDate:  startDate,
+				PageNumber: tea.Int64(page),
+				PageSize:   tea.Int64(logPageSize),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range logs {
+				batch = append(batch, &LogEntry{
+					DomainName:      tea.StringValue(l.DomainName),
+					Action:          tea.StringValue(l.Action),
+					ActionTime:      tea.StringValue(l.ActionTime),
+					ActionTimestamp: tea.Int64Value(l.ActionTimestamp),
+					ClientIp:        tea.StringValue(l.ClientIp),
+					Message:         tea.StringValue(l.Message),
+				})
+			}
+		}
+
+		for _, entry := range batch {
+			// StartDate only has day precision; the timestamp is in
+			// milliseconds.
+			if !in.Since.IsZero() && entry.ActionTimestamp > 0 && entry.ActionTimestamp < in.Since.UnixMilli() {
+				return entries, nil
+			}
+			if level == LogLevelDomain && in.DomainName != "" && !strings.EqualFold(entry.DomainName, in.DomainName) {
+				continue
+			}
+			entries = append(entries, entry)
+			if in.Limit > 0 && len(entries) >= in.Limit {
+				return entries, nil
+			}
+		}
+		if int64(len(batch)) < logPageSize {
+			return entries, nil
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func recordLogPage(first, n int, at time.Time) []*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog {
+	page := make([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, 0, n)
+	for i := range n {
+		page = append(page, &alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog{
+			Action:          tea.String("ADD"),
+			ActionTimestamp: tea.Int64(at.Add(-time.Duration(first+i) * time.Minute).UnixMilli()),
+		})
+	}
+	return page
+}
+
+func TestServiceLogsPagesUntilSince(t *testing.T) {
+	now := time.Now()
+	api := &fakeAPI{recordLogResp: [][]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog{
+		recordLogPage(0, int(logPageSize), now),
+		recordLogPage(int(logPageSize), int(logPageSize), now),
+	}}
+	svc := NewService(api)
+
+	entries, err := svc.Logs(context.Background(), LogsInput{DomainName: "example.com", Since: now.Add(-150*time.Minute + 30*time.Second)})
+	if err != nil {
+		t.Fatalf("Logs returned error: %v", err)
+	}
+	if len(entries) != 150 {
+		t.Fatalf("expected entries of the last 150 minutes, got %d", len(entries))
+	}
+	if len(api.logReq) != 2 {
+		t.Fatalf("expected two pages to be read, got %d", len(api.logReq))
+	}
+	req := api.logReq[0].(*alidns20150109.DescribeRecordLogsRequest)
+	if tea.StringValue(req.DomainName) != "example.com" || tea.StringValue(req.StartDate) == "" || req.KeyWord != nil {
+		t.Fatalf("unexpected record log request: %+v", req)
+	}
+}
+
+func TestServiceLogsStopsAtLimit(t *testing.T) {
+	api := &fakeAPI{domainLogResp: [][]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog{
+		{{DomainName: tea.String("a.com")}, {DomainName: tea.String("b.com")}, {DomainName: tea.String("c.com")}},
+	}}
+	svc := NewService(api)
+
+	entries, err := svc.Logs(context.Background(), LogsInput{KeyWord: "com", Limit: 2})
+	if err != nil {
+		t.Fatalf("Logs returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[1].DomainName != "b.com" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	req := api.logReq[0].(*alidns20150109.DescribeDomainLogsRequest)
+	if tea.StringValue(req.KeyWord) != "com" || req.StartDate != nil {
+		t.Fatalf("unexpected domain log request: %+v", req)
+	}
+}
+
+func TestServiceLogsDomainLevelOfOneDomain(t *testing.T) {
+	api := &fakeAPI{domainLogResp: [][]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog{
+		{{DomainName: tea.String("example.com"), Action: tea.String("DNSSEC")}, {DomainName: tea.String("myexample.com")}},
+	}}
+	svc := NewService(api)
+
+	entries, err := svc.Logs(context.Background(), LogsInput{Level: LogLevelDomain, DomainName: "example.com"})
+	if err != nil {
+		t.Fatalf("Logs returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Action != "DNSSEC" {
+		t.Fatalf("expected only the entries of example.com, got %+v", entries)
+	}
+	req := api.logReq[0].(*alidns20150109.DescribeDomainLogsRequest)
+	if tea.StringValue(req.KeyWord) != "example.com" {
+		t.Fatalf("unexpected domain log request: %+v", req)
+	}
+
+	if _, err := svc.Logs(context.Background(), LogsInput{Level: LogLevelRecord}); err == nil {
+		t.Fatal("record logs without a domain should be rejected")
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"strings"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+)
+
+// Call is one DNSAPI operation on its way through a middleware chain.
+type Call struct {
+	// Operation is the DNSAPI method name, such as AddDomainRecord.
+	Operation string
+	// Request is the SDK request of the operation, such as
+	// *alidns20150109.AddDomainRecordRequest.
+	Request any
+
+	invoke func(ctx context.Context) (any, error)
+}
+
+// ReadOnly reports whether the operation only reads.
+func (c *Call) ReadOnly() bool {
+	return strings.HasPrefix(c.Operation, "Describe")
+}
+
+// Handler performs a call and returns the SDK response of the operation.
+type Handler func(ctx context.Context, call *Call) (any, error)
+
+// Middleware decorates a Handler. It may inspect or change the call, return
+// early, or run next any number of times.
+type Middleware func(next Handler) Handler
+
+// Wrap returns api with every operation passing through mws. The first
+// middleware is the outermost: it sees a call first and its result last.
+func Wrap(api DNSAPI, mws ...Middleware) DNSAPI {
+	if len(mws) == 0 {
+		return api
+	}
+	return &wrappedAPI{api: api, handler: chain(mws)}
+}
+
+func chain(mws []Middleware) Handler {
+	h := Handler(func(ctx context.Context, call *Call) (any, error) {
+		return call.invoke(ctx)
+	})
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+type wrappedAPI struct {
+	api     DNSAPI
+	handler Handler
+}
+
+func invoke[Resp any](ctx context.Context, h Handler, op string, req any, call func(context.Context) (Resp, error)) (Resp, error) {
+	out, err := h(ctx, &Call{
+		Operation: op,
+		Request:   req,
+		invoke: func(ctx context.Context) (any, error) {
+			return call(ctx)
+		},
+	})
+	resp, _ := out.(Resp)
+	return resp, err
+}
+
+func (w *wrappedAPI) AddCustomLine(ctx context.Context, req *alidns20150109.AddCustomLineRequest) (*alidns20150109.AddCustomLineResponseBody, error) {
+	return invoke(ctx, w.handler, "AddCustomLine", req, func(ctx context.Context) (*alidns20150109.AddCustomLineResponseBody, error) {
+		return w.api.AddCustomLine(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) AddDomainGroup(ctx context.Context, req *alidns20150109.AddDomainGroupRequest) (*alidns20150109.AddDomainGroupResponseBody, error) {
+	return invoke(ctx, w.handler, "AddDomainGroup", req, func(ctx context.Context) (*alidns20150109.AddDomainGroupResponseBody, error) {
+		return w.api.AddDomainGroup(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) AddDomainRecord(ctx context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	return invoke(ctx, w.handler, "AddDomainRecord", req, func(ctx context.Context) (*alidns20150109.AddDomainRecordResponseBody, error) {
+		return w.api.AddDomainRecord(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) ChangeDomainGroup(ctx context.Context, req *alidns20150109.ChangeDomainGroupRequest) (*alidns20150109.ChangeDomainGroupResponseBody, error) {
+	return invoke(ctx, w.handler, "ChangeDomainGroup", req, func(ctx context.Context) (*alidns20150109.ChangeDomainGroupResponseBody, error) {
+		return w.api.ChangeDomainGroup(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DeleteCustomLines(ctx context.Context, req *alidns20150109.DeleteCustomLinesRequest) (*alidns20150109.DeleteCustomLinesResponseBody, error) {
+	return invoke(ctx, w.handler, "DeleteCustomLines", req, func(ctx context.Context) (*alidns20150109.DeleteCustomLinesResponseBody, error) {
+		return w.api.DeleteCustomLines(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DeleteDomainGroup(ctx context.Context, req *alidns20150109.DeleteDomainGroupRequest) (*alidns20150109.DeleteDomainGroupResponseBody, error) {
+	return invoke(ctx, w.handler, "DeleteDomainGroup", req, func(ctx context.Context) (*alidns20150109.DeleteDomainGroupResponseBody, error) {
+		return w.api.DeleteDomainGroup(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DeleteDomainRecord(ctx context.Context, req *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	return invoke(ctx, w.handler, "DeleteDomainRecord", req, func(ctx context.Context) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+		return w.api.DeleteDomainRecord(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DeleteSubDomainRecords(ctx context.Context, req *alidns20150109.DeleteSubDomainRecordsRequest) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
+	return invoke(ctx, w.handler, "DeleteSubDomainRecords", req, func(ctx context.Context) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
+		return w.api.DeleteSubDomainRecords(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeCustomLines(ctx context.Context, req *alidns20150109.DescribeCustomLinesRequest) ([]*alidns20150109.DescribeCustomLinesResponseBodyCustomLines, error) {
+	return invoke(ctx, w.handler, "DescribeCustomLines", req, func(ctx context.Context) ([]*alidns20150109.DescribeCustomLinesResponseBodyCustomLines, error) {
+		return w.api.DescribeCustomLines(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDNSSLBSubDomains(ctx context.Context, req *alidns20150109.DescribeDNSSLBSubDomainsRequest) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error) {
+	return invoke(ctx, w.handler, "DescribeDNSSLBSubDomains", req, func(ctx context.Context) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error) {
+		return w.api.DescribeDNSSLBSubDomains(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomainDnssecInfo(ctx context.Context, req *alidns20150109.DescribeDomainDnssecInfoRequest) (*alidns20150109.DescribeDomainDnssecInfoResponseBody, error) {
+	return invoke(ctx, w.handler, "DescribeDomainDnssecInfo", req, func(ctx context.Context) (*alidns20150109.DescribeDomainDnssecInfoResponseBody, error) {
+		return w.api.DescribeDomainDnssecInfo(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomainGroups(ctx context.Context, req *alidns20150109.DescribeDomainGroupsRequest) ([]*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error) {
+	return invoke(ctx, w.handler, "DescribeDomainGroups", req, func(ctx context.Context) ([]*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error) {
+		return w.api.DescribeDomainGroups(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomainLogs(ctx context.Context, req *alidns20150109.DescribeDomainLogsRequest) ([]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog, error) {
+	return invoke(ctx, w.handler, "DescribeDomainLogs", req, func(ctx context.Context) ([]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog, error) {
+		return w.api.DescribeDomainLogs(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomainRecordInfo(ctx context.Context, req *alidns20150109.DescribeDomainRecordInfoRequest) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+	return invoke(ctx, w.handler, "DescribeDomainRecordInfo", req, func(ctx context.Context) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+		return w.api.DescribeDomainRecordInfo(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomainStatistics(ctx context.Context, req *alidns20150109.DescribeDomainStatisticsRequest) ([]*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic, error) {
+	return invoke(ctx, w.handler, "DescribeDomainStatistics", req, func(ctx context.Context) ([]*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic, error) {
+		return w.api.DescribeDomainStatistics(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomainStatisticsSummary(ctx context.Context, req *alidns20150109.DescribeDomainStatisticsSummaryRequest) ([]*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	return invoke(ctx, w.handler, "DescribeDomainStatisticsSummary", req, func(ctx context.Context) ([]*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+		return w.api.DescribeDomainStatisticsSummary(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomains(ctx context.Context, req *alidns20150109.DescribeDomainsRequest) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	return invoke(ctx, w.handler, "DescribeDomains", req, func(ctx context.Context) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+		return w.api.DescribeDomains(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeDomainRecords(ctx context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	return invoke(ctx, w.handler, "DescribeDomainRecords", req, func(ctx context.Context) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+		return w.api.DescribeDomainRecords(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeRecordLogs(ctx context.Context, req *alidns20150109.DescribeRecordLogsRequest) ([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, error) {
+	return invoke(ctx, w.handler, "DescribeRecordLogs", req, func(ctx context.Context) ([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, error) {
+		return w.api.DescribeRecordLogs(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeRecordStatistics(ctx context.Context, req *alidns20150109.DescribeRecordStatisticsRequest) ([]*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic, error) {
+	return invoke(ctx, w.handler, "DescribeRecordStatistics", req, func(ctx context.Context) ([]*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic, error) {
+		return w.api.DescribeRecordStatistics(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeRecordStatisticsSummary(ctx context.Context, req *alidns20150109.DescribeRecordStatisticsSummaryRequest) ([]*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	return invoke(ctx, w.handler, "DescribeRecordStatisticsSummary", req, func(ctx context.Context) ([]*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+		return w.api.DescribeRecordStatisticsSummary(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) DescribeSupportLines(ctx context.Context, req *alidns20150109.DescribeSupportLinesRequest) ([]*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine, error) {
+	return invoke(ctx, w.handler, "DescribeSupportLines", req, func(ctx context.Context) ([]*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine, error) {
+		return w.api.DescribeSupportLines(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) SetDNSSLBStatus(ctx context.Context, req *alidns20150109.SetDNSSLBStatusRequest) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+	return invoke(ctx, w.handler, "SetDNSSLBStatus", req, func(ctx context.Context) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+		return w.api.SetDNSSLBStatus(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) SetDomainDnssecStatus(ctx context.Context, req *alidns20150109.SetDomainDnssecStatusRequest) (*alidns20150109.SetDomainDnssecStatusResponseBody, error) {
+	return invoke(ctx, w.handler, "SetDomainDnssecStatus", req, func(ctx context.Context) (*alidns20150109.SetDomainDnssecStatusResponseBody, error) {
+		return w.api.SetDomainDnssecStatus(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) SetDomainRecordStatus(ctx context.Context, req *alidns20150109.SetDomainRecordStatusRequest) (*alidns20150109.SetDomainRecordStatusResponseBody, error) {
+	return invoke(ctx, w.handler, "SetDomainRecordStatus", req, func(ctx context.Context) (*alidns20150109.SetDomainRecordStatusResponseBody, error) {
+		return w.api.SetDomainRecordStatus(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) UpdateDNSSLBWeight(ctx context.Context, req *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	return invoke(ctx, w.handler, "UpdateDNSSLBWeight", req, func(ctx context.Context) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+		return w.api.UpdateDNSSLBWeight(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) UpdateDomainGroup(ctx context.Context, req *alidns20150109.UpdateDomainGroupRequest) (*alidns20150109.UpdateDomainGroupResponseBody, error) {
+	return invoke(ctx, w.handler, "UpdateDomainGroup", req, func(ctx context.Context) (*alidns20150109.UpdateDomainGroupResponseBody, error) {
+		return w.api.UpdateDomainGroup(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) UpdateDomainRecord(ctx context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+	return invoke(ctx, w.handler, "UpdateDomainRecord", req, func(ctx context.Context) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+		return w.api.UpdateDomainRecord(ctx, req)
+	})
+}
+
+func (w *wrappedAPI) UpdateDomainRecordRemark(ctx context.Context, req *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	return invoke(ctx, w.handler, "UpdateDomainRecordRemark", req, func(ctx context.Context) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+		return w.api.UpdateDomainRecordRemark(ctx, req)
+	})
+}
+
+// WrapGTM is Wrap for GTMAPI.
+func WrapGTM(api GTMAPI, mws ...Middleware) GTMAPI {
+	if len(mws) == 0 {
+		return api
+	}
+	return &wrappedGTMAPI{api: api, handler: chain(mws)}
+}
+
+type wrappedGTMAPI struct {
+	api     GTMAPI
+	handler Handler
+}
+
+func (g *wrappedGTMAPI) AddDnsGtmAccessStrategy(ctx context.Context, req *alidns20150109.AddDnsGtmAccessStrategyRequest) (*alidns20150109.AddDnsGtmAccessStrategyResponseBody, error) {
+	return invoke(ctx, g.handler, "AddDnsGtmAccessStrategy", req, func(ctx context.Context) (*alidns20150109.AddDnsGtmAccessStrategyResponseBody, error) {
+		return g.api.AddDnsGtmAccessStrategy(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) AddDnsGtmAddressPool(ctx context.Context, req *alidns20150109.AddDnsGtmAddressPoolRequest) (*alidns20150109.AddDnsGtmAddressPoolResponseBody, error) {
+	return invoke(ctx, g.handler, "AddDnsGtmAddressPool", req, func(ctx context.Context) (*alidns20150109.AddDnsGtmAddressPoolResponseBody, error) {
+		return g.api.AddDnsGtmAddressPool(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DeleteDnsGtmAccessStrategy(ctx context.Context, req *alidns20150109.DeleteDnsGtmAccessStrategyRequest) (*alidns20150109.DeleteDnsGtmAccessStrategyResponseBody, error) {
+	return invoke(ctx, g.handler, "DeleteDnsGtmAccessStrategy", req, func(ctx context.Context) (*alidns20150109.DeleteDnsGtmAccessStrategyResponseBody, error) {
+		return g.api.DeleteDnsGtmAccessStrategy(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DeleteDnsGtmAddressPool(ctx context.Context, req *alidns20150109.DeleteDnsGtmAddressPoolRequest) (*alidns20150109.DeleteDnsGtmAddressPoolResponseBody, error) {
+	return invoke(ctx, g.handler, "DeleteDnsGtmAddressPool", req, func(ctx context.Context) (*alidns20150109.DeleteDnsGtmAddressPoolResponseBody, error) {
+		return g.api.DeleteDnsGtmAddressPool(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DescribeDnsGtmAccessStrategies(ctx context.Context, req *alidns20150109.DescribeDnsGtmAccessStrategiesRequest) ([]*alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy, error) {
+	return invoke(ctx, g.handler, "DescribeDnsGtmAccessStrategies", req, func(ctx context.Context) ([]*alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy, error) {
+		return g.api.DescribeDnsGtmAccessStrategies(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DescribeDnsGtmInstance(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstanceRequest) (*alidns20150109.DescribeDnsGtmInstanceResponseBody, error) {
+	return invoke(ctx, g.handler, "DescribeDnsGtmInstance", req, func(ctx context.Context) (*alidns20150109.DescribeDnsGtmInstanceResponseBody, error) {
+		return g.api.DescribeDnsGtmInstance(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DescribeDnsGtmInstanceAddressPool(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolRequest) (*alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody, error) {
+	return invoke(ctx, g.handler, "DescribeDnsGtmInstanceAddressPool", req, func(ctx context.Context) (*alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody, error) {
+		return g.api.DescribeDnsGtmInstanceAddressPool(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DescribeDnsGtmInstanceAddressPools(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolsRequest) ([]*alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool, error) {
+	return invoke(ctx, g.handler, "DescribeDnsGtmInstanceAddressPools", req, func(ctx context.Context) ([]*alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool, error) {
+		return g.api.DescribeDnsGtmInstanceAddressPools(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DescribeDnsGtmInstances(ctx context.Context, req *alidns20150109.DescribeDnsGtmInstancesRequest) ([]*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances, error) {
+	return invoke(ctx, g.handler, "DescribeDnsGtmInstances", req, func(ctx context.Context) ([]*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances, error) {
+		return g.api.DescribeDnsGtmInstances(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) DescribeDnsGtmMonitorConfig(ctx context.Context, req *alidns20150109.DescribeDnsGtmMonitorConfigRequest) (*alidns20150109.DescribeDnsGtmMonitorConfigResponseBody, error) {
+	return invoke(ctx, g.handler, "DescribeDnsGtmMonitorConfig", req, func(ctx context.Context) (*alidns20150109.DescribeDnsGtmMonitorConfigResponseBody, error) {
+		return g.api.DescribeDnsGtmMonitorConfig(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) SetDnsGtmMonitorStatus(ctx context.Context, req *alidns20150109.SetDnsGtmMonitorStatusRequest) (*alidns20150109.SetDnsGtmMonitorStatusResponseBody, error) {
+	return invoke(ctx, g.handler, "SetDnsGtmMonitorStatus", req, func(ctx context.Context) (*alidns20150109.SetDnsGtmMonitorStatusResponseBody, error) {
+		return g.api.SetDnsGtmMonitorStatus(ctx, req)
+	})
+}
+
+func (g *wrappedGTMAPI) UpdateDnsGtmMonitor(ctx context.Context, req *alidns20150109.UpdateDnsGtmMonitorRequest) (*alidns20150109.UpdateDnsGtmMonitorResponseBody, error) {
+	return invoke(ctx, g.handler, "UpdateDnsGtmMonitor", req, func(ctx context.Context) (*alidns20150109.UpdateDnsGtmMonitorResponseBody, error) {
+		return g.api.UpdateDnsGtmMonitor(ctx, req)
+	})
+}
+
+// WrapPvtz is Wrap for PvtzAPI; the zone methods pass through mws too, with
+// the zone ID or nil as their request.
+func WrapPvtz(api PvtzAPI, mws ...Middleware) PvtzAPI {
+	if len(mws) == 0 {
+		return api
+	}
+	return &wrappedPvtzAPI{wrappedAPI: &wrappedAPI{api: api, handler: chain(mws)}, pvtz: api}
+}
+
+type wrappedPvtzAPI struct {
+	*wrappedAPI
+	pvtz PvtzAPI
+}
+
+func (w *wrappedPvtzAPI) BindZoneVpc(ctx context.Context, req *BindZoneVpcRequest) (*BindZoneVpcResponseBody, error) {
+	return invoke(ctx, w.handler, "BindZoneVpc", req, func(ctx context.Context) (*BindZoneVpcResponseBody, error) {
+		return w.pvtz.BindZoneVpc(ctx, req)
+	})
+}
+
+func (w *wrappedPvtzAPI) DescribeZoneInfo(ctx context.Context, zoneID string) (*PvtzZone, error) {
+	return invoke(ctx, w.handler, "DescribeZoneInfo", zoneID, func(ctx context.Context) (*PvtzZone, error) {
+		return w.pvtz.DescribeZoneInfo(ctx, zoneID)
+	})
+}
+
+func (w *wrappedPvtzAPI) DescribeZones(ctx context.Context) ([]*PvtzZone, error) {
+	return invoke(ctx, w.handler, "DescribeZones", nil, func(ctx context.Context) ([]*PvtzZone, error) {
+		return w.pvtz.DescribeZones(ctx)
+	})
+}
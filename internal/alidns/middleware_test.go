@@ -0,0 +1,127 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// throttledAPI rejects the first failures adds with err.
+type throttledAPI struct {
+	*fakeAPI
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *throttledAPI) AddDomainRecord(ctx context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return f.fakeAPI.AddDomainRecord(ctx, req)
+}
+
+func TestWrapPassesCallsThroughMiddlewaresInOrder(t *testing.T) {
+	api := &fakeAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1")}}
+	var trace []string
+	var seen *Call
+	mark := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) (any, error) {
+				trace = append(trace, name+">")
+				seen = call
+				resp, err := next(ctx, call)
+				trace = append(trace, "<"+name)
+				return resp, err
+			}
+		}
+	}
+
+	resp, err := NewService(Wrap(api, mark("a"), mark("b"))).Add(context.Background(), AddInput{DomainName: "example.com", Name: "www", Type: "A", Value: "1.2.3.4"})
+	if err != nil || tea.StringValue(resp.RecordId) != "r-1" {
+		t.Fatalf("Add = %v, %v", resp, err)
+	}
+	if want := []string{"a>", "b>", "<b", "<a"}; !reflect.DeepEqual(trace, want) {
+		t.Fatalf("trace = %v, want %v", trace, want)
+	}
+	if seen.Operation != "AddDomainRecord" || seen.Request != any(api.addReq) || seen.ReadOnly() {
+		t.Fatalf("unexpected call %+v", seen)
+	}
+	if Wrap(api) != DNSAPI(api) {
+		t.Fatal("Wrap without middlewares should return api itself")
+	}
+}
+
+func TestRetryRetriesOnlyThrottledCalls(t *testing.T) {
+	throttled := &tea.SDKError{Code: tea.String("Throttling.User"), Message: tea.String("slow down")}
+	api := &throttledAPI{fakeAPI: &fakeAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{}}, failures: 2, err: throttled}
+	svc := NewService(Wrap(api, Retry(3, time.Millisecond)))
+	if _, err := svc.Add(context.Background(), AddInput{DomainName: "example.com", Name: "www", Type: "A", Value: "1.2.3.4"}); err != nil || api.calls != 3 {
+		t.Fatalf("expected success on the third attempt, got %v after %d calls", err, api.calls)
+	}
+
+	denied := &tea.SDKError{Code: tea.String("Forbidden.RAM")}
+	api = &throttledAPI{fakeAPI: &fakeAPI{}, failures: 2, err: denied}
+	svc = NewService(Wrap(api, Retry(3, time.Millisecond)))
+	if _, err := svc.Add(context.Background(), AddInput{DomainName: "example.com", Name: "www", Type: "A", Value: "1.2.3.4"}); ErrorCode(err) != "Forbidden.RAM" || api.calls != 1 {
+		t.Fatalf("expected no retry for %v, got %d calls", err, api.calls)
+	}
+}
+
+func TestReadOnlyRefusesChangesAndLogAudits(t *testing.T) {
+	api := &fakeAPI{}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	svc := NewService(Wrap(api, Log(logger), ReadOnly()))
+
+	_, err := svc.Add(context.Background(), AddInput{DomainName: "example.com", Name: "www", Type: "A", Value: "1.2.3.4"})
+	if !errors.Is(err, ErrReadOnly) || api.addReq != nil {
+		t.Fatalf("expected the add to be refused, got %v", err)
+	}
+	if _, err := svc.Records(context.Background(), "example.com"); err != nil {
+		t.Fatalf("reads should pass, got %v", err)
+	}
+	got := logs.String()
+	if !strings.Contains(got, "level=WARN") || !strings.Contains(got, "op=AddDomainRecord") || !strings.Contains(got, "1.2.3.4") {
+		t.Fatalf("expected the refused add to be logged with its request:\n%s", got)
+	}
+	if strings.Contains(got, "DescribeDomainRecords") {
+		t.Fatalf("reads are logged at debug level only:\n%s", got)
+	}
+}
+
+func TestWrapPvtzObservesZoneMethods(t *testing.T) {
+	fake := &fakePvtz{responses: map[string]string{"DescribeZones": pvtzZonesBody}}
+	var ops []string
+	api := WrapPvtz(newPvtzClient(fake.call), Observe(func(op string, _ time.Duration, _ error) {
+		ops = append(ops, op)
+	}))
+
+	zones, err := api.DescribeZones(context.Background())
+	if err != nil || len(zones) == 0 {
+		t.Fatalf("DescribeZones = %v, %v", zones, err)
+	}
+	if !reflect.DeepEqual(ops, []string{"DescribeZones"}) {
+		t.Fatalf("observed %v", ops)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrReadOnly is returned by the ReadOnly middleware for operations that
+// would change something.
+var ErrReadOnly = errors.New("operation refused: the API is read-only")
+
+// Observe reports the operation, duration and error of every call, for
+// metrics or tracing.
+func Observe(fn func(op string, d time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (any, error) {
+			start := time.Now()
+			resp, err := next(ctx, call)
+			fn(call.Operation, time.Since(start), err)
+			return resp, err
+		}
+	}
+}
+
+// Log writes one entry per call: reads at debug level, changes at info level
+// together with their request as an audit trail, and failures at warn level
+// with the API error code.
+func Log(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (any, error) {
+			start := time.Now()
+			resp, err := next(ctx, call)
+			attrs := []any{slog.String("op", call.Operation), slog.Duration("duration", time.Since(start))}
+			if !call.ReadOnly() {
+				attrs = append(attrs, slog.Any("request", call.Request))
+			}
+			switch {
+			case err != nil:
+				attrs = append(attrs, slog.String("code", ErrorCode(err)), slog.Any("error", err))
+				logger.WarnContext(ctx, "alidns api call failed", attrs...)
+			case call.ReadOnly():
+				logger.DebugContext(ctx, "alidns api call", attrs...)
+			default:
+				logger.InfoContext(ctx, "alidns api call", attrs...)
+			}
+			return resp, err
+		}
+	}
+}
+
+// Policy runs check before every call and refuses the call with its error.
+func Policy(check func(ctx context.Context, call *Call) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (any, error) {
+			if err := check(ctx, call); err != nil {
+				return nil, err
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// ReadOnly refuses every operation that would change something.
+func ReadOnly() Middleware {
+	return Policy(func(_ context.Context, call *Call) error {
+		if call.ReadOnly() {
+			return nil
+		}
+		return ErrReadOnly
+	})
+}
+
+// RateLimit spaces calls at least 1/perSecond apart, across all goroutines
+// sharing the middleware. A waiting call gives up when its context ends. A
+// rate of zero or less does not limit.
+func RateLimit(perSecond float64) Middleware {
+	if perSecond <= 0 {
+		return func(next Handler) Handler { return next }
+	}
+	interval := time.Duration(float64(time.Second) / perSecond)
+	var (
+		mu  sync.Mutex
+		due time.Time
+	)
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (any, error) {
+			mu.Lock()
+			at := time.Now()
+			if due.After(at) {
+				at = due
+			}
+			due = at.Add(interval)
+			mu.Unlock()
+			if err := sleep(ctx, time.Until(at)); err != nil {
+				return nil, err
+			}
+			return next(ctx, call)
+		}
+	}
+}
+
+// Retry runs a call up to attempts times while it fails with a retryable
+// error, waiting backoff before the first retry and doubling it after.
+func Retry(attempts int, backoff time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (any, error) {
+			wait := backoff
+			for attempt := 1; ; attempt++ {
+				resp, err := next(ctx, call)
+				if err == nil || attempt >= attempts || !Retryable(err) {
+					return resp, err
+				}
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+				wait *= 2
+			}
+		}
+	}
+}
+
+// Retryable reports whether err means the API turned the request away
+// without acting on it, so that sending it again is safe even for changes.
+func Retryable(err error) bool {
+	code := ErrorCode(err)
+	return strings.HasPrefix(code, "Throttling") || code == "ServiceUnavailable"
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
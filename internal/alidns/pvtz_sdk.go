@@ -0,0 +1,529 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	openapi "github.com/alibabacloud-go/darabonba-openapi/v2/client"
+	openapiutil "github.com/alibabacloud-go/darabonba-openapi/v2/utils"
+	"github.com/alibabacloud-go/tea/dara"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const (
+	pvtzVersion        = "2018-01-01"
+	pvtzPageSize int64 = 100
+)
+
+// pvtzCall sends one PrivateZone RPC action and decodes its JSON body into
+// out.
+type pvtzCall func(ctx context.Context, action string, query map[string]any, out any) error
+
+// pvtzClient maps the record-level DNSAPI methods onto PrivateZone. Zones are
+// addressed by name like domains, or by zone ID when a name is shared by
+// several zones.
+type pvtzClient struct {
+	call pvtzCall
+
+	mu    sync.Mutex
+	zones []*PvtzZone
+}
+
+// NewPvtzSDKClient serves PvtzAPI from a client made by CreatePvtzClient.
+func NewPvtzSDKClient(client *openapi.Client) PvtzAPI {
+	return newPvtzClient(func(_ context.Context, action string, query map[string]any, out any) error {
+		params := &openapi.Params{
+			Action:      tea.String(action),
+			Version:     tea.String(pvtzVersion),
+			Protocol:    tea.String("HTTPS"),
+			Pathname:    tea.String("/"),
+			Method:      tea.String("POST"),
+			AuthType:    tea.String("AK"),
+			Style:       tea.String("RPC"),
+			ReqBodyType: tea.String("formData"),
+			BodyType:    tea.String("json"),
+		}
+		req := &openapi.OpenApiRequest{Query: openapiutil.Query(query)}
+		resp, err := client.CallApi(params, req, &dara.RuntimeOptions{})
+		if err != nil {
+			return err
+		}
+		body, err := json.Marshal(resp["body"])
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, out)
+	})
+}
+
+func newPvtzClient(call pvtzCall) *pvtzClient {
+	return &pvtzClient{call: call}
+}
+
+func errPvtzUnsupported(method string) error {
+	return fmt.Errorf("%s is not supported by the pvtz backend", method)
+}
+
+// pvtzRecord is a record as returned by DescribeZoneRecords.
+type pvtzRecord struct {
+	RecordId json.Number
+	Rr       string
+	Type     string
+	Value    string
+	Ttl      int64
+	Priority int64
+	Status   string
+	Remark   string
+	Line     string
+	Weight   int32
+}
+
+type pvtzMutationBody struct {
+	RecordId  json.Number
+	RequestId string
+	Status    string
+}
+
+func (r *pvtzRecord) toRecord(zoneName string) *alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord {
+	record := &alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		DomainName: tea.String(zoneName),
+		RR:         tea.String(r.Rr),
+		RecordId:   tea.String(r.RecordId.String()),
+		Type:       tea.String(r.Type),
+		Value:      tea.String(r.Value),
+		TTL:        tea.Int64(r.Ttl),
+		Line:       tea.String(defaultString(r.Line, defaultLine)),
+		Status:     tea.String(r.Status),
+		Locked:     tea.Bool(false),
+	}
+	if r.Priority != 0 {
+		record.Priority = tea.Int64(r.Priority)
+	}
+	if r.Remark != "" {
+		record.Remark = tea.String(r.Remark)
+	}
+	if r.Weight != 0 {
+		record.Weight = tea.Int32(r.Weight)
+	}
+	return record
+}
+
+// recordQuery holds the fields shared by AddZoneRecord and UpdateZoneRecord.
+// Priority is only accepted for MX records.
+func recordQuery(rr, rType, value *string, ttl, priority *int64) map[string]any {
+	query := map[string]any{
+		"Lang":  "en",
+		"Rr":    tea.StringValue(rr),
+		"Type":  tea.StringValue(rType),
+		"Value": tea.StringValue(value),
+	}
+	if ttl != nil {
+		query["Ttl"] = *ttl
+	}
+	if strings.EqualFold(tea.StringValue(rType), "MX") && priority != nil {
+		query["Priority"] = *priority
+	}
+	return query
+}
+
+func (c *pvtzClient) AddDomainRecord(ctx context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	zone, err := c.zone(ctx, tea.StringValue(req.DomainName))
+	if err != nil {
+		return nil, err
+	}
+	query := recordQuery(req.RR, req.Type, req.Value, req.TTL, req.Priority)
+	query["ZoneId"] = zone.ZoneId
+	var body pvtzMutationBody
+	if err := c.call(ctx, "AddZoneRecord", query, &body); err != nil {
+		return nil, err
+	}
+	return &alidns20150109.AddDomainRecordResponseBody{
+		RecordId:  tea.String(body.RecordId.String()),
+		RequestId: tea.String(body.RequestId),
+	}, nil
+}
+
+func (c *pvtzClient) UpdateDomainRecord(ctx context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+	query := recordQuery(req.RR, req.Type, req.Value, req.TTL, req.Priority)
+	query["RecordId"] = tea.StringValue(req.RecordId)
+	var body pvtzMutationBody
+	if err := c.call(ctx, "UpdateZoneRecord", query, &body); err != nil {
+		return nil, err
+	}
+	return &alidns20150109.UpdateDomainRecordResponseBody{
+		RecordId:  tea.String(body.RecordId.String()),
+		RequestId: tea.String(body.RequestId),
+	}, nil
+}
+
+func (c *pvtzClient) DeleteDomainRecord(ctx context.Context, req *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	var body pvtzMutationBody
+	if err := c.call(ctx, "DeleteZoneRecord", map[string]any{"Lang": "en", "RecordId": tea.StringValue(req.RecordId)}, &body); err != nil {
+		return nil, err
+	}
+	return &alidns20150109.DeleteDomainRecordResponseBody{
+		RecordId:  tea.String(body.RecordId.String()),
+		RequestId: tea.String(body.RequestId),
+	}, nil
+}
+
+// DeleteSubDomainRecords has no PrivateZone counterpart, so the matching
+// records are deleted one by one.
+func (c *pvtzClient) DeleteSubDomainRecords(ctx context.Context, req *alidns20150109.DeleteSubDomainRecordsRequest) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
+	zone, err := c.zone(ctx, tea.StringValue(req.DomainName))
+	if err != nil {
+		return nil, err
+	}
+	rr := tea.StringValue(req.RR)
+	records, err := c.zoneRecords(ctx, zone.ZoneId, rr)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &alidns20150109.DeleteSubDomainRecordsResponseBody{RR: tea.String(rr)}
+	deleted := 0
+	for _, record := range records {
+		if !strings.EqualFold(record.Rr, rr) {
+			continue
+		}
+		if req.Type != nil && !strings.EqualFold(record.Type, tea.StringValue(req.Type)) {
+			continue
+		}
+		body, err := c.DeleteDomainRecord(ctx, &alidns20150109.DeleteDomainRecordRequest{RecordId: tea.String(record.RecordId.String())})
+		if err != nil {
+			return nil, err
+		}
+		resp.RequestId = body.RequestId
+		deleted++
+	}
+	resp.TotalCount = tea.String(strconv.Itoa(deleted))
+	return resp, nil
+}
+
+// DescribeDomainRecords reads every matching record and then cuts out the
+// requested page, since PrivateZone pages are smaller and cannot filter by
+// type or value.
+func (c *pvtzClient) DescribeDomainRecords(ctx context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	zone, err := c.zone(ctx, tea.StringValue(req.DomainName))
+	if err != nil {
+		return nil, err
+	}
+	keyword := tea.StringValue(req.RRKeyWord)
+	if keyword == "" {
+		keyword = tea.StringValue(req.KeyWord)
+	}
+	records, err := c.zoneRecords(ctx, zone.ZoneId, keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{}
+	for _, record := range records {
+		if req.TypeKeyWord != nil && !strings.EqualFold(record.Type, tea.StringValue(req.TypeKeyWord)) {
+			continue
+		}
+		if req.ValueKeyWord != nil && !strings.Contains(strings.ToLower(record.Value), strings.ToLower(tea.StringValue(req.ValueKeyWord))) {
+			continue
+		}
+		if req.Status != nil && !strings.EqualFold(record.Status, tea.StringValue(req.Status)) {
+			continue
+		}
+		matched = append(matched, record.toRecord(zone.ZoneName))
+	}
+	return page(matched, tea.Int64Value(req.PageNumber), tea.Int64Value(req.PageSize)), nil
+}
+
+// DescribeDomainRecordInfo searches every zone, as PrivateZone cannot look a
+// record up by ID alone. A missing record yields nil.
+func (c *pvtzClient) DescribeDomainRecordInfo(ctx context.Context, req *alidns20150109.DescribeDomainRecordInfoRequest) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+	zones, err := c.cachedZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordID := tea.StringValue(req.RecordId)
+	for _, zone := range zones {
+		records, err := c.zoneRecords(ctx, zone.ZoneId, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record.RecordId.String() != recordID {
+				continue
+			}
+			r := record.toRecord(zone.ZoneName)
+			return &alidns20150109.DescribeDomainRecordInfoResponseBody{
+				DomainId:   tea.String(zone.ZoneId),
+				DomainName: r.DomainName,
+				Line:       r.Line,
+				Locked:     r.Locked,
+				Priority:   r.Priority,
+				RR:         r.RR,
+				RecordId:   r.RecordId,
+				Remark:     r.Remark,
+				Status:     r.Status,
+				TTL:        r.TTL,
+				Type:       r.Type,
+				Value:      r.Value,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *pvtzClient) DescribeDomains(ctx context.Context, req *alidns20150109.DescribeDomainsRequest) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	zones, err := c.cachedZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyword := strings.ToLower(tea.StringValue(req.KeyWord))
+	domains := []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain{}
+	for _, zone := range zones {
+		if !strings.Contains(strings.ToLower(zone.ZoneName), keyword) {
+			continue
+		}
+		domains = append(domains, &alidns20150109.DescribeDomainsResponseBodyDomainsDomain{
+			DomainId:    tea.String(zone.ZoneId),
+			DomainName:  tea.String(zone.ZoneName),
+			RecordCount: tea.Int64(zone.RecordCount),
+		})
+	}
+	return page(domains, tea.Int64Value(req.PageNumber), tea.Int64Value(req.PageSize)), nil
+}
+
+func (c *pvtzClient) SetDomainRecordStatus(ctx context.Context, req *alidns20150109.SetDomainRecordStatusRequest) (*alidns20150109.SetDomainRecordStatusResponseBody, error) {
+	var body pvtzMutationBody
+	query := map[string]any{
+		"Lang":     "en",
+		"RecordId": tea.StringValue(req.RecordId),
+		"Status":   strings.ToUpper(tea.StringValue(req.Status)),
+	}
+	if err := c.call(ctx, "SetZoneRecordStatus", query, &body); err != nil {
+		return nil, err
+	}
+	return &alidns20150109.SetDomainRecordStatusResponseBody{
+		RequestId: tea.String(body.RequestId),
+		Status:    tea.String(body.Status),
+	}, nil
+}
+
+func (c *pvtzClient) UpdateDomainRecordRemark(ctx context.Context, req *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	var body pvtzMutationBody
+	query := map[string]any{
+		"Lang":     "en",
+		"RecordId": tea.StringValue(req.RecordId),
+		"Remark":   tea.StringValue(req.Remark),
+	}
+	if err := c.call(ctx, "UpdateRecordRemark", query, &body); err != nil {
+		return nil, err
+	}
+	return &alidns20150109.UpdateDomainRecordRemarkResponseBody{RequestId: tea.String(body.RequestId)}, nil
+}
+
+func (c *pvtzClient) BindZoneVpc(ctx context.Context, req *BindZoneVpcRequest) (*BindZoneVpcResponseBody, error) {
+	query := map[string]any{"Lang": "en", "ZoneId": req.ZoneId}
+	for i, vpc := range req.Vpcs {
+		query[fmt.Sprintf("Vpcs.%d.RegionId", i+1)] = vpc.RegionId
+		query[fmt.Sprintf("Vpcs.%d.VpcId", i+1)] = vpc.VpcId
+	}
+	var body BindZoneVpcResponseBody
+	if err := c.call(ctx, "BindZoneVpc", query, &body); err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+
+func (c *pvtzClient) DescribeZoneInfo(ctx context.Context, zoneID string) (*PvtzZone, error) {
+	var body struct {
+		ZoneId      string
+		ZoneName    string
+		RecordCount int64
+		BindVpcs    struct {
+			Vpc []PvtzVpc
+		}
+	}
+	if err := c.call(ctx, "DescribeZoneInfo", map[string]any{"Lang": "en", "ZoneId": zoneID}, &body); err != nil {
+		return nil, err
+	}
+	return &PvtzZone{
+		ZoneId:      body.ZoneId,
+		ZoneName:    body.ZoneName,
+		RecordCount: body.RecordCount,
+		Vpcs:        body.BindVpcs.Vpc,
+	}, nil
+}
+
+func (c *pvtzClient) DescribeZones(ctx context.Context) ([]*PvtzZone, error) {
+	all := []*PvtzZone{}
+	for pageNumber := int64(1); ; pageNumber++ {
+		var body struct {
+			Zones struct {
+				Zone []*PvtzZone
+			}
+		}
+		query := map[string]any{"Lang": "en", "PageNumber": pageNumber, "PageSize": pvtzPageSize}
+		if err := c.call(ctx, "DescribeZones", query, &body); err != nil {
+			return nil, err
+		}
+		all = append(all, body.Zones.Zone...)
+		if int64(len(body.Zones.Zone)) < pvtzPageSize {
+			return all, nil
+		}
+	}
+}
+
+// cachedZones lists the zones once per client; record calls resolve a zone
+// name on every request.
+func (c *pvtzClient) cachedZones(ctx context.Context) ([]*PvtzZone, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.zones != nil {
+		return c.zones, nil
+	}
+	zones, err := c.DescribeZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.zones = zones
+	return zones, nil
+}
+
+func (c *pvtzClient) zone(ctx context.Context, nameOrID string) (*PvtzZone, error) {
+	zones, err := c.cachedZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchZone(zones, nameOrID)
+}
+
+// zoneRecords returns every record of a zone whose RR contains keyword.
+func (c *pvtzClient) zoneRecords(ctx context.Context, zoneID, keyword string) ([]*pvtzRecord, error) {
+	all := []*pvtzRecord{}
+	for pageNumber := int64(1); ; pageNumber++ {
+		var body struct {
+			Records struct {
+				Record []*pvtzRecord
+			}
+		}
+		query := map[string]any{"Lang": "en", "ZoneId": zoneID, "PageNumber": pageNumber, "PageSize": pvtzPageSize}
+		if keyword != "" {
+			query["Keyword"] = keyword
+			query["SearchMode"] = "LIKE"
+		}
+		if err := c.call(ctx, "DescribeZoneRecords", query, &body); err != nil {
+			return nil, err
+		}
+		all = append(all, body.Records.Record...)
+		if int64(len(body.Records.Record)) < pvtzPageSize {
+			return all, nil
+		}
+	}
+}
+
+// page returns page number pageNumber of items when split into pages of
+// pageSize. Zero values select everything.
+func page[T any](items []T, pageNumber, pageSize int64) []T {
+	if pageSize <= 0 {
+		return items
+	}
+	start := (max(pageNumber, 1) - 1) * pageSize
+	if start >= int64(len(items)) {
+		return items[:0]
+	}
+	return items[start:min(start+pageSize, int64(len(items)))]
+}
+
+func (c *pvtzClient) AddCustomLine(context.Context, *alidns20150109.AddCustomLineRequest) (*alidns20150109.AddCustomLineResponseBody, error) {
+	return nil, errPvtzUnsupported("AddCustomLine")
+}
+
+func (c *pvtzClient) AddDomainGroup(context.Context, *alidns20150109.AddDomainGroupRequest) (*alidns20150109.AddDomainGroupResponseBody, error) {
+	return nil, errPvtzUnsupported("AddDomainGroup")
+}
+
+func (c *pvtzClient) ChangeDomainGroup(context.Context, *alidns20150109.ChangeDomainGroupRequest) (*alidns20150109.ChangeDomainGroupResponseBody, error) {
+	return nil, errPvtzUnsupported("ChangeDomainGroup")
+}
+
+func (c *pvtzClient) DeleteCustomLines(context.Context, *alidns20150109.DeleteCustomLinesRequest) (*alidns20150109.DeleteCustomLinesResponseBody, error) {
+	return nil, errPvtzUnsupported("DeleteCustomLines")
+}
+
+func (c *pvtzClient) DeleteDomainGroup(context.Context, *alidns20150109.DeleteDomainGroupRequest) (*alidns20150109.DeleteDomainGroupResponseBody, error) {
+	return nil, errPvtzUnsupported("DeleteDomainGroup")
+}
+
+func (c *pvtzClient) DescribeCustomLines(context.Context, *alidns20150109.DescribeCustomLinesRequest) ([]*alidns20150109.DescribeCustomLinesResponseBodyCustomLines, error) {
+	return nil, errPvtzUnsupported("DescribeCustomLines")
+}
+
+func (c *pvtzClient) DescribeDNSSLBSubDomains(context.Context, *alidns20150109.DescribeDNSSLBSubDomainsRequest) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error) {
+	return nil, errPvtzUnsupported("DescribeDNSSLBSubDomains")
+}
+
+func (c *pvtzClient) DescribeDomainDnssecInfo(context.Context, *alidns20150109.DescribeDomainDnssecInfoRequest) (*alidns20150109.DescribeDomainDnssecInfoResponseBody, error) {
+	return nil, errPvtzUnsupported("DescribeDomainDnssecInfo")
+}
+
+func (c *pvtzClient) DescribeDomainGroups(context.Context, *alidns20150109.DescribeDomainGroupsRequest) ([]*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error) {
+	return nil, errPvtzUnsupported("DescribeDomainGroups")
+}
+
+func (c *pvtzClient) DescribeDomainLogs(context.Context, *alidns20150109.DescribeDomainLogsRequest) ([]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog, error) {
+	return nil, errPvtzUnsupported("DescribeDomainLogs")
+}
+
+func (c *pvtzClient) DescribeDomainStatistics(context.Context, *alidns20150109.DescribeDomainStatisticsRequest) ([]*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic, error) {
+	return nil, errPvtzUnsupported("DescribeDomainStatistics")
+}
+
+func (c *pvtzClient) DescribeDomainStatisticsSummary(context.Context, *alidns20150109.DescribeDomainStatisticsSummaryRequest) ([]*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	return nil, errPvtzUnsupported("DescribeDomainStatisticsSummary")
+}
+
+func (c *pvtzClient) DescribeRecordLogs(context.Context, *alidns20150109.DescribeRecordLogsRequest) ([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, error) {
+	return nil, errPvtzUnsupported("DescribeRecordLogs")
+}
+
+func (c *pvtzClient) DescribeRecordStatistics(context.Context, *alidns20150109.DescribeRecordStatisticsRequest) ([]*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic, error) {
+	return nil, errPvtzUnsupported("DescribeRecordStatistics")
+}
+
+func (c *pvtzClient) DescribeRecordStatisticsSummary(context.Context, *alidns20150109.DescribeRecordStatisticsSummaryRequest) ([]*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	return nil, errPvtzUnsupported("DescribeRecordStatisticsSummary")
+}
+
+func (c *pvtzClient) DescribeSupportLines(context.Context, *alidns20150109.DescribeSupportLinesRequest) ([]*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine, error) {
+	return nil, errPvtzUnsupported("DescribeSupportLines")
+}
+
+func (c *pvtzClient) SetDNSSLBStatus(context.Context, *alidns20150109.SetDNSSLBStatusRequest) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+	return nil, errPvtzUnsupported("SetDNSSLBStatus")
+}
+
+func (c *pvtzClient) SetDomainDnssecStatus(context.Context, *alidns20150109.SetDomainDnssecStatusRequest) (*alidns20150109.SetDomainDnssecStatusResponseBody, error) {
+	return nil, errPvtzUnsupported("SetDomainDnssecStatus")
+}
+
+func (c *pvtzClient) UpdateDNSSLBWeight(context.Context, *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	return nil, errPvtzUnsupported("UpdateDNSSLBWeight")
+}
+
+func (c *pvtzClient) UpdateDomainGroup(context.Context, *alidns20150109.UpdateDomainGroupRequest) (*alidns20150109.UpdateDomainGroupResponseBody, error) {
+	return nil, errPvtzUnsupported("UpdateDomainGroup")
+}
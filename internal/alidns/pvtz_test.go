@@ -0,0 +1,193 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type pvtzCallRecord struct {
+	action string
+	query  map[string]any
+}
+
+// fakePvtz answers PrivateZone actions with canned JSON bodies and records
+// every call.
+type fakePvtz struct {
+	calls     []pvtzCallRecord
+	responses map[string]string
+}
+
+func (f *fakePvtz) call(_ context.Context, action string, query map[string]any, out any) error {
+	f.calls = append(f.calls, pvtzCallRecord{action: action, query: query})
+	body, ok := f.responses[action]
+	if !ok {
+		body = "{}"
+	}
+	return json.Unmarshal([]byte(body), out)
+}
+
+func (f *fakePvtz) find(action string) []pvtzCallRecord {
+	var calls []pvtzCallRecord
+	for _, c := range f.calls {
+		if c.action == action {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+const pvtzZonesBody = `{"Zones":{"Zone":[
+	{"ZoneId":"z-1","ZoneName":"corp.internal","RecordCount":2},
+	{"ZoneId":"z-2","ZoneName":"shared.internal"},
+	{"ZoneId":"z-3","ZoneName":"shared.internal"}]}}`
+
+const pvtzRecordsBody = `{"Records":{"Record":[
+	{"RecordId":101,"Rr":"db","Type":"A","Value":"10.0.0.5","Ttl":60,"Status":"ENABLE"},
+	{"RecordId":102,"Rr":"db","Type":"TXT","Value":"owner=ops","Ttl":60,"Status":"DISABLE","Remark":"ops"}]}}`
+
+func TestPvtzAddRecordResolvesZoneAndAddsRemark(t *testing.T) {
+	fake := &fakePvtz{responses: map[string]string{
+		"DescribeZones": pvtzZonesBody,
+		"AddZoneRecord": `{"RecordId":123456789012,"RequestId":"req-1"}`,
+	}}
+	svc := NewService(newPvtzClient(fake.call))
+
+	resp, err := svc.Add(context.Background(), AddInput{DomainName: "corp.internal", Name: "db", Type: "A", Value: "10.0.0.5", Remark: "primary"})
+	if err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if got := tea.StringValue(resp.RecordId); got != "123456789012" {
+		t.Fatalf("unexpected record ID %q", got)
+	}
+	add := fake.find("AddZoneRecord")
+	if len(add) != 1 || add[0].query["ZoneId"] != "z-1" || add[0].query["Rr"] != "db" || add[0].query["Ttl"] != int64(600) {
+		t.Fatalf("unexpected AddZoneRecord calls: %+v", add)
+	}
+	if _, ok := add[0].query["Priority"]; ok {
+		t.Fatalf("priority should only be sent for MX records: %+v", add[0].query)
+	}
+	remark := fake.find("UpdateRecordRemark")
+	if len(remark) != 1 || remark[0].query["RecordId"] != "123456789012" || remark[0].query["Remark"] != "primary" {
+		t.Fatalf("unexpected UpdateRecordRemark calls: %+v", remark)
+	}
+}
+
+func TestPvtzFindFiltersRecordsLocally(t *testing.T) {
+	fake := &fakePvtz{responses: map[string]string{
+		"DescribeZones":       pvtzZonesBody,
+		"DescribeZoneRecords": pvtzRecordsBody,
+	}}
+	svc := NewService(newPvtzClient(fake.call))
+
+	records, err := svc.Find(context.Background(), FindInput{DomainName: "corp.internal", Name: "db", Type: "TXT"})
+	if err != nil {
+		t.Fatalf("Find returned error: %v", err)
+	}
+	if len(records) != 1 || tea.StringValue(records[0].RecordId) != "102" || tea.StringValue(records[0].Status) != "DISABLE" || tea.StringValue(records[0].DomainName) != "corp.internal" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	calls := fake.find("DescribeZoneRecords")
+	if len(calls) != 1 || calls[0].query["Keyword"] != "db" || calls[0].query["PageSize"] != pvtzPageSize {
+		t.Fatalf("unexpected DescribeZoneRecords calls: %+v", calls)
+	}
+}
+
+func TestPvtzDelRemovesEachMatchingRecord(t *testing.T) {
+	fake := &fakePvtz{responses: map[string]string{
+		"DescribeZones":       pvtzZonesBody,
+		"DescribeZoneRecords": pvtzRecordsBody,
+		"DeleteZoneRecord":    `{"RecordId":101,"RequestId":"req-del"}`,
+	}}
+	svc := NewService(newPvtzClient(fake.call))
+
+	resp, err := svc.Del(context.Background(), DelInput{DomainName: "corp.internal", Name: "db", Type: "A"})
+	if err != nil {
+		t.Fatalf("Del returned error: %v", err)
+	}
+	if tea.StringValue(resp.TotalCount) != "1" || tea.StringValue(resp.RequestId) != "req-del" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	del := fake.find("DeleteZoneRecord")
+	if len(del) != 1 || del[0].query["RecordId"] != "101" {
+		t.Fatalf("unexpected DeleteZoneRecord calls: %+v", del)
+	}
+}
+
+func TestPvtzRecordInfoSearchesZones(t *testing.T) {
+	fake := &fakePvtz{responses: map[string]string{
+		"DescribeZones":       pvtzZonesBody,
+		"DescribeZoneRecords": pvtzRecordsBody,
+	}}
+	svc := NewService(newPvtzClient(fake.call))
+
+	info, err := svc.RecordInfo(context.Background(), "102")
+	if err != nil {
+		t.Fatalf("RecordInfo returned error: %v", err)
+	}
+	if tea.StringValue(info.DomainName) != "corp.internal" || tea.StringValue(info.Value) != "owner=ops" {
+		t.Fatalf("unexpected record: %+v", info)
+	}
+	if _, err := svc.RecordInfo(context.Background(), "999"); err == nil {
+		t.Fatalf("expected an error for a missing record")
+	}
+}
+
+func TestPvtzSharedZoneNameNeedsID(t *testing.T) {
+	fake := &fakePvtz{responses: map[string]string{"DescribeZones": pvtzZonesBody}}
+	svc := NewService(newPvtzClient(fake.call))
+
+	if _, err := svc.Query(context.Background(), QueryInput{DomainName: "shared.internal"}); err == nil {
+		t.Fatalf("expected an error for a zone name shared by two zones")
+	}
+	if _, err := svc.Query(context.Background(), QueryInput{DomainName: "z-3"}); err != nil {
+		t.Fatalf("Query by zone ID returned error: %v", err)
+	}
+	if got := len(fake.find("DescribeZones")); got != 1 {
+		t.Fatalf("expected zones to be listed once, got %d", got)
+	}
+}
+
+func TestPvtzUnsupportedMethod(t *testing.T) {
+	svc := NewService(newPvtzClient((&fakePvtz{}).call))
+	if _, err := svc.Groups(context.Background()); err == nil {
+		t.Fatalf("expected groups to be unsupported")
+	}
+}
+
+func TestZoneBindKeepsExistingVpcs(t *testing.T) {
+	fake := &fakePvtz{responses: map[string]string{
+		"DescribeZones":    pvtzZonesBody,
+		"DescribeZoneInfo": `{"ZoneId":"z-1","ZoneName":"corp.internal","BindVpcs":{"Vpc":[{"RegionId":"cn-hangzhou","VpcId":"vpc-a","VpcName":"prod"}]}}`,
+		"BindZoneVpc":      `{"RequestId":"req-bind"}`,
+	}}
+	svc := NewZoneService(newPvtzClient(fake.call))
+
+	result, err := svc.Bind(context.Background(), ZoneBindInput{
+		Zone: "corp.internal",
+		Vpcs: []PvtzVpc{{RegionId: "cn-hangzhou", VpcId: "vpc-a"}, {RegionId: "cn-hangzhou", VpcId: "vpc-b"}},
+	})
+	if err != nil {
+		t.Fatalf("Bind returned error: %v", err)
+	}
+	if len(result.Vpcs) != 2 || result.RequestId != "req-bind" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	bind := fake.find("BindZoneVpc")
+	if len(bind) != 1 || bind[0].query["Vpcs.1.VpcId"] != "vpc-a" || bind[0].query["Vpcs.2.VpcId"] != "vpc-b" || bind[0].query["Vpcs.2.RegionId"] != "cn-hangzhou" {
+		t.Fatalf("unexpected BindZoneVpc calls: %+v", bind)
+	}
+}
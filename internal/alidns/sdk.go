@@ -37,6 +37,17 @@ func (s *sdkClient) AddDomainRecord(_ context.Context, req *alidns20150109.AddDo
 	return resp.Body, nil
 }
 
+func (s *sdkClient) DeleteDomainRecord(_ context.Context, req *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	resp, err := s.client.DeleteDomainRecordWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
 func (s *sdkClient) DeleteSubDomainRecords(_ context.Context, req *alidns20150109.DeleteSubDomainRecordsRequest) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
 	resp, err := s.client.DeleteSubDomainRecordsWithOptions(req, &util.RuntimeOptions{})
 	if err != nil {
@@ -48,6 +59,39 @@ func (s *sdkClient) DeleteSubDomainRecords(_ context.Context, req *alidns2015010
 	return resp.Body, nil
 }
 
+func (s *sdkClient) DescribeDNSSLBSubDomains(_ context.Context, req *alidns20150109.DescribeDNSSLBSubDomainsRequest) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error) {
+	resp, err := s.client.DescribeDNSSLBSubDomainsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.SlbSubDomains == nil || resp.Body.SlbSubDomains.SlbSubDomain == nil {
+		return []*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain{}, nil
+	}
+	return resp.Body.SlbSubDomains.SlbSubDomain, nil
+}
+
+func (s *sdkClient) DescribeDomainRecordInfo(_ context.Context, req *alidns20150109.DescribeDomainRecordInfoRequest) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+	resp, err := s.client.DescribeDomainRecordInfoWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DescribeDomains(_ context.Context, req *alidns20150109.DescribeDomainsRequest) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	resp, err := s.client.DescribeDomainsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.Domains == nil || resp.Body.Domains.Domain == nil {
+		return []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain{}, nil
+	}
+	return resp.Body.Domains.Domain, nil
+}
+
 func (s *sdkClient) DescribeDomainRecords(_ context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
 	resp, err := s.client.DescribeDomainRecordsWithOptions(req, &util.RuntimeOptions{})
 	if err != nil {
@@ -59,6 +103,50 @@ func (s *sdkClient) DescribeDomainRecords(_ context.Context, req *alidns20150109
 	return resp.Body.DomainRecords.Record, nil
 }
 
+func (s *sdkClient) SetDNSSLBStatus(_ context.Context, req *alidns20150109.SetDNSSLBStatusRequest) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+	resp, err := s.client.SetDNSSLBStatusWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) SetDomainDnssecStatus(_ context.Context, req *alidns20150109.SetDomainDnssecStatusRequest) (*alidns20150109.SetDomainDnssecStatusResponseBody, error) {
+	resp, err := s.client.SetDomainDnssecStatusWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) SetDomainRecordStatus(_ context.Context, req *alidns20150109.SetDomainRecordStatusRequest) (*alidns20150109.SetDomainRecordStatusResponseBody, error) {
+	resp, err := s.client.SetDomainRecordStatusWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) UpdateDNSSLBWeight(_ context.Context, req *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	resp, err := s.client.UpdateDNSSLBWeightWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
 func (s *sdkClient) UpdateDomainRecord(_ context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
 	resp, err := s.client.UpdateDomainRecordWithOptions(req, &util.RuntimeOptions{})
 	if err != nil {
@@ -69,3 +157,190 @@ func (s *sdkClient) UpdateDomainRecord(_ context.Context, req *alidns20150109.Up
 	}
 	return resp.Body, nil
 }
+
+func (s *sdkClient) UpdateDomainRecordRemark(_ context.Context, req *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	resp, err := s.client.UpdateDomainRecordRemarkWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) AddCustomLine(_ context.Context, req *alidns20150109.AddCustomLineRequest) (*alidns20150109.AddCustomLineResponseBody, error) {
+	resp, err := s.client.AddCustomLineWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DeleteCustomLines(_ context.Context, req *alidns20150109.DeleteCustomLinesRequest) (*alidns20150109.DeleteCustomLinesResponseBody, error) {
+	resp, err := s.client.DeleteCustomLinesWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DescribeCustomLines(_ context.Context, req *alidns20150109.DescribeCustomLinesRequest) ([]*alidns20150109.DescribeCustomLinesResponseBodyCustomLines, error) {
+	resp, err := s.client.DescribeCustomLinesWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.CustomLines == nil {
+		return []*alidns20150109.DescribeCustomLinesResponseBodyCustomLines{}, nil
+	}
+	return resp.Body.CustomLines, nil
+}
+
+func (s *sdkClient) DescribeSupportLines(_ context.Context, req *alidns20150109.DescribeSupportLinesRequest) ([]*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine, error) {
+	resp, err := s.client.DescribeSupportLinesWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.RecordLines == nil || resp.Body.RecordLines.RecordLine == nil {
+		return []*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine{}, nil
+	}
+	return resp.Body.RecordLines.RecordLine, nil
+}
+
+func (s *sdkClient) AddDomainGroup(_ context.Context, req *alidns20150109.AddDomainGroupRequest) (*alidns20150109.AddDomainGroupResponseBody, error) {
+	resp, err := s.client.AddDomainGroupWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) ChangeDomainGroup(_ context.Context, req *alidns20150109.ChangeDomainGroupRequest) (*alidns20150109.ChangeDomainGroupResponseBody, error) {
+	resp, err := s.client.ChangeDomainGroupWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DeleteDomainGroup(_ context.Context, req *alidns20150109.DeleteDomainGroupRequest) (*alidns20150109.DeleteDomainGroupResponseBody, error) {
+	resp, err := s.client.DeleteDomainGroupWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) UpdateDomainGroup(_ context.Context, req *alidns20150109.UpdateDomainGroupRequest) (*alidns20150109.UpdateDomainGroupResponseBody, error) {
+	resp, err := s.client.UpdateDomainGroupWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DescribeDomainDnssecInfo(_ context.Context, req *alidns20150109.DescribeDomainDnssecInfoRequest) (*alidns20150109.DescribeDomainDnssecInfoResponseBody, error) {
+	resp, err := s.client.DescribeDomainDnssecInfoWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	return resp.Body, nil
+}
+
+func (s *sdkClient) DescribeDomainGroups(_ context.Context, req *alidns20150109.DescribeDomainGroupsRequest) ([]*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error) {
+	resp, err := s.client.DescribeDomainGroupsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.DomainGroups == nil || resp.Body.DomainGroups.DomainGroup == nil {
+		return []*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup{}, nil
+	}
+	return resp.Body.DomainGroups.DomainGroup, nil
+}
+
+func (s *sdkClient) DescribeDomainLogs(_ context.Context, req *alidns20150109.DescribeDomainLogsRequest) ([]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog, error) {
+	resp, err := s.client.DescribeDomainLogsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.DomainLogs == nil || resp.Body.DomainLogs.DomainLog == nil {
+		return []*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog{}, nil
+	}
+	return resp.Body.DomainLogs.DomainLog, nil
+}
+
+func (s *sdkClient) DescribeRecordLogs(_ context.Context, req *alidns20150109.DescribeRecordLogsRequest) ([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, error) {
+	resp, err := s.client.DescribeRecordLogsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.RecordLogs == nil || resp.Body.RecordLogs.RecordLog == nil {
+		return []*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog{}, nil
+	}
+	return resp.Body.RecordLogs.RecordLog, nil
+}
+
+func (s *sdkClient) DescribeDomainStatistics(_ context.Context, req *alidns20150109.DescribeDomainStatisticsRequest) ([]*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic, error) {
+	resp, err := s.client.DescribeDomainStatisticsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.Statistics == nil || resp.Body.Statistics.Statistic == nil {
+		return []*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic{}, nil
+	}
+	return resp.Body.Statistics.Statistic, nil
+}
+
+func (s *sdkClient) DescribeDomainStatisticsSummary(_ context.Context, req *alidns20150109.DescribeDomainStatisticsSummaryRequest) ([]*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	resp, err := s.client.DescribeDomainStatisticsSummaryWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.Statistics == nil || resp.Body.Statistics.Statistic == nil {
+		return []*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic{}, nil
+	}
+	return resp.Body.Statistics.Statistic, nil
+}
+
+func (s *sdkClient) DescribeRecordStatistics(_ context.Context, req *alidns20150109.DescribeRecordStatisticsRequest) ([]*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic, error) {
+	resp, err := s.client.DescribeRecordStatisticsWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.Statistics == nil || resp.Body.Statistics.Statistic == nil {
+		return []*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic{}, nil
+	}
+	return resp.Body.Statistics.Statistic, nil
+}
+
+func (s *sdkClient) DescribeRecordStatisticsSummary(_ context.Context, req *alidns20150109.DescribeRecordStatisticsSummaryRequest) ([]*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	resp, err := s.client.DescribeRecordStatisticsSummaryWithOptions(req, &util.RuntimeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Body == nil || resp.Body.Statistics == nil || resp.Body.Statistics.Statistic == nil {
+		return []*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic{}, nil
+	}
+	return resp.Body.Statistics.Statistic, nil
+}
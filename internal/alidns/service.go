@@ -13,6 +13,10 @@ package alidns
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
 	"github.com/alibabacloud-go/tea/tea"
@@ -22,8 +26,15 @@ const (
 	defaultTTL      int64 = 600
 	defaultPriority int64 = 1
 	defaultLine           = "default"
+
+	recordPageSize int64 = 500
+	domainPageSize int64 = 100
 )
 
+// APIZone is the time zone (China Standard Time) of the dates the Alidns
+// APIs take and of the buckets they report statistics in.
+var APIZone = time.FixedZone("CST", 8*60*60)
+
 type Service struct {
 	api DNSAPI
 }
@@ -40,16 +51,33 @@ type AddInput struct {
 	TTL        int64
 	Priority   int64
 	Line       string
+	// Remark is set on the new record with a second call when not empty.
+	Remark string
 }
 
 type DelInput struct {
 	DomainName string
 	Name       string
 	Type       string
+	// Value limits the deletion to records whose value matches exactly.
+	// When empty, every record of Type at Name is deleted.
+	Value string
 }
 
 type QueryInput struct {
 	DomainName string
+	// Name limits the result to one RR. Empty returns the whole zone.
+	Name string
+	// Remark keeps only records whose remark contains it, ignoring case.
+	Remark string
+}
+
+type FindInput struct {
+	DomainName string
+	Name       string
+	Type       string
+	// Value restricts the match to records with exactly this value.
+	Value string
 }
 
 type UpdateInput struct {
@@ -63,6 +91,9 @@ type UpdateInput struct {
 }
 
 func (s *Service) Add(ctx context.Context, in AddInput) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	if err := asciiNames(&in.DomainName, &in.Name); err != nil {
+		return nil, err
+	}
 	req := &alidns20150109.AddDomainRecordRequest{
 		Lang:       tea.String("en"),
 		DomainName: tea.String(in.DomainName),
@@ -73,10 +104,23 @@ func (s *Service) Add(ctx context.Context, in AddInput) (*alidns20150109.AddDoma
 		Priority:   tea.Int64(defaultInt64(in.Priority, defaultPriority)),
 		Line:       tea.String(defaultString(in.Line, defaultLine)),
 	}
-	return s.api.AddDomainRecord(ctx, req)
+	resp, err := s.api.AddDomainRecord(ctx, req)
+	if err != nil || in.Remark == "" || resp == nil {
+		return resp, err
+	}
+	if _, err := s.SetRemark(ctx, tea.StringValue(resp.RecordId), in.Remark); err != nil {
+		return resp, fmt.Errorf("record %s added but setting its remark failed: %w", tea.StringValue(resp.RecordId), err)
+	}
+	return resp, nil
 }
 
 func (s *Service) Del(ctx context.Context, in DelInput) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
+	if err := asciiNames(&in.DomainName, &in.Name); err != nil {
+		return nil, err
+	}
+	if in.Value != "" {
+		return s.delValue(ctx, in)
+	}
 	req := &alidns20150109.DeleteSubDomainRecordsRequest{
 		DomainName: tea.String(in.DomainName),
 		RR:         tea.String(in.Name),
@@ -87,6 +131,9 @@ func (s *Service) Del(ctx context.Context, in DelInput) (*alidns20150109.DeleteS
 }
 
 func (s *Service) Query(ctx context.Context, in QueryInput) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	if err := asciiNames(&in.DomainName, &in.Name); err != nil {
+		return nil, err
+	}
 	req := &alidns20150109.DescribeDomainRecordsRequest{
 		DomainName: tea.String(in.DomainName),
 		Lang:       tea.String("en"),
@@ -96,10 +143,175 @@ func (s *Service) Query(ctx context.Context, in QueryInput) ([]*alidns20150109.D
 		PageSize:   tea.Int64(500),
 		SearchMode: tea.String("LIKE"),
 	}
-	return s.api.DescribeDomainRecords(ctx, req)
+	if in.Name == "" && in.Remark == "" {
+		return s.api.DescribeDomainRecords(ctx, req)
+	}
+
+	if in.Name != "" {
+		req.SearchMode = tea.String("ADVANCED")
+		req.RRKeyWord = tea.String(in.Name)
+	}
+	records, err := s.api.DescribeDomainRecords(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	remark := strings.ToLower(in.Remark)
+	matched := make([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, 0, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		if in.Name != "" && !strings.EqualFold(tea.StringValue(record.RR), in.Name) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(tea.StringValue(record.Remark)), remark) {
+			continue
+		}
+		matched = append(matched, record)
+	}
+	return matched, nil
 }
 
+// Records returns every record of a domain, including disabled ones, walking
+// all result pages.
+func (s *Service) Records(ctx context.Context, domainName string) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	if err := asciiNames(&domainName); err != nil {
+		return nil, err
+	}
+	all := []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{}
+	for page := int64(1); ; page++ {
+		records, err := s.api.DescribeDomainRecords(ctx, &alidns20150109.DescribeDomainRecordsRequest{
+			DomainName: tea.String(domainName),
+			Lang:       tea.String("en"),
+			Direction:  tea.String("ASC"),
+			PageNumber: tea.Int64(page),
+			PageSize:   tea.Int64(recordPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, records...)
+		if int64(len(records)) < recordPageSize {
+			return all, nil
+		}
+	}
+}
+
+// Domains returns every domain in the account, walking all result pages.
+func (s *Service) Domains(ctx context.Context) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	return s.domains(ctx, "")
+}
+
+// domains walks DescribeDomains, limited to groupID when it is not empty.
+func (s *Service) domains(ctx context.Context, groupID string) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	all := []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain{}
+	for page := int64(1); ; page++ {
+		req := &alidns20150109.DescribeDomainsRequest{
+			Lang:       tea.String("en"),
+			PageNumber: tea.Int64(page),
+			PageSize:   tea.Int64(domainPageSize),
+		}
+		if groupID != "" {
+			req.GroupId = tea.String(groupID)
+		}
+		domains, err := s.api.DescribeDomains(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, domains...)
+		if int64(len(domains)) < domainPageSize {
+			return all, nil
+		}
+	}
+}
+
+// SetStatus enables or disables a single record.
+func (s *Service) SetStatus(ctx context.Context, recordID string, enabled bool) (*alidns20150109.SetDomainRecordStatusResponseBody, error) {
+	status := "Disable"
+	if enabled {
+		status = "Enable"
+	}
+	return s.api.SetDomainRecordStatus(ctx, &alidns20150109.SetDomainRecordStatusRequest{
+		Lang:     tea.String("en"),
+		RecordId: tea.String(recordID),
+		Status:   tea.String(status),
+	})
+}
+
+// SetRemark replaces the remark of a single record. An empty remark clears
+// it.
+func (s *Service) SetRemark(ctx context.Context, recordID, remark string) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	return s.api.UpdateDomainRecordRemark(ctx, &alidns20150109.UpdateDomainRecordRemarkRequest{
+		Lang:     tea.String("en"),
+		RecordId: tea.String(recordID),
+		Remark:   tea.String(remark),
+	})
+}
+
+// Find returns the records at exactly Name with type Type. Unlike Query it
+// includes disabled records.
+func (s *Service) Find(ctx context.Context, in FindInput) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	if err := asciiNames(&in.DomainName, &in.Name); err != nil {
+		return nil, err
+	}
+	return s.findRecords(ctx, in.DomainName, in.Name, in.Type, in.Value)
+}
+
+// DeleteRecord deletes a single record by ID.
+func (s *Service) DeleteRecord(ctx context.Context, recordID string) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	return s.api.DeleteDomainRecord(ctx, &alidns20150109.DeleteDomainRecordRequest{
+		Lang:     tea.String("en"),
+		RecordId: tea.String(recordID),
+	})
+}
+
+// RecordInfo fetches a single record by ID.
+func (s *Service) RecordInfo(ctx context.Context, recordID string) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+	resp, err := s.api.DescribeDomainRecordInfo(ctx, &alidns20150109.DescribeDomainRecordInfoRequest{
+		Lang:     tea.String("en"),
+		RecordId: tea.String(recordID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("record %s not found", recordID)
+	}
+	return resp, nil
+}
+
+// RecordFromInfo converts a DescribeDomainRecordInfo response into the
+// record shape returned by Query and Find.
+func RecordFromInfo(info *alidns20150109.DescribeDomainRecordInfoResponseBody) *alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord {
+	return &alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		DomainName: info.DomainName,
+		Line:       info.Line,
+		Locked:     info.Locked,
+		Priority:   info.Priority,
+		RR:         info.RR,
+		RecordId:   info.RecordId,
+		Remark:     info.Remark,
+		Status:     info.Status,
+		TTL:        info.TTL,
+		Type:       info.Type,
+		Value:      info.Value,
+	}
+}
+
+// Update changes the record in.RecordID. A zero field keeps the record's
+// current value, which is read with RecordInfo when the input does not carry
+// every field, rather than falling back to the defaults Add uses.
 func (s *Service) Update(ctx context.Context, in UpdateInput) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+	if err := asciiNames(&in.Name); err != nil {
+		return nil, err
+	}
+	if in.Name == "" || in.Type == "" || in.Value == "" || in.TTL == 0 || in.Line == "" || (in.Priority == 0 && strings.EqualFold(in.Type, "MX")) {
+		info, err := s.RecordInfo(ctx, in.RecordID)
+		if err != nil {
+			return nil, err
+		}
+		in, _ = MergeUpdate(info, in)
+	}
 	req := &alidns20150109.UpdateDomainRecordRequest{
 		Lang:     tea.String("en"),
 		RR:       tea.String(in.Name),
@@ -113,6 +325,105 @@ func (s *Service) Update(ctx context.Context, in UpdateInput) (*alidns20150109.U
 	return s.api.UpdateDomainRecord(ctx, req)
 }
 
+// delValue removes only the records carrying in.Value, leaving the other
+// values at the same RR alone. The result mirrors DeleteSubDomainRecords so
+// callers see the same shape either way.
+func (s *Service) delValue(ctx context.Context, in DelInput) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
+	records, err := s.findRecords(ctx, in.DomainName, in.Name, in.Type, in.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, err := s.deleteRecords(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+	return &alidns20150109.DeleteSubDomainRecordsResponseBody{
+		RR:         tea.String(in.Name),
+		TotalCount: tea.String(strconv.Itoa(len(records))),
+		RequestId:  tea.String(requestID),
+	}, nil
+}
+
+// deleteRecords deletes records one by one by ID and returns the RequestId of
+// the last call.
+func (s *Service) deleteRecords(ctx context.Context, records []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord) (string, error) {
+	var requestID string
+	for _, record := range records {
+		resp, err := s.DeleteRecord(ctx, tea.StringValue(record.RecordId))
+		if err != nil {
+			return "", err
+		}
+		if resp != nil {
+			requestID = tea.StringValue(resp.RequestId)
+		}
+	}
+	return requestID, nil
+}
+
+// findRecords returns the records at exactly rr with type rType, including
+// disabled ones, walking all result pages. A non-empty value further
+// restricts the match to that value.
+func (s *Service) findRecords(ctx context.Context, domainName, rr, rType, value string) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	req := &alidns20150109.DescribeDomainRecordsRequest{
+		DomainName:  tea.String(domainName),
+		Lang:        tea.String("en"),
+		Direction:   tea.String("ASC"),
+		PageSize:    tea.Int64(recordPageSize),
+		SearchMode:  tea.String("ADVANCED"),
+		RRKeyWord:   tea.String(rr),
+		TypeKeyWord: tea.String(rType),
+	}
+	if value != "" {
+		req.ValueKeyWord = tea.String(value)
+	}
+
+	matched := []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{}
+	for page := int64(1); ; page++ {
+		req.PageNumber = tea.Int64(page)
+		records, err := s.api.DescribeDomainRecords(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if record == nil || !strings.EqualFold(tea.StringValue(record.RR), rr) || !strings.EqualFold(tea.StringValue(record.Type), rType) {
+				continue
+			}
+			if value != "" && !sameValue(tea.StringValue(record.Value), value) {
+				continue
+			}
+			matched = append(matched, record)
+		}
+		if int64(len(records)) < recordPageSize {
+			return matched, nil
+		}
+	}
+}
+
+// sameValue compares record values, ignoring the surrounding quotes that TXT
+// values may or may not carry.
+func sameValue(a, b string) bool {
+	return strings.Trim(a, `"`) == strings.Trim(b, `"`)
+}
+
+// JoinFQDN joins an RR and its domain, mapping "@" to the apex.
+func JoinFQDN(domainName, rr string) string {
+	if rr == "" || rr == "@" {
+		return domainName
+	}
+	return rr + "." + domainName
+}
+
+// rrOf is the inverse of JoinFQDN.
+func rrOf(domainName, fqdn string) string {
+	fqdn = strings.TrimSuffix(strings.ToLower(fqdn), ".")
+	domainName = strings.ToLower(domainName)
+	if fqdn == domainName {
+		return "@"
+	}
+	return strings.TrimSuffix(fqdn, "."+domainName)
+}
+
 func defaultInt64(v, fallback int64) int64 {
 	if v == 0 {
 		return fallback
@@ -126,3 +437,24 @@ func defaultString(v, fallback string) string {
 	}
 	return v
 }
+
+// MergeUpdate fills the zero fields of in from the record's current state and
+// reports whether the result differs from it.
+func MergeUpdate(info *alidns20150109.DescribeDomainRecordInfoResponseBody, in UpdateInput) (UpdateInput, bool) {
+	merged := UpdateInput{
+		RecordID: defaultString(in.RecordID, tea.StringValue(info.RecordId)),
+		Name:     defaultString(in.Name, tea.StringValue(info.RR)),
+		Type:     defaultString(in.Type, tea.StringValue(info.Type)),
+		Value:    defaultString(in.Value, tea.StringValue(info.Value)),
+		TTL:      defaultInt64(in.TTL, tea.Int64Value(info.TTL)),
+		Priority: defaultInt64(in.Priority, tea.Int64Value(info.Priority)),
+		Line:     defaultString(in.Line, tea.StringValue(info.Line)),
+	}
+	changed := merged.Name != tea.StringValue(info.RR) ||
+		!strings.EqualFold(merged.Type, tea.StringValue(info.Type)) ||
+		merged.Value != tea.StringValue(info.Value) ||
+		merged.TTL != tea.Int64Value(info.TTL) ||
+		merged.Priority != tea.Int64Value(info.Priority) ||
+		defaultString(merged.Line, defaultLine) != defaultString(tea.StringValue(info.Line), defaultLine)
+	return merged, changed
+}
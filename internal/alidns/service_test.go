@@ -13,6 +13,7 @@ package alidns
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
@@ -21,14 +22,37 @@ import (
 
 type fakeAPI struct {
 	addReq    *alidns20150109.AddDomainRecordRequest
+	deleteReq []*alidns20150109.DeleteDomainRecordRequest
 	delReq    *alidns20150109.DeleteSubDomainRecordsRequest
 	queryReq  *alidns20150109.DescribeDomainRecordsRequest
+	infoReq   *alidns20150109.DescribeDomainRecordInfoRequest
+	slbReq    *alidns20150109.SetDNSSLBStatusRequest
+	weightReq *alidns20150109.UpdateDNSSLBWeightRequest
+	statusReq []*alidns20150109.SetDomainRecordStatusRequest
 	updateReq *alidns20150109.UpdateDomainRecordRequest
+	remarkReq *alidns20150109.UpdateDomainRecordRemarkRequest
+	groupReq  []any
+	domainReq []*alidns20150109.DescribeDomainsRequest
+	logReq    []any
+	statReq   []any
+	lineReq   []any
+	dnssecReq []any
 
-	addResp    *alidns20150109.AddDomainRecordResponseBody
-	delResp    *alidns20150109.DeleteSubDomainRecordsResponseBody
-	queryResp  []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
-	updateResp *alidns20150109.UpdateDomainRecordResponseBody
+	addResp           *alidns20150109.AddDomainRecordResponseBody
+	delResp           *alidns20150109.DeleteSubDomainRecordsResponseBody
+	queryResp         []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+	infoResp          *alidns20150109.DescribeDomainRecordInfoResponseBody
+	subResp           []*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain
+	groupResp         []*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup
+	domainLogResp     [][]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog
+	recordLogResp     [][]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog
+	domainResp        []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain
+	recordStatResp    []*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic
+	recordSummaryResp []*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic
+	supportLineResp   []*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine
+	customLineResp    []*alidns20150109.DescribeCustomLinesResponseBodyCustomLines
+	dnssecResp        *alidns20150109.DescribeDomainDnssecInfoResponseBody
+	updateResp        *alidns20150109.UpdateDomainRecordResponseBody
 }
 
 func (f *fakeAPI) AddDomainRecord(_ context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
@@ -36,21 +60,60 @@ func (f *fakeAPI) AddDomainRecord(_ context.Context, req *alidns20150109.AddDoma
 	return f.addResp, nil
 }
 
+func (f *fakeAPI) DeleteDomainRecord(_ context.Context, req *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	f.deleteReq = append(f.deleteReq, req)
+	return &alidns20150109.DeleteDomainRecordResponseBody{RecordId: req.RecordId, RequestId: tea.String("del-" + tea.StringValue(req.RecordId))}, nil
+}
+
 func (f *fakeAPI) DeleteSubDomainRecords(_ context.Context, req *alidns20150109.DeleteSubDomainRecordsRequest) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
 	f.delReq = req
 	return f.delResp, nil
 }
 
+func (f *fakeAPI) DescribeDNSSLBSubDomains(_ context.Context, _ *alidns20150109.DescribeDNSSLBSubDomainsRequest) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error) {
+	return f.subResp, nil
+}
+
+func (f *fakeAPI) DescribeDomainRecordInfo(_ context.Context, req *alidns20150109.DescribeDomainRecordInfoRequest) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+	f.infoReq = req
+	return f.infoResp, nil
+}
+
+func (f *fakeAPI) DescribeDomains(_ context.Context, req *alidns20150109.DescribeDomainsRequest) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	f.domainReq = append(f.domainReq, req)
+	return f.domainResp, nil
+}
+
 func (f *fakeAPI) DescribeDomainRecords(_ context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
 	f.queryReq = req
 	return f.queryResp, nil
 }
 
+func (f *fakeAPI) SetDNSSLBStatus(_ context.Context, req *alidns20150109.SetDNSSLBStatusRequest) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+	f.slbReq = req
+	return &alidns20150109.SetDNSSLBStatusResponseBody{Open: req.Open}, nil
+}
+
+func (f *fakeAPI) SetDomainRecordStatus(_ context.Context, req *alidns20150109.SetDomainRecordStatusRequest) (*alidns20150109.SetDomainRecordStatusResponseBody, error) {
+	f.statusReq = append(f.statusReq, req)
+	return &alidns20150109.SetDomainRecordStatusResponseBody{RecordId: req.RecordId, Status: req.Status}, nil
+}
+
+func (f *fakeAPI) UpdateDNSSLBWeight(_ context.Context, req *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	f.weightReq = req
+	return &alidns20150109.UpdateDNSSLBWeightResponseBody{RecordId: req.RecordId, Weight: req.Weight}, nil
+}
+
 func (f *fakeAPI) UpdateDomainRecord(_ context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
 	f.updateReq = req
 	return f.updateResp, nil
 }
 
+func (f *fakeAPI) UpdateDomainRecordRemark(_ context.Context, req *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	f.remarkReq = req
+	return &alidns20150109.UpdateDomainRecordRemarkResponseBody{}, nil
+}
+
 func TestServiceAddBuildsRequest(t *testing.T) {
 	api := &fakeAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1")}}
 	svc := NewService(api)
@@ -92,6 +155,32 @@ func TestServiceDelBuildsRequest(t *testing.T) {
 	}
 }
 
+func TestServiceDelWithValueDeletesOnlyMatchingRecords(t *testing.T) {
+	api := &fakeAPI{queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		{RecordId: tea.String("r-1"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String("token-a")},
+		{RecordId: tea.String("r-2"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String("token-b")},
+		{RecordId: tea.String("r-3"), RR: tea.String("_acme-challenge.www"), Type: tea.String("TXT"), Value: tea.String("token-b")},
+	}}
+	svc := NewService(api)
+
+	resp, err := svc.Del(context.Background(), DelInput{DomainName: "example.com", Name: "_acme-challenge", Type: "TXT", Value: "token-b"})
+	if err != nil {
+		t.Fatalf("Del returned error: %v", err)
+	}
+	if api.delReq != nil {
+		t.Fatal("DeleteSubDomainRecords should not be called when a value is given")
+	}
+	if len(api.deleteReq) != 1 || tea.StringValue(api.deleteReq[0].RecordId) != "r-2" {
+		t.Fatalf("unexpected delete requests: %+v", api.deleteReq)
+	}
+	if tea.StringValue(resp.TotalCount) != "1" || tea.StringValue(resp.RequestId) != "del-r-2" {
+		t.Fatalf("unexpected del response: %+v", resp)
+	}
+	if tea.StringValue(api.queryReq.SearchMode) != "ADVANCED" || tea.StringValue(api.queryReq.RRKeyWord) != "_acme-challenge" || tea.StringValue(api.queryReq.TypeKeyWord) != "TXT" {
+		t.Fatalf("unexpected lookup request: %+v", api.queryReq)
+	}
+}
+
 func TestServiceQueryBuildsRequestAndReturnsRecords(t *testing.T) {
 	api := &fakeAPI{queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{{RecordId: tea.String("r-2")}}}
 	svc := NewService(api)
@@ -110,8 +199,15 @@ func TestServiceQueryBuildsRequestAndReturnsRecords(t *testing.T) {
 	}
 }
 
-func TestServiceUpdateBuildsRequest(t *testing.T) {
-	api := &fakeAPI{}
+func TestServiceUpdateKeepsCurrentFields(t *testing.T) {
+	api := &fakeAPI{infoResp: &alidns20150109.DescribeDomainRecordInfoResponseBody{
+		RecordId: tea.String("r-3"),
+		RR:       tea.String("www"),
+		Type:     tea.String("A"),
+		Value:    tea.String("1.2.3.4"),
+		TTL:      tea.Int64(60),
+		Line:     tea.String("telecom"),
+	}}
 	svc := NewService(api)
 
 	_, err := svc.Update(context.Background(), UpdateInput{
@@ -128,7 +224,196 @@ func TestServiceUpdateBuildsRequest(t *testing.T) {
 	if tea.StringValue(req.RecordId) != "r-3" || tea.StringValue(req.RR) != "www" || tea.StringValue(req.Type) != "A" || tea.StringValue(req.Value) != "1.2.3.5" {
 		t.Fatalf("unexpected update request core fields: %+v", req)
 	}
-	if tea.Int64Value(req.TTL) != 600 || tea.Int64Value(req.Priority) != 1 || tea.StringValue(req.Line) != "default" || tea.StringValue(req.Lang) != "en" {
-		t.Fatalf("unexpected update request defaults: %+v", req)
+	if tea.Int64Value(req.TTL) != 60 || tea.Int64Value(req.Priority) != 1 || tea.StringValue(req.Line) != "telecom" || tea.StringValue(req.Lang) != "en" {
+		t.Fatalf("update should keep the current TTL and line: %+v", req)
+	}
+
+	api.infoReq = nil
+	if _, err := svc.Update(context.Background(), UpdateInput{RecordID: "r-3", Name: "www", Type: "A", Value: "1.2.3.6", TTL: 600, Line: "default"}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if api.infoReq != nil {
+		t.Fatal("a complete input should not read the current record")
+	}
+}
+
+func TestMergeUpdateKeepsUnsetFields(t *testing.T) {
+	info := &alidns20150109.DescribeDomainRecordInfoResponseBody{
+		RecordId: tea.String("r-3"),
+		RR:       tea.String("www"),
+		Type:     tea.String("A"),
+		Value:    tea.String("1.2.3.4"),
+		TTL:      tea.Int64(600),
+		Line:     tea.String("telecom"),
+	}
+
+	merged, changed := MergeUpdate(info, UpdateInput{RecordID: "r-3", TTL: 60})
+	if !changed {
+		t.Fatal("TTL change should be reported")
+	}
+	want := UpdateInput{RecordID: "r-3", Name: "www", Type: "A", Value: "1.2.3.4", TTL: 60, Line: "telecom"}
+	if merged != want {
+		t.Fatalf("unexpected merged input: %+v", merged)
+	}
+
+	if _, changed := MergeUpdate(info, UpdateInput{RecordID: "r-3", Value: "1.2.3.4", TTL: 600}); changed {
+		t.Fatal("identical values should not be reported as a change")
+	}
+}
+
+func TestServiceAddSetsRemark(t *testing.T) {
+	api := &fakeAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-9")}}
+	svc := NewService(api)
+
+	if _, err := svc.Add(context.Background(), AddInput{DomainName: "example.com", Name: "www", Type: "A", Value: "1.2.3.4", Remark: "owner: ops"}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if tea.StringValue(api.remarkReq.RecordId) != "r-9" || tea.StringValue(api.remarkReq.Remark) != "owner: ops" {
+		t.Fatalf("unexpected remark request: %+v", api.remarkReq)
+	}
+}
+
+func TestServiceQueryFiltersByRemark(t *testing.T) {
+	api := &fakeAPI{queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		{RecordId: tea.String("r-1"), RR: tea.String("www"), Remark: tea.String("Owner: OPS-123")},
+		{RecordId: tea.String("r-2"), RR: tea.String("api")},
+	}}
+	svc := NewService(api)
+
+	records, err := svc.Query(context.Background(), QueryInput{DomainName: "example.com", Remark: "ops-123"})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(records) != 1 || tea.StringValue(records[0].RecordId) != "r-1" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if tea.StringValue(api.queryReq.SearchMode) != "LIKE" {
+		t.Fatalf("remark filter should not change the search mode: %+v", api.queryReq)
+	}
+}
+
+func (f *fakeAPI) AddCustomLine(_ context.Context, req *alidns20150109.AddCustomLineRequest) (*alidns20150109.AddCustomLineResponseBody, error) {
+	f.lineReq = append(f.lineReq, req)
+	return &alidns20150109.AddCustomLineResponseBody{LineId: tea.Int64(1), LineCode: tea.String("cust1")}, nil
+}
+
+func (f *fakeAPI) DeleteCustomLines(_ context.Context, req *alidns20150109.DeleteCustomLinesRequest) (*alidns20150109.DeleteCustomLinesResponseBody, error) {
+	f.lineReq = append(f.lineReq, req)
+	return &alidns20150109.DeleteCustomLinesResponseBody{}, nil
+}
+
+func (f *fakeAPI) DescribeCustomLines(_ context.Context, req *alidns20150109.DescribeCustomLinesRequest) ([]*alidns20150109.DescribeCustomLinesResponseBodyCustomLines, error) {
+	f.lineReq = append(f.lineReq, req)
+	return f.customLineResp, nil
+}
+
+func (f *fakeAPI) DescribeSupportLines(_ context.Context, req *alidns20150109.DescribeSupportLinesRequest) ([]*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine, error) {
+	f.lineReq = append(f.lineReq, req)
+	return f.supportLineResp, nil
+}
+
+func (f *fakeAPI) DescribeDomainDnssecInfo(_ context.Context, req *alidns20150109.DescribeDomainDnssecInfoRequest) (*alidns20150109.DescribeDomainDnssecInfoResponseBody, error) {
+	f.dnssecReq = append(f.dnssecReq, req)
+	return f.dnssecResp, nil
+}
+
+func (f *fakeAPI) SetDomainDnssecStatus(_ context.Context, req *alidns20150109.SetDomainDnssecStatusRequest) (*alidns20150109.SetDomainDnssecStatusResponseBody, error) {
+	f.dnssecReq = append(f.dnssecReq, req)
+	return &alidns20150109.SetDomainDnssecStatusResponseBody{RequestId: tea.String("dnssec")}, nil
+}
+
+func (f *fakeAPI) AddDomainGroup(_ context.Context, req *alidns20150109.AddDomainGroupRequest) (*alidns20150109.AddDomainGroupResponseBody, error) {
+	f.groupReq = append(f.groupReq, req)
+	return &alidns20150109.AddDomainGroupResponseBody{GroupId: tea.String("g-new"), GroupName: req.GroupName}, nil
+}
+
+func (f *fakeAPI) ChangeDomainGroup(_ context.Context, req *alidns20150109.ChangeDomainGroupRequest) (*alidns20150109.ChangeDomainGroupResponseBody, error) {
+	f.groupReq = append(f.groupReq, req)
+	return &alidns20150109.ChangeDomainGroupResponseBody{GroupId: req.GroupId}, nil
+}
+
+func (f *fakeAPI) DeleteDomainGroup(_ context.Context, req *alidns20150109.DeleteDomainGroupRequest) (*alidns20150109.DeleteDomainGroupResponseBody, error) {
+	f.groupReq = append(f.groupReq, req)
+	return &alidns20150109.DeleteDomainGroupResponseBody{}, nil
+}
+
+func (f *fakeAPI) DescribeDomainGroups(_ context.Context, _ *alidns20150109.DescribeDomainGroupsRequest) ([]*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error) {
+	return f.groupResp, nil
+}
+
+func (f *fakeAPI) UpdateDomainGroup(_ context.Context, req *alidns20150109.UpdateDomainGroupRequest) (*alidns20150109.UpdateDomainGroupResponseBody, error) {
+	f.groupReq = append(f.groupReq, req)
+	return &alidns20150109.UpdateDomainGroupResponseBody{GroupId: req.GroupId, GroupName: req.GroupName}, nil
+}
+
+// DescribeDomainLogs and DescribeRecordLogs serve one canned slice per page.
+func (f *fakeAPI) DescribeDomainLogs(_ context.Context, req *alidns20150109.DescribeDomainLogsRequest) ([]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog, error) {
+	f.logReq = append(f.logReq, req)
+	page := int(tea.Int64Value(req.PageNumber)) - 1
+	if page >= len(f.domainLogResp) {
+		return nil, nil
+	}
+	return f.domainLogResp[page], nil
+}
+
+func (f *fakeAPI) DescribeRecordLogs(_ context.Context, req *alidns20150109.DescribeRecordLogsRequest) ([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, error) {
+	f.logReq = append(f.logReq, req)
+	page := int(tea.Int64Value(req.PageNumber)) - 1
+	if page >= len(f.recordLogResp) {
+		return nil, nil
+	}
+	return f.recordLogResp[page], nil
+}
+
+func (f *fakeAPI) DescribeDomainStatistics(_ context.Context, req *alidns20150109.DescribeDomainStatisticsRequest) ([]*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic, error) {
+	f.statReq = append(f.statReq, req)
+	return nil, nil
+}
+
+func (f *fakeAPI) DescribeDomainStatisticsSummary(_ context.Context, req *alidns20150109.DescribeDomainStatisticsSummaryRequest) ([]*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	f.statReq = append(f.statReq, req)
+	return nil, nil
+}
+
+func (f *fakeAPI) DescribeRecordStatistics(_ context.Context, req *alidns20150109.DescribeRecordStatisticsRequest) ([]*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic, error) {
+	f.statReq = append(f.statReq, req)
+	return f.recordStatResp, nil
+}
+
+func (f *fakeAPI) DescribeRecordStatisticsSummary(_ context.Context, req *alidns20150109.DescribeRecordStatisticsSummaryRequest) ([]*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	f.statReq = append(f.statReq, req)
+	return f.recordSummaryResp, nil
+}
+
+// pagedRecordsAPI serves DescribeDomainRecords one canned slice per page.
+type pagedRecordsAPI struct {
+	*fakeAPI
+	pages [][]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+}
+
+func (f *pagedRecordsAPI) DescribeDomainRecords(_ context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	page := int(tea.Int64Value(req.PageNumber)) - 1
+	if page >= len(f.pages) {
+		return nil, nil
+	}
+	return f.pages[page], nil
+}
+
+func TestFindWalksAllPages(t *testing.T) {
+	first := make([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, recordPageSize)
+	for i := range first {
+		first[i] = &alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{RR: tea.String("www"), Type: tea.String("TXT"), Value: tea.String(fmt.Sprintf("other-%d", i))}
+	}
+	api := &pagedRecordsAPI{fakeAPI: &fakeAPI{}, pages: [][]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		first,
+		{{RecordId: tea.String("r-501"), RR: tea.String("www"), Type: tea.String("TXT"), Value: tea.String("token")}},
+	}}
+
+	records, err := NewService(api).Find(context.Background(), FindInput{DomainName: "example.com", Name: "www", Type: "TXT", Value: "token"})
+	if err != nil {
+		t.Fatalf("Find returned error: %v", err)
+	}
+	if len(records) != 1 || tea.StringValue(records[0].RecordId) != "r-501" {
+		t.Fatalf("expected the match from the second page, got %+v", records)
 	}
 }
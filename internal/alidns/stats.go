@@ -0,0 +1,166 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const statsPageSize int64 = 100
+
+type StatsInput struct {
+	// DomainName is required for Stats. StatsSummary without it totals
+	// every domain in the account.
+	DomainName string
+	// RR narrows Stats to a single record name.
+	RR string
+	// StartDate and EndDate are YYYY-MM-DD. An empty EndDate means today.
+	StartDate string
+	EndDate   string
+}
+
+// StatPoint is the query count of one interval.
+type StatPoint struct {
+	Timestamp int64
+	Count     int64
+}
+
+// StatTotal is the query count of one domain or subdomain over the period.
+type StatTotal struct {
+	Name  string
+	Count int64
+}
+
+// Stats returns the query volume of a domain, or of one RR, over time.
+func (s *Service) Stats(ctx context.Context, in StatsInput) ([]*StatPoint, error) {
+	if err := asciiNames(&in.DomainName, &in.RR); err != nil {
+		return nil, err
+	}
+
+	points := []*StatPoint{}
+	if in.RR != "" {
+		stats, err := s.api.DescribeRecordStatistics(ctx, &alidns20150109.DescribeRecordStatisticsRequest{
+			Lang:       tea.String("en"),
+			DomainName: tea.String(in.DomainName),
+			Rr:         tea.String(in.RR),
+			StartDate:  tea.String(in.StartDate),
+			EndDate:    optionalString(in.EndDate),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, stat := range stats {
+			points = append(points, &StatPoint{Timestamp: tea.Int64Value(stat.Timestamp), Count: tea.Int64Value(stat.Count)})
+		}
+	} else {
+		stats, err := s.api.DescribeDomainStatistics(ctx, &alidns20150109.DescribeDomainStatisticsRequest{
+			Lang:       tea.String("en"),
+			DomainName: tea.String(in.DomainName),
+			StartDate:  tea.String(in.StartDate),
+			EndDate:    optionalString(in.EndDate),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, stat := range stats {
+			points = append(points, &StatPoint{Timestamp: tea.Int64Value(stat.Timestamp), Count: tea.Int64Value(stat.Count)})
+		}
+	}
+
+	slices.SortFunc(points, func(a, b *StatPoint) int { return cmp.Compare(a.Timestamp, b.Timestamp) })
+	return points, nil
+}
+
+// StatsSummary totals the queries per subdomain of in.DomainName, or per
+// domain when it is empty, busiest first. Subdomains that have records but
+// received no queries are included with a zero count.
+func (s *Service) StatsSummary(ctx context.Context, in StatsInput) ([]*StatTotal, error) {
+	if err := asciiNames(&in.DomainName); err != nil {
+		return nil, err
+	}
+
+	totals := []*StatTotal{}
+	for page := int64(1); ; page++ {
+		var n int
+		if in.DomainName != "" {
+			stats, err := s.api.DescribeRecordStatisticsSummary(ctx, &alidns20150109.DescribeRecordStatisticsSummaryRequest{
+				Lang:       tea.String("en"),
+				DomainName: tea.String(in.DomainName),
+				StartDate:  tea.String(in.StartDate),
+				EndDate:    optionalString(in.EndDate),
+				PageNumber: tea.Int64(page),
+				PageSize:   tea.Int64(statsPageSize),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, stat := range stats {
+				totals = append(totals, &StatTotal{Name: tea.StringValue(stat.SubDomain), Count: tea.Int64Value(stat.Count)})
+			}
+			n = len(stats)
+		} else {
+			stats, err := s.api.DescribeDomainStatisticsSummary(ctx, &alidns20150109.DescribeDomainStatisticsSummaryRequest{
+				Lang:       tea.String("en"),
+				StartDate:  tea.String(in.StartDate),
+				EndDate:    optionalString(in.EndDate),
+				PageNumber: tea.Int64(page),
+				PageSize:   tea.Int64(statsPageSize),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, stat := range stats {
+				totals = append(totals, &StatTotal{Name: tea.StringValue(stat.DomainName), Count: tea.Int64Value(stat.Count)})
+			}
+			n = len(stats)
+		}
+		if int64(n) < statsPageSize {
+			break
+		}
+	}
+
+	if in.DomainName != "" {
+		records, err := s.Records(ctx, in.DomainName)
+		if err != nil {
+			return nil, err
+		}
+		seen := map[string]bool{}
+		for _, total := range totals {
+			seen[strings.ToLower(total.Name)] = true
+		}
+		for _, record := range records {
+			name := JoinFQDN(in.DomainName, tea.StringValue(record.RR))
+			if !seen[strings.ToLower(name)] {
+				seen[strings.ToLower(name)] = true
+				totals = append(totals, &StatTotal{Name: name})
+			}
+		}
+	}
+
+	slices.SortStableFunc(totals, func(a, b *StatTotal) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return totals, nil
+}
+
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return tea.String(v)
+}
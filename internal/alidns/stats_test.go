@@ -0,0 +1,68 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"testing"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestServiceStatsUsesRecordStatisticsForRR(t *testing.T) {
+	api := &fakeAPI{recordStatResp: []*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic{
+		{Timestamp: tea.Int64(2000), Count: tea.Int64(5)},
+		{Timestamp: tea.Int64(1000), Count: tea.Int64(3)},
+	}}
+	svc := NewService(api)
+
+	points, err := svc.Stats(context.Background(), StatsInput{DomainName: "example.com", RR: "www", StartDate: "2026-10-01"})
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+	if len(points) != 2 || points[0].Timestamp != 1000 || points[1].Count != 5 {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+	req := api.statReq[0].(*alidns20150109.DescribeRecordStatisticsRequest)
+	if tea.StringValue(req.Rr) != "www" || tea.StringValue(req.StartDate) != "2026-10-01" || req.EndDate != nil {
+		t.Fatalf("unexpected record statistics request: %+v", req)
+	}
+}
+
+func TestServiceStatsSummaryIncludesIdleRecords(t *testing.T) {
+	api := &fakeAPI{
+		recordSummaryResp: []*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic{
+			{SubDomain: tea.String("www.example.com"), Count: tea.Int64(10)},
+			{SubDomain: tea.String("api.example.com"), Count: tea.Int64(40)},
+		},
+		queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+			{RR: tea.String("www"), Type: tea.String("A")},
+			{RR: tea.String("old"), Type: tea.String("A")},
+		},
+	}
+	svc := NewService(api)
+
+	totals, err := svc.StatsSummary(context.Background(), StatsInput{DomainName: "example.com", StartDate: "2026-10-01"})
+	if err != nil {
+		t.Fatalf("StatsSummary returned error: %v", err)
+	}
+	want := []StatTotal{{"api.example.com", 40}, {"www.example.com", 10}, {"old.example.com", 0}}
+	if len(totals) != len(want) {
+		t.Fatalf("unexpected totals: %+v", totals)
+	}
+	for i, total := range totals {
+		if *total != want[i] {
+			t.Fatalf("total %d = %+v, want %+v", i, *total, want[i])
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"time"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const (
+	EventAdded    = "added"
+	EventRemoved  = "removed"
+	EventModified = "modified"
+)
+
+// RecordEvent is one difference between two reads of a domain. Added and
+// removed events carry Record; modified events carry Before, After and the
+// names of the fields that changed.
+type RecordEvent struct {
+	Time   time.Time
+	Event  string
+	Domain string
+	Record *Record  `json:",omitempty"`
+	Before *Record  `json:",omitempty"`
+	After  *Record  `json:",omitempty"`
+	Fields []string `json:",omitempty"`
+}
+
+// RecordEvents compares two reads of a domain. Unlike DiffRecords, records
+// are matched by ID, so an edited value is reported as a modification.
+func RecordEvents(domainName string, before, after []*Record) []*RecordEvent {
+	beforeByID := make(map[string]*Record, len(before))
+	for _, record := range before {
+		if record != nil {
+			beforeByID[tea.StringValue(record.RecordId)] = record
+		}
+	}
+
+	var events []*RecordEvent
+	seen := make(map[string]bool, len(after))
+	for _, record := range after {
+		if record == nil {
+			continue
+		}
+		id := tea.StringValue(record.RecordId)
+		seen[id] = true
+		old, ok := beforeByID[id]
+		if !ok {
+			events = append(events, &RecordEvent{Event: EventAdded, Domain: domainName, Record: record})
+			continue
+		}
+		if fields := modifiedFields(old, record); len(fields) > 0 {
+			events = append(events, &RecordEvent{Event: EventModified, Domain: domainName, Before: old, After: record, Fields: fields})
+		}
+	}
+	for _, record := range before {
+		if record != nil && !seen[tea.StringValue(record.RecordId)] {
+			events = append(events, &RecordEvent{Event: EventRemoved, Domain: domainName, Record: record})
+		}
+	}
+	return events
+}
+
+func modifiedFields(before, after *Record) []string {
+	var fields []string
+	for _, f := range []struct {
+		name          string
+		before, after string
+	}{
+		{"RR", tea.StringValue(before.RR), tea.StringValue(after.RR)},
+		{"Type", tea.StringValue(before.Type), tea.StringValue(after.Type)},
+		{"Line", tea.StringValue(before.Line), tea.StringValue(after.Line)},
+		{"Value", tea.StringValue(before.Value), tea.StringValue(after.Value)},
+		{"Status", tea.StringValue(before.Status), tea.StringValue(after.Status)},
+		{"Remark", tea.StringValue(before.Remark), tea.StringValue(after.Remark)},
+	} {
+		if f.before != f.after {
+			fields = append(fields, f.name)
+		}
+	}
+	if tea.Int64Value(before.TTL) != tea.Int64Value(after.TTL) {
+		fields = append(fields, "TTL")
+	}
+	if tea.Int64Value(before.Priority) != tea.Int64Value(after.Priority) {
+		fields = append(fields, "Priority")
+	}
+	if tea.Int32Value(before.Weight) != tea.Int32Value(after.Weight) {
+		fields = append(fields, "Weight")
+	}
+	return fields
+}
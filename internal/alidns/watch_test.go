@@ -0,0 +1,47 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestRecordEventsMatchesByID(t *testing.T) {
+	before := []*Record{
+		{RecordId: tea.String("1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.2.3.4"), TTL: tea.Int64(600), Status: tea.String("ENABLE")},
+		{RecordId: tea.String("2"), RR: tea.String("old"), Type: tea.String("A"), Value: tea.String("5.6.7.8"), TTL: tea.Int64(600)},
+		{RecordId: tea.String("3"), RR: tea.String("mail"), Type: tea.String("MX"), Value: tea.String("mx.example.com"), TTL: tea.Int64(600)},
+	}
+	after := []*Record{
+		{RecordId: tea.String("1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("9.9.9.9"), TTL: tea.Int64(60), Status: tea.String("ENABLE")},
+		{RecordId: tea.String("3"), RR: tea.String("mail"), Type: tea.String("MX"), Value: tea.String("mx.example.com"), TTL: tea.Int64(600)},
+		{RecordId: tea.String("4"), RR: tea.String("new"), Type: tea.String("CNAME"), Value: tea.String("example.net"), TTL: tea.Int64(600)},
+	}
+
+	events := RecordEvents("example.com", before, after)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d: %+v", len(events), events)
+	}
+	modified, added, removed := events[0], events[1], events[2]
+	if modified.Event != EventModified || tea.StringValue(modified.Before.Value) != "1.2.3.4" || tea.StringValue(modified.After.Value) != "9.9.9.9" || !slices.Equal(modified.Fields, []string{"Value", "TTL"}) {
+		t.Fatalf("unexpected modified event: %+v", modified)
+	}
+	if added.Event != EventAdded || tea.StringValue(added.Record.RecordId) != "4" || added.Domain != "example.com" {
+		t.Fatalf("unexpected added event: %+v", added)
+	}
+	if removed.Event != EventRemoved || tea.StringValue(removed.Record.RecordId) != "2" {
+		t.Fatalf("unexpected removed event: %+v", removed)
+	}
+}
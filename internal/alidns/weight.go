@@ -0,0 +1,119 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const slbSubDomainPageSize int64 = 100
+
+type SLBStatusInput struct {
+	DomainName string
+	Name       string
+	Type       string
+	// Line limits the change to one line. Empty applies to all lines.
+	Line string
+	Open bool
+}
+
+type WeightInput struct {
+	RecordID string
+	Weight   int32
+}
+
+type WeightsInput struct {
+	DomainName string
+	// Name limits the view to one RR. Empty lists every weighted RR.
+	Name string
+}
+
+// WeightedSubDomain is a weighted round-robin set together with the records
+// whose weights it distributes traffic across.
+type WeightedSubDomain struct {
+	*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain
+	Records []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+}
+
+func (s *Service) SetSLBStatus(ctx context.Context, in SLBStatusInput) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+	if err := asciiNames(&in.DomainName, &in.Name); err != nil {
+		return nil, err
+	}
+	req := &alidns20150109.SetDNSSLBStatusRequest{
+		Lang:       tea.String("en"),
+		DomainName: tea.String(in.DomainName),
+		SubDomain:  tea.String(JoinFQDN(in.DomainName, in.Name)),
+		Type:       tea.String(in.Type),
+		Open:       tea.Bool(in.Open),
+	}
+	if in.Line != "" {
+		req.Line = tea.String(in.Line)
+	}
+	return s.api.SetDNSSLBStatus(ctx, req)
+}
+
+func (s *Service) SetWeight(ctx context.Context, in WeightInput) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	req := &alidns20150109.UpdateDNSSLBWeightRequest{
+		Lang:     tea.String("en"),
+		RecordId: tea.String(in.RecordID),
+		Weight:   tea.Int32(in.Weight),
+	}
+	return s.api.UpdateDNSSLBWeight(ctx, req)
+}
+
+// Weights lists the weighted round-robin sets of a domain, walking all
+// result pages, and the current weight of every record in them.
+func (s *Service) Weights(ctx context.Context, in WeightsInput) ([]*WeightedSubDomain, error) {
+	if err := asciiNames(&in.DomainName, &in.Name); err != nil {
+		return nil, err
+	}
+	subDomains := []*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain{}
+	for page := int64(1); ; page++ {
+		req := &alidns20150109.DescribeDNSSLBSubDomainsRequest{
+			Lang:       tea.String("en"),
+			DomainName: tea.String(in.DomainName),
+			PageNumber: tea.Int64(page),
+			PageSize:   tea.Int64(slbSubDomainPageSize),
+		}
+		if in.Name != "" {
+			req.Rr = tea.String(in.Name)
+		}
+		subs, err := s.api.DescribeDNSSLBSubDomains(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		subDomains = append(subDomains, subs...)
+		if int64(len(subs)) < slbSubDomainPageSize {
+			break
+		}
+	}
+
+	sets := make([]*WeightedSubDomain, 0, len(subDomains))
+	for _, sub := range subDomains {
+		if sub == nil {
+			continue
+		}
+		rr := rrOf(in.DomainName, tea.StringValue(sub.SubDomain))
+		records, err := s.findRecords(ctx, in.DomainName, rr, tea.StringValue(sub.Type), "")
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, &WeightedSubDomain{
+			DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain: sub,
+			Records: records,
+		})
+	}
+	return sets, nil
+}
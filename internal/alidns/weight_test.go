@@ -0,0 +1,109 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestServiceSetSLBStatusBuildsRequest(t *testing.T) {
+	api := &fakeAPI{}
+	svc := NewService(api)
+
+	if _, err := svc.SetSLBStatus(context.Background(), SLBStatusInput{DomainName: "example.com", Name: "@", Type: "A", Open: true}); err != nil {
+		t.Fatalf("SetSLBStatus returned error: %v", err)
+	}
+
+	req := api.slbReq
+	if tea.StringValue(req.DomainName) != "example.com" || tea.StringValue(req.SubDomain) != "example.com" || tea.StringValue(req.Type) != "A" || !tea.BoolValue(req.Open) {
+		t.Fatalf("unexpected SLB status request: %+v", req)
+	}
+	if req.Line != nil {
+		t.Fatalf("line should be omitted when not set: %+v", req)
+	}
+}
+
+func TestServiceSetWeightBuildsRequest(t *testing.T) {
+	api := &fakeAPI{}
+	svc := NewService(api)
+
+	if _, err := svc.SetWeight(context.Background(), WeightInput{RecordID: "r-1", Weight: 80}); err != nil {
+		t.Fatalf("SetWeight returned error: %v", err)
+	}
+	if tea.StringValue(api.weightReq.RecordId) != "r-1" || tea.Int32Value(api.weightReq.Weight) != 80 {
+		t.Fatalf("unexpected weight request: %+v", api.weightReq)
+	}
+}
+
+func TestServiceWeightsAttachesRecords(t *testing.T) {
+	api := &fakeAPI{
+		subResp: []*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain{
+			{SubDomain: tea.String("www.example.com"), Type: tea.String("A"), Open: tea.Bool(true)},
+		},
+		queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+			{RecordId: tea.String("r-1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.1.1.1"), Weight: tea.Int32(80)},
+			{RecordId: tea.String("r-2"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("2.2.2.2"), Weight: tea.Int32(20)},
+			{RecordId: tea.String("r-3"), RR: tea.String("www.dev"), Type: tea.String("A"), Value: tea.String("3.3.3.3")},
+		},
+	}
+	svc := NewService(api)
+
+	sets, err := svc.Weights(context.Background(), WeightsInput{DomainName: "example.com"})
+	if err != nil {
+		t.Fatalf("Weights returned error: %v", err)
+	}
+	if len(sets) != 1 || len(sets[0].Records) != 2 {
+		t.Fatalf("unexpected weighted sets: %+v", sets)
+	}
+	if tea.StringValue(api.queryReq.RRKeyWord) != "www" || tea.StringValue(api.queryReq.TypeKeyWord) != "A" {
+		t.Fatalf("unexpected record lookup: %+v", api.queryReq)
+	}
+}
+
+// pagedSubDomainsAPI serves DescribeDNSSLBSubDomains one canned slice per page.
+type pagedSubDomainsAPI struct {
+	*fakeAPI
+	pages [][]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain
+}
+
+func (f *pagedSubDomainsAPI) DescribeDNSSLBSubDomains(_ context.Context, req *alidns20150109.DescribeDNSSLBSubDomainsRequest) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error) {
+	page := int(tea.Int64Value(req.PageNumber)) - 1
+	if page >= len(f.pages) {
+		return nil, nil
+	}
+	return f.pages[page], nil
+}
+
+func TestServiceWeightsWalksAllPages(t *testing.T) {
+	first := make([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, slbSubDomainPageSize)
+	for i := range first {
+		first[i] = &alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain{SubDomain: tea.String(fmt.Sprintf("s%d.example.com", i)), Type: tea.String("A")}
+	}
+	api := &pagedSubDomainsAPI{fakeAPI: &fakeAPI{}, pages: [][]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain{
+		first,
+		{{SubDomain: tea.String("www.example.com"), Type: tea.String("A")}},
+	}}
+	svc := NewService(api)
+
+	sets, err := svc.Weights(context.Background(), WeightsInput{DomainName: "example.com"})
+	if err != nil {
+		t.Fatalf("Weights returned error: %v", err)
+	}
+	if len(sets) != int(slbSubDomainPageSize)+1 || tea.StringValue(sets[len(sets)-1].SubDomain) != "www.example.com" {
+		t.Fatalf("expected the second page to be included, got %d sets", len(sets))
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PvtzZone is a PrivateZone zone. Vpcs is only filled by DescribeZoneInfo.
+type PvtzZone struct {
+	ZoneId      string
+	ZoneName    string
+	RecordCount int64
+	Vpcs        []PvtzVpc `json:",omitempty"`
+}
+
+// PvtzVpc is a VPC a zone is bound to. Records of a zone only resolve inside
+// its bound VPCs.
+type PvtzVpc struct {
+	RegionId string
+	VpcId    string
+	VpcName  string `json:",omitempty"`
+}
+
+type BindZoneVpcRequest struct {
+	ZoneId string
+	// Vpcs replaces the whole binding list of the zone.
+	Vpcs []PvtzVpc
+}
+
+type BindZoneVpcResponseBody struct {
+	RequestId string
+}
+
+// MatchZone finds a zone by ID or by name. PrivateZone allows the same name
+// in several zones, in which case only the ID is accepted.
+func MatchZone(zones []*PvtzZone, nameOrID string) (*PvtzZone, error) {
+	name := strings.TrimSuffix(nameOrID, ".")
+	var matched []*PvtzZone
+	for _, zone := range zones {
+		if zone.ZoneId == nameOrID {
+			return zone, nil
+		}
+		if strings.EqualFold(zone.ZoneName, name) {
+			matched = append(matched, zone)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("private zone %s not found", nameOrID)
+	case 1:
+		return matched[0], nil
+	}
+	ids := make([]string, 0, len(matched))
+	for _, zone := range matched {
+		ids = append(ids, zone.ZoneId)
+	}
+	return nil, fmt.Errorf("private zone name %s is shared by zones %s, use a zone ID instead", nameOrID, strings.Join(ids, ", "))
+}
+
+// ZoneService manages PrivateZone zones themselves; their records go through
+// Service with a PvtzAPI.
+type ZoneService struct {
+	api PvtzAPI
+}
+
+func NewZoneService(api PvtzAPI) *ZoneService {
+	return &ZoneService{api: api}
+}
+
+func (s *ZoneService) Zones(ctx context.Context) ([]*PvtzZone, error) {
+	return s.api.DescribeZones(ctx)
+}
+
+type ZoneBindInput struct {
+	// Zone is a zone name or ID.
+	Zone string
+	Vpcs []PvtzVpc
+	// Replace drops the existing bindings instead of keeping them.
+	Replace bool
+}
+
+type ZoneBindResult struct {
+	*PvtzZone
+	RequestId string
+}
+
+// Bind binds a zone to VPCs. BindZoneVpc replaces the binding list, so
+// unless in.Replace is set the current bindings are read first and kept.
+func (s *ZoneService) Bind(ctx context.Context, in ZoneBindInput) (*ZoneBindResult, error) {
+	zone, err := s.zone(ctx, in.Zone)
+	if err != nil {
+		return nil, err
+	}
+	info, err := s.api.DescribeZoneInfo(ctx, zone.ZoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	var vpcs []PvtzVpc
+	if !in.Replace {
+		vpcs = append(vpcs, info.Vpcs...)
+	}
+	for _, vpc := range in.Vpcs {
+		if !containsVpc(vpcs, vpc) {
+			vpcs = append(vpcs, vpc)
+		}
+	}
+	resp, err := s.api.BindZoneVpc(ctx, &BindZoneVpcRequest{ZoneId: zone.ZoneId, Vpcs: vpcs})
+	if err != nil {
+		return nil, err
+	}
+	info.Vpcs = vpcs
+	result := &ZoneBindResult{PvtzZone: info}
+	if resp != nil {
+		result.RequestId = resp.RequestId
+	}
+	return result, nil
+}
+
+func (s *ZoneService) zone(ctx context.Context, nameOrID string) (*PvtzZone, error) {
+	zones, err := s.api.DescribeZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchZone(zones, nameOrID)
+}
+
+func containsVpc(vpcs []PvtzVpc, vpc PvtzVpc) bool {
+	for _, v := range vpcs {
+		if v.VpcId == vpc.VpcId && v.RegionId == vpc.RegionId {
+			return true
+		}
+	}
+	return false
+}
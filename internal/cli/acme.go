@@ -0,0 +1,124 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/dnscheck"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	"github.com/Joey-Kot/alidns/internal/notify"
+)
+
+const defaultACMEWait = 2 * time.Minute
+
+func runACME(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	action, args, helpShown, err := parseAction("acme", args, func() {
+		printACMEUsage(deps.Stderr, globalOutput)
+	}, "present", "cleanup")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newACMEFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+		requiredArg{name: "-fqdn", value: f.fqdn},
+		requiredArg{name: "-value", value: f.value},
+	); err != nil {
+		return err
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	result, err := applyACME(ctx, deps, f.ak, svc, action, alidns.ACMEInput{FQDN: f.fqdn, Value: f.value}, f.wait)
+	if err != nil {
+		return err
+	}
+
+	return Print(deps.Stdout, result, output)
+}
+
+// applyACME runs a present or cleanup action and, after present, waits for
+// the authoritative nameservers to serve the value. Challenge records are
+// short-lived, so changes are notified but not journaled.
+func applyACME(ctx context.Context, deps Deps, ak string, svc *alidns.Service, action string, in alidns.ACMEInput, wait waitFlags) (*alidns.ACMEResult, error) {
+	var (
+		result *alidns.ACMEResult
+		err    error
+	)
+	if action == "present" {
+		result, err = svc.ACMEPresent(ctx, in)
+	} else {
+		result, err = svc.ACMECleanup(ctx, in)
+	}
+	if err != nil || result.Changed {
+		reportACME(ctx, deps, ak, action, in, result, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if action == "present" && wait.wait > 0 {
+		_, err := waitPropagation(ctx, wait, dnscheck.Expect{
+			DomainName: result.DomainName,
+			FQDN:       strings.TrimSuffix(in.FQDN, "."),
+			Type:       "TXT",
+			Value:      in.Value,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func reportACME(ctx context.Context, deps Deps, ak, action string, in alidns.ACMEInput, result *alidns.ACMEResult, err error) {
+	domainName, rr, _ := alidns.SplitFQDN(in.FQDN)
+	ev := &notify.Event{
+		Operation: journal.OpAdd,
+		Domain:    domainName,
+		Record:    recordSummary(domainName, rr, "TXT", in.Value),
+	}
+	if action == "cleanup" {
+		ev.Operation = journal.OpDel
+	}
+	if result != nil {
+		ev.RequestId = result.RequestId
+		if action == "present" {
+			ev.After = result
+		}
+	}
+	reportChange(ctx, deps, ak, ev, err)
+}
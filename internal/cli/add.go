@@ -15,7 +15,11 @@ import (
 	"context"
 	"fmt"
 
-	"alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/dnscheck"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
 )
 
 func runAdd(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
@@ -31,13 +35,14 @@ func runAdd(ctx context.Context, args []string, globalOutput OutputFormat, deps
 	if err := requireAll(
 		requiredArg{name: "-ak", value: f.ak},
 		requiredArg{name: "-sk", value: f.sk},
-		requiredArg{name: "-domain", value: f.domain},
-		requiredArg{name: "-name", value: f.name},
 		requiredArg{name: "-type", value: f.rType},
 		requiredArg{name: "-value", value: f.value},
 	); err != nil {
 		return err
 	}
+	if err := requireTarget(f.fqdn, f.domain, f.name, true); err != nil {
+		return err
+	}
 
 	output, err := ParseOutputFormat(f.output)
 	if err != nil {
@@ -49,8 +54,16 @@ func runAdd(ctx context.Context, args []string, globalOutput OutputFormat, deps
 		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
 	}
 	svc := alidns.NewService(api)
+	if f.fqdn != "" {
+		if f.domain, f.name, err = resolveFQDN(ctx, deps, svc, f.ak, f.fqdn); err != nil {
+			return err
+		}
+	}
+	if err := checkLine(ctx, deps, svc, f.ak, f.domain, f.line); err != nil {
+		return err
+	}
 
-	resp, err := svc.Add(ctx, alidns.AddInput{
+	in := alidns.AddInput{
 		DomainName: f.domain,
 		Name:       f.name,
 		Type:       f.rType,
@@ -58,10 +71,53 @@ func runAdd(ctx context.Context, args []string, globalOutput OutputFormat, deps
 		TTL:        f.ttl,
 		Priority:   f.priority,
 		Line:       f.line,
-	})
+		Remark:     f.remark,
+	}
+	var before []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+	if deps.journal() != nil {
+		before, err = svc.Find(ctx, alidns.FindInput{DomainName: f.domain, Name: f.name, Type: f.rType})
+		if err != nil {
+			return fmt.Errorf("读取变更前记录失败: %w", err)
+		}
+	}
+
+	resp, err := svc.Add(ctx, in)
+	entry := &journal.Entry{Domain: f.domain, Operation: journal.OpAdd, Request: in, Before: before}
+	if resp != nil {
+		entry.RequestId = tea.StringValue(resp.RequestId)
+		entry.RecordIds = []string{tea.StringValue(resp.RecordId)}
+	}
+	summary := recordSummary(f.domain, f.name, f.rType, f.value)
+	if err != nil && resp != nil {
+		// The record was created before its remark failed. Journal and
+		// report the add on its own so that it can be undone, and the
+		// remark as a separate failure.
+		added := in
+		added.Remark = ""
+		entry.Request = added
+		recordChange(ctx, deps, f.ak, entry, summary, added, nil)
+		remark := f.remark
+		failed := &journal.Entry{
+			Domain:    f.domain,
+			Operation: journal.OpUpdate,
+			Request:   updateRequest{UpdateInput: alidns.UpdateInput{RecordID: entry.RecordIds[0]}, Remark: &remark},
+		}
+		recordChange(ctx, deps, f.ak, failed, summary, failed.Request, err)
+		return err
+	}
+	recordChange(ctx, deps, f.ak, entry, summary, in, err)
 	if err != nil {
 		return err
 	}
+	if f.wait.wait <= 0 {
+		return Print(deps.Stdout, resp, output)
+	}
 
-	return Print(deps.Stdout, resp, output)
+	report, waitErr := waitPropagation(ctx, f.wait, dnscheck.Expect{
+		DomainName: f.domain,
+		FQDN:       alidns.JoinFQDN(f.domain, f.name),
+		Type:       f.rType,
+		Value:      f.value,
+	})
+	return printWithWaitError(deps, addOutput{AddDomainRecordResponseBody: resp, Propagation: report}, output, waitErr)
 }
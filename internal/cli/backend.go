@@ -0,0 +1,54 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+const (
+	backendAlidns = "alidns"
+	backendPvtz   = "pvtz"
+)
+
+// withBackend points deps.NewAPI at the record backend chosen by --backend.
+// Caches and journal entries are kept apart per backend, since record IDs and
+// zone names mean different things in each.
+func withBackend(deps Deps, backend string) (Deps, error) {
+	switch backend {
+	case backendAlidns:
+		return deps, nil
+	case backendPvtz:
+		if deps.NewPvtzAPI == nil {
+			return deps, fmt.Errorf("invalid deps: NewPvtzAPI is nil")
+		}
+		newPvtzAPI := deps.NewPvtzAPI
+		deps.NewAPI = func(accessKeyID, accessKeySecret string) (alidns.DNSAPI, error) {
+			return newPvtzAPI(accessKeyID, accessKeySecret)
+		}
+		deps.backend = backendPvtz
+		return deps, nil
+	default:
+		return deps, fmt.Errorf("unsupported backend %q, expected %s|%s", backend, backendAlidns, backendPvtz)
+	}
+}
+
+// backendName names the backend stored in Deps and journal entries, where
+// alidns is left empty.
+func backendName(backend string) string {
+	if backend == "" {
+		return backendAlidns
+	}
+	return backend
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/snapshot"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type backupResult struct {
+	Domain      string
+	Path        string
+	RecordCount int
+}
+
+func runBackup(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	fs, f := newBackupFlagSet(deps.Stderr, globalOutput)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+		requiredArg{name: "-dir", value: f.dir},
+	); err != nil {
+		return err
+	}
+	if countSet(f.domain != "", f.group != "", f.allDomains) != 1 {
+		return fmt.Errorf("错误: 需要且只能指定 -domain、-group 或 --all-domains 之一")
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	domains := []string{f.domain}
+	switch {
+	case f.group != "":
+		if domains, err = targetDomains(ctx, svc, f.group); err != nil {
+			return err
+		}
+	case f.allDomains:
+		all, err := svc.Domains(ctx)
+		if err != nil {
+			return err
+		}
+		domains = domains[:0]
+		for _, d := range all {
+			domains = append(domains, tea.StringValue(d.DomainName))
+		}
+	}
+
+	results := make([]backupResult, 0, len(domains))
+	for _, domain := range domains {
+		records, err := svc.Records(ctx, domain)
+		if err != nil {
+			return fmt.Errorf("读取 %s 记录失败: %w", domain, err)
+		}
+		path, err := snapshot.New(domain, records).WriteDir(f.dir)
+		if err != nil {
+			return fmt.Errorf("写入 %s 快照失败: %w", domain, err)
+		}
+		results = append(results, backupResult{Domain: domain, Path: path, RecordCount: len(records)})
+	}
+
+	return Print(deps.Stdout, results, output)
+}
@@ -15,7 +15,10 @@ import (
 	"context"
 	"fmt"
 
-	"alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
 )
 
 func runDel(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
@@ -31,12 +34,13 @@ func runDel(ctx context.Context, args []string, globalOutput OutputFormat, deps
 	if err := requireAll(
 		requiredArg{name: "-ak", value: f.ak},
 		requiredArg{name: "-sk", value: f.sk},
-		requiredArg{name: "-domain", value: f.domain},
-		requiredArg{name: "-name", value: f.name},
 		requiredArg{name: "-type", value: f.rType},
 	); err != nil {
 		return err
 	}
+	if err := requireTarget(f.fqdn, f.domain, f.name, true); err != nil {
+		return err
+	}
 
 	output, err := ParseOutputFormat(f.output)
 	if err != nil {
@@ -48,12 +52,32 @@ func runDel(ctx context.Context, args []string, globalOutput OutputFormat, deps
 		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
 	}
 	svc := alidns.NewService(api)
+	if f.fqdn != "" {
+		if f.domain, f.name, err = resolveFQDN(ctx, deps, svc, f.ak, f.fqdn); err != nil {
+			return err
+		}
+	}
 
-	resp, err := svc.Del(ctx, alidns.DelInput{
+	in := alidns.DelInput{
 		DomainName: f.domain,
 		Name:       f.name,
 		Type:       f.rType,
-	})
+		Value:      f.value,
+	}
+	var before []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+	if deps.journal() != nil {
+		before, err = svc.Find(ctx, alidns.FindInput{DomainName: f.domain, Name: f.name, Type: f.rType, Value: f.value})
+		if err != nil {
+			return fmt.Errorf("读取变更前记录失败: %w", err)
+		}
+	}
+
+	resp, err := svc.Del(ctx, in)
+	entry := &journal.Entry{Domain: f.domain, Operation: journal.OpDel, Request: in, Before: before}
+	if resp != nil {
+		entry.RequestId = tea.StringValue(resp.RequestId)
+	}
+	recordChange(ctx, deps, f.ak, entry, recordSummary(f.domain, f.name, f.rType, f.value), nil, err)
 	if err != nil {
 		return err
 	}
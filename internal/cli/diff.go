@@ -0,0 +1,202 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/snapshot"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// exitDrift is the status diff exits with when the records differ.
+const exitDrift = 2
+
+const (
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+)
+
+type diffResult struct {
+	Domain string
+	Drift  bool
+	*alidns.RecordDiff
+}
+
+func runDiff(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	fs, f := newDiffFlagSet(deps.Stderr, globalOutput)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(requiredArg{name: "-f", value: f.file}); err != nil {
+		return err
+	}
+	if f.from == "" {
+		if err := requireAll(
+			requiredArg{name: "-ak", value: f.ak},
+			requiredArg{name: "-sk", value: f.sk},
+		); err != nil {
+			return err
+		}
+	}
+	text := f.output == "text"
+	var output OutputFormat
+	if !text {
+		if output, err = ParseOutputFormat(f.output); err != nil {
+			return fmt.Errorf("invalid --output value %q, expected json|pretty|text", f.output)
+		}
+	}
+	color, err := useColor(f.color, deps)
+	if err != nil {
+		return err
+	}
+
+	desired, err := snapshot.Load(f.file)
+	if err != nil {
+		return err
+	}
+	domain := desired.Domain
+	if f.domain != "" {
+		domain = f.domain
+	}
+
+	var current []*alidns.Record
+	if f.from != "" {
+		base, err := snapshot.Load(f.from)
+		if err != nil {
+			return err
+		}
+		current = base.Records
+		if domain == "" {
+			domain = base.Domain
+		}
+	} else {
+		if domain == "" {
+			return fmt.Errorf("错误: %s 未指定域名，请使用 -domain", f.file)
+		}
+		api, err := deps.NewAPI(f.ak, f.sk)
+		if err != nil {
+			return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+		}
+		current, err = alidns.NewService(api).Records(ctx, domain)
+		if err != nil {
+			return fmt.Errorf("读取 %s 当前记录失败: %w", domain, err)
+		}
+	}
+
+	diff := alidns.DiffRecords(current, desired.Records)
+	result := diffResult{Domain: domain, Drift: !diff.Empty(), RecordDiff: diff}
+	if text {
+		err = printDiffText(deps.Stdout, result, color)
+	} else {
+		err = Print(deps.Stdout, result, output)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Drift {
+		return &ExitError{Code: exitDrift, Err: fmt.Errorf("%s: %d 条新增, %d 条删除, %d 条变更",
+			domain, len(diff.Added), len(diff.Removed), len(diff.Changed))}
+	}
+	return nil
+}
+
+// useColor resolves -color auto|always|never. auto colors only a terminal
+// stdout and honours NO_COLOR.
+func useColor(mode string, deps Deps) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if _, ok := deps.lookupEnv("NO_COLOR"); ok {
+			return false, nil
+		}
+		file, ok := deps.Stdout.(*os.File)
+		if !ok {
+			return false, nil
+		}
+		info, err := file.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid -color value %q, expected auto|always|never", mode)
+	}
+}
+
+func printDiffText(w io.Writer, result diffResult, color bool) error {
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + colorReset
+	}
+
+	var b strings.Builder
+	if !result.Drift {
+		fmt.Fprintf(&b, "%s: 无漂移\n", result.Domain)
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	for _, r := range result.Added {
+		b.WriteString(paint(colorGreen, "+ "+formatRecord(r)) + "\n")
+	}
+	for _, r := range result.Removed {
+		b.WriteString(paint(colorRed, "- "+formatRecord(r)) + "\n")
+	}
+	for _, c := range result.Changed {
+		changes := make([]string, 0, len(c.Fields))
+		for _, field := range c.Fields {
+			changes = append(changes, fmt.Sprintf("%s %s -> %s", field, recordField(c.Before, field), recordField(c.After, field)))
+		}
+		b.WriteString(paint(colorYellow, "~ "+formatRecord(c.Before)+": "+strings.Join(changes, ", ")) + "\n")
+	}
+	fmt.Fprintf(&b, "%s: %d 条新增, %d 条删除, %d 条变更\n",
+		result.Domain, len(result.Added), len(result.Removed), len(result.Changed))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatRecord(r *alidns.Record) string {
+	return fmt.Sprintf("%s %s %s %s (TTL %d, %s)",
+		tea.StringValue(r.RR), tea.StringValue(r.Type), tea.StringValue(r.Line),
+		tea.StringValue(r.Value), tea.Int64Value(r.TTL), recordField(r, "Status"))
+}
+
+func recordField(r *alidns.Record, field string) string {
+	switch field {
+	case "TTL":
+		return fmt.Sprint(tea.Int64Value(r.TTL))
+	case "Priority":
+		return fmt.Sprint(tea.Int64Value(r.Priority))
+	case "Remark":
+		return fmt.Sprintf("%q", tea.StringValue(r.Remark))
+	case "Status":
+		if r.Status == nil {
+			return "ENABLE"
+		}
+		return strings.ToUpper(tea.StringValue(r.Status))
+	}
+	return ""
+}
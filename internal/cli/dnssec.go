@@ -0,0 +1,145 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/dnscheck"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type dnssecOutput struct {
+	*alidns.DNSSECInfo
+	// DS is the delegation signer data to enter at the registrar.
+	DS *alidns.DSRecord `json:",omitempty"`
+	// ParentDS is what the parent zone currently publishes.
+	ParentDS *dnscheck.DSReport `json:",omitempty"`
+}
+
+func runDNSSEC(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	action, args, helpShown, err := parseAction("dnssec", args, func() {
+		printDNSSECUsage(deps.Stderr, globalOutput)
+	}, "status", "enable", "disable")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newDNSSECFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+		requiredArg{name: "-domain", value: f.domain},
+	); err != nil {
+		return err
+	}
+	if action != "enable" && f.timeout <= 0 {
+		return fmt.Errorf("错误: -timeout 必须大于 0")
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+	domainName, err := alidns.ToASCII(f.domain)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "enable":
+		if _, err := svc.SetDNSSEC(ctx, domainName, true); err != nil {
+			return err
+		}
+	case "disable":
+		// Unsigning a zone whose parent still publishes a DS record makes
+		// validating resolvers reject every answer from it.
+		if !f.force {
+			report, err := checkParentDS(ctx, f, domainName, nil)
+			if err != nil {
+				return fmt.Errorf("检查父域 DS 记录失败: %w (可使用 -force 跳过)", err)
+			}
+			if published := parentDS(report); len(published) > 0 {
+				return fmt.Errorf("错误: 父域 %s 仍发布 %s 的 DS 记录 (%s)，请先在注册商处删除，或使用 -force",
+					report.Parent, domainName, strings.Join(published, "; "))
+			}
+		}
+		if _, err := svc.SetDNSSEC(ctx, domainName, false); err != nil {
+			return err
+		}
+	}
+
+	info, err := svc.DNSSEC(ctx, domainName)
+	if err != nil {
+		return err
+	}
+	result := dnssecOutput{DNSSECInfo: info}
+	if info == nil || !strings.EqualFold(tea.StringValue(info.Status), "ON") || tea.StringValue(info.DsRecord) == "" {
+		return Print(deps.Stdout, result, output)
+	}
+	ds, err := alidns.ParseDSRecord(tea.StringValue(info.DsRecord))
+	if err != nil {
+		return err
+	}
+	result.DS = &ds
+	// A freshly enabled zone has no DS at the registrar yet.
+	if action != "status" || !f.check {
+		return Print(deps.Stdout, result, output)
+	}
+
+	if result.ParentDS, err = checkParentDS(ctx, f, domainName, []string{ds.String()}); err != nil {
+		return fmt.Errorf("检查父域 DS 记录失败: %w", err)
+	}
+	var checkErr error
+	if !result.ParentDS.Matched {
+		checkErr = &ExitError{Code: exitDrift, Err: fmt.Errorf("父域 %s 的 DS 记录与 %s 的密钥不一致，请在注册商处填写: %s",
+			result.ParentDS.Parent, domainName, ds)}
+	}
+	return printWithWaitError(deps, result, output, checkErr)
+}
+
+func checkParentDS(ctx context.Context, f *dnssecFlags, domainName string, expected []string) (*dnscheck.DSReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	return newChecker(f.resolver, f.nameservers).CheckDS(ctx, domainName, expected)
+}
+
+// parentDS returns every DS record any parent nameserver answered with.
+func parentDS(report *dnscheck.DSReport) []string {
+	var all []string
+	for _, ns := range report.Nameservers {
+		for _, answer := range ns.Answers {
+			if !slices.Contains(all, answer) {
+				all = append(all, answer)
+			}
+		}
+	}
+	return all
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+)
+
+func runDomain(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	action, args, helpShown, err := parseAction("domain", args, func() {
+		printDomainUsage(deps.Stderr, globalOutput)
+	}, "list", "move")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newDomainFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	required := []requiredArg{
+		{name: "-ak", value: f.ak},
+		{name: "-sk", value: f.sk},
+	}
+	if action == "move" {
+		required = append(required,
+			requiredArg{name: "-domain", value: f.domain},
+			requiredArg{name: "-group", value: f.group},
+		)
+	}
+	if err := requireAll(required...); err != nil {
+		return err
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	var resp any
+	switch action {
+	case "list":
+		var domains []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain
+		if f.group != "" {
+			domains, err = svc.GroupDomains(ctx, f.group)
+		} else {
+			domains, err = svc.Domains(ctx)
+		}
+		resp = domains
+	case "move":
+		resp, err = svc.MoveDomain(ctx, f.domain, f.group)
+	}
+	if err != nil {
+		return err
+	}
+
+	return Print(deps.Stdout, resp, output)
+}
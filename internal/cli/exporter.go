@@ -0,0 +1,66 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/exporter"
+)
+
+func runExporter(ctx context.Context, args []string, deps Deps) error {
+	fs, f := newExporterFlagSet(deps.Stderr)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+	); err != nil {
+		return err
+	}
+	if countSet(f.domain != "", f.group != "") != 1 {
+		return fmt.Errorf("错误: 需要且只能指定 -domain 或 -group 之一")
+	}
+	if f.interval <= 0 {
+		return fmt.Errorf("错误: -interval 必须大于 0")
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	domains, err := listedDomains(ctx, alidns.NewService(api), f.domain, f.group)
+	if err != nil {
+		return err
+	}
+	e := exporter.New(api, exporter.Options{Domains: domains, ValueInfo: f.valueInfo})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.Handler())
+	return serve(ctx, "exporter", f.listen, mux, func(addr net.Addr) {
+		_, _ = fmt.Fprintf(deps.Stderr, "exporter 已启动: http://%s/metrics\n", addr)
+	}, func(ctx context.Context) {
+		e.Run(ctx, f.interval, func(err error) {
+			_, _ = fmt.Fprintf(deps.Stderr, "警告: 采集失败: %v\n", err)
+		})
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/externaldns"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	"github.com/Joey-Kot/alidns/internal/notify"
+)
+
+func runExternalDNS(ctx context.Context, args []string, deps Deps) error {
+	fs, f := newExternalDNSFlagSet(deps.Stderr)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+		requiredArg{name: "-domain", value: f.domain},
+	); err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	domains := splitList(f.domain)
+	p := externaldns.New(api, externaldns.Options{
+		Domains: domains,
+		OnChange: func(ctx context.Context, change externaldns.Change, err error) {
+			reportEndpointChange(ctx, deps, f.ak, domains, change, err)
+		},
+	})
+
+	handler := p.Handler(func(err error) {
+		_, _ = fmt.Fprintf(deps.Stderr, "警告: 处理 external-dns 请求失败: %v\n", err)
+	})
+	return serve(ctx, "external-dns webhook", f.listen, handler, func(addr net.Addr) {
+		_, _ = fmt.Fprintf(deps.Stderr, "external-dns webhook 已启动: http://%s\n", addr)
+	}, nil)
+}
+
+var endpointOperations = map[string]string{
+	externaldns.ChangeCreate: journal.OpAdd,
+	externaldns.ChangeUpdate: journal.OpUpdate,
+	externaldns.ChangeDelete: journal.OpDel,
+}
+
+// reportEndpointChange notifies one endpoint external-dns changed. Like
+// ACME challenges these changes are owned by another tool and not journaled.
+func reportEndpointChange(ctx context.Context, deps Deps, ak string, domains []string, change externaldns.Change, err error) {
+	ep := change.Endpoint
+	domainName, rr, ok := alidns.MatchFQDN(ep.DNSName, domains)
+	if !ok {
+		domainName, rr = strings.TrimSuffix(ep.DNSName, "."), "@"
+	}
+	ev := &notify.Event{
+		Operation: endpointOperations[change.Action],
+		Domain:    domainName,
+		Record:    recordSummary(domainName, rr, ep.RecordType, strings.Join(ep.Targets, ",")),
+	}
+	if change.Action != externaldns.ChangeDelete {
+		ev.After = ep
+	}
+	reportChange(ctx, deps, ak, ev, err)
+}
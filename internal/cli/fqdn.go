@@ -0,0 +1,82 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// domainCacheTTL is how long the account's domain list is trusted before
+// DescribeDomains is called again.
+const domainCacheTTL = time.Hour
+
+type domainCache struct {
+	Fetched time.Time
+	Domains []string
+}
+
+// requireTarget checks that a record is addressed either by -fqdn or by
+// -domain (and -name when withName is set), but not both.
+func requireTarget(fqdn, domain, name string, withName bool) error {
+	if fqdn != "" {
+		if domain != "" || name != "" {
+			return fmt.Errorf("错误: -fqdn 不能与 -domain、-name 同时使用")
+		}
+		return nil
+	}
+	args := []requiredArg{{name: "-domain", value: domain}}
+	if withName {
+		args = append(args, requiredArg{name: "-name", value: name})
+	}
+	return requireAll(args...)
+}
+
+// resolveFQDN splits fqdn at the longest domain hosted in the account. The
+// domain list is cached per AccessKey in the state directory and refreshed
+// when stale or when fqdn matches none of the cached domains.
+func resolveFQDN(ctx context.Context, deps Deps, svc *alidns.Service, accessKeyID, fqdn string) (domainName, rr string, err error) {
+	if _, err := alidns.ToASCII(fqdn); err != nil {
+		return "", "", err
+	}
+	kind := "domains"
+	if deps.backend == backendPvtz {
+		kind = "zones"
+	}
+	path := stateCachePath(deps, kind, accessKeyID)
+	var cache domainCache
+	if readStateCache(path, &cache) && time.Since(cache.Fetched) < domainCacheTTL {
+		if domainName, rr, ok := alidns.MatchFQDN(fqdn, cache.Domains); ok {
+			return domainName, rr, nil
+		}
+	}
+
+	all, err := svc.Domains(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("读取域名列表失败: %w", err)
+	}
+	cache = domainCache{Fetched: time.Now().UTC(), Domains: make([]string, 0, len(all))}
+	for _, d := range all {
+		cache.Domains = append(cache.Domains, tea.StringValue(d.DomainName))
+	}
+	writeStateCache(path, cache)
+
+	domainName, rr, ok := alidns.MatchFQDN(fqdn, cache.Domains)
+	if !ok {
+		return "", "", fmt.Errorf("错误: %s 不属于账号下的任何域名", fqdn)
+	}
+	return domainName, rr, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func runGroup(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	action, args, helpShown, err := parseAction("group", args, func() {
+		printGroupUsage(deps.Stderr, globalOutput)
+	}, "list", "add", "rename", "del")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newGroupFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	required := []requiredArg{
+		{name: "-ak", value: f.ak},
+		{name: "-sk", value: f.sk},
+	}
+	switch action {
+	case "add", "del":
+		required = append(required, requiredArg{name: "-name", value: f.name})
+	case "rename":
+		required = append(required,
+			requiredArg{name: "-name", value: f.name},
+			requiredArg{name: "-new-name", value: f.newName},
+		)
+	}
+	if err := requireAll(required...); err != nil {
+		return err
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	var resp any
+	switch action {
+	case "list":
+		resp, err = svc.Groups(ctx)
+	case "add":
+		resp, err = svc.AddGroup(ctx, f.name)
+	case "rename":
+		resp, err = svc.RenameGroup(ctx, f.name, f.newName)
+	case "del":
+		resp, err = svc.DeleteGroup(ctx, f.name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return Print(deps.Stdout, resp, output)
+}
+
+// targetDomains returns the names of the domains in group.
+func targetDomains(ctx context.Context, svc *alidns.Service, group string) ([]string, error) {
+	domains, err := svc.GroupDomains(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(domains))
+	for _, d := range domains {
+		names = append(names, tea.StringValue(d.DomainName))
+	}
+	return names, nil
+}
+
+// listedDomains returns the comma-separated -domain list, or the domains of
+// group when -group was given instead. The group is resolved once, so
+// long-running commands do not follow later membership changes.
+func listedDomains(ctx context.Context, svc *alidns.Service, domain, group string) ([]string, error) {
+	if group == "" {
+		return splitList(domain), nil
+	}
+	return targetDomains(ctx, svc, group)
+}
@@ -0,0 +1,258 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+// gtmActions lists the actions of each gtm resource.
+var gtmActions = map[string][]string{
+	"instance": {"list", "show"},
+	"pool":     {"list", "show", "add", "del"},
+	"strategy": {"list", "add", "del"},
+	"monitor":  {"show", "enable", "disable", "update"},
+}
+
+func runGTM(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	usage := func() {
+		printGTMUsage(deps.Stderr, globalOutput)
+	}
+	resource, args, helpShown, err := parseAction("gtm", args, usage, "instance", "pool", "strategy", "monitor")
+	if err != nil || helpShown {
+		return err
+	}
+	action, args, helpShown, err := parseAction("gtm "+resource, args, usage, gtmActions[resource]...)
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newGTMFlagSet(resource, action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	required := []requiredArg{
+		{name: "-ak", value: f.ak},
+		{name: "-sk", value: f.sk},
+	}
+	switch resource + " " + action {
+	case "instance show", "pool list", "strategy list":
+		required = append(required, requiredArg{name: "-instance", value: f.instance})
+	case "pool show", "pool del":
+		required = append(required, requiredArg{name: "-pool", value: f.pool})
+	case "pool add":
+		required = append(required,
+			requiredArg{name: "-instance", value: f.instance},
+			requiredArg{name: "-name", value: f.name},
+			requiredArg{name: "-type", value: f.poolType},
+			requiredArg{name: "-addrs", value: f.addrs},
+		)
+	case "strategy add":
+		required = append(required,
+			requiredArg{name: "-instance", value: f.instance},
+			requiredArg{name: "-name", value: f.name},
+			requiredArg{name: "-type", value: f.poolType},
+			requiredArg{name: "-pools", value: f.pools},
+		)
+	case "strategy del":
+		required = append(required, requiredArg{name: "-strategy", value: f.strategy})
+	case "monitor show", "monitor enable", "monitor disable", "monitor update":
+		required = append(required, requiredArg{name: "-monitor", value: f.monitor})
+	}
+	if err := requireAll(required...); err != nil {
+		return err
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	if deps.NewGTMAPI == nil {
+		return fmt.Errorf("invalid deps: NewGTMAPI is nil")
+	}
+	api, err := deps.NewGTMAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 GTM Client 失败: %w", err)
+	}
+	svc := alidns.NewGTMService(api)
+
+	var resp any
+	switch resource + " " + action {
+	case "instance list":
+		resp, err = svc.Instances(ctx, f.keyword)
+	case "instance show":
+		resp, err = svc.Instance(ctx, f.instance)
+	case "pool list":
+		resp, err = svc.Pools(ctx, f.instance)
+	case "pool show":
+		resp, err = svc.Pool(ctx, f.pool)
+	case "pool add":
+		var in alidns.GTMPoolInput
+		if in, err = f.poolInput(); err != nil {
+			return err
+		}
+		resp, err = svc.AddPool(ctx, in)
+	case "pool del":
+		resp, err = svc.DeletePool(ctx, f.pool)
+	case "strategy list":
+		resp, err = svc.Strategies(ctx, f.instance, f.mode)
+	case "strategy add":
+		var in alidns.GTMStrategyInput
+		if in, err = f.strategyInput(); err != nil {
+			return err
+		}
+		resp, err = svc.AddStrategy(ctx, in)
+	case "strategy del":
+		resp, err = svc.DeleteStrategy(ctx, f.strategy)
+	case "monitor show":
+		resp, err = svc.Monitor(ctx, f.monitor)
+	case "monitor enable", "monitor disable":
+		resp, err = svc.SetMonitorStatus(ctx, f.monitor, action == "enable")
+	case "monitor update":
+		var in alidns.GTMMonitorInput
+		if in, err = f.monitorInput(); err != nil {
+			return err
+		}
+		resp, err = svc.UpdateMonitor(ctx, f.monitor, in)
+	}
+	if err != nil {
+		return err
+	}
+
+	return Print(deps.Stdout, resp, output)
+}
+
+func (f *gtmFlags) poolInput() (alidns.GTMPoolInput, error) {
+	in := alidns.GTMPoolInput{
+		InstanceID:  f.instance,
+		Name:        f.name,
+		Type:        f.poolType,
+		LbaStrategy: f.lba,
+	}
+	addrs, err := parseWeighted("-addrs", f.addrs)
+	if err != nil {
+		return in, err
+	}
+	for _, addr := range addrs {
+		in.Addrs = append(in.Addrs, alidns.GTMAddress{
+			Addr:   addr.name,
+			Weight: addr.weight,
+			Mode:   f.mode,
+			Lines:  splitList(f.lines),
+		})
+	}
+	if f.protocol != "" {
+		monitor, err := f.monitorInput()
+		if err != nil {
+			return in, err
+		}
+		in.Monitor = &monitor
+	}
+	return in, nil
+}
+
+func (f *gtmFlags) strategyInput() (alidns.GTMStrategyInput, error) {
+	in := alidns.GTMStrategyInput{
+		InstanceID:   f.instance,
+		Name:         f.name,
+		Mode:         f.mode,
+		Lines:        splitList(f.lines),
+		PoolType:     f.poolType,
+		LbaStrategy:  f.lba,
+		MinAvailable: int32(f.minAvailable),
+	}
+	pools, err := parseWeighted("-pools", f.pools)
+	if err != nil {
+		return in, err
+	}
+	for _, pool := range pools {
+		in.Pools = append(in.Pools, alidns.GTMPoolWeight{ID: pool.name, Weight: pool.weight})
+	}
+	if f.failoverPools != "" {
+		if pools, err = parseWeighted("-failover-pools", f.failoverPools); err != nil {
+			return in, err
+		}
+		for _, pool := range pools {
+			in.FailoverPools = append(in.FailoverPools, alidns.GTMPoolWeight{ID: pool.name, Weight: pool.weight})
+		}
+	}
+	return in, nil
+}
+
+func (f *gtmFlags) monitorInput() (alidns.GTMMonitorInput, error) {
+	in := alidns.GTMMonitorInput{
+		Protocol:        f.protocol,
+		Interval:        int32(f.interval),
+		Timeout:         int32(f.timeout),
+		EvaluationCount: int32(f.count),
+		ExtendInfo:      f.extend,
+	}
+	for _, node := range splitList(f.nodes) {
+		isp, city, ok := strings.Cut(node, ":")
+		if !ok || isp == "" || city == "" {
+			return in, fmt.Errorf("错误: -nodes 中的 %q 无效，应为 运营商代码:城市代码", node)
+		}
+		in.Nodes = append(in.Nodes, alidns.GTMMonitorNode{ISPCode: isp, CityCode: city})
+	}
+	return in, nil
+}
+
+type weighted struct {
+	name   string
+	weight int32
+}
+
+// parseWeighted parses "a,b=3": a comma separated list of names, each with
+// an optional weight.
+func parseWeighted(flagName, v string) ([]weighted, error) {
+	var items []weighted
+	for _, item := range splitList(v) {
+		name, w, found := strings.Cut(item, "=")
+		entry := weighted{name: strings.TrimSpace(name)}
+		if found {
+			n, err := strconv.ParseInt(strings.TrimSpace(w), 10, 32)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("错误: %s 中 %q 的权重无效", flagName, item)
+			}
+			entry.weight = int32(n)
+		}
+		if entry.name == "" {
+			return nil, fmt.Errorf("错误: %s 中存在空项", flagName)
+		}
+		items = append(items, entry)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("错误: %s 不能为空", flagName)
+	}
+	return items, nil
+}
+
+func splitList(v string) []string {
+	var items []string
+	for item := range strings.SplitSeq(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
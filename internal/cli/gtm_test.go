@@ -0,0 +1,135 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type fakeGTMAPI struct {
+	poolReq     *alidns20150109.AddDnsGtmAddressPoolRequest
+	strategyReq *alidns20150109.AddDnsGtmAccessStrategyRequest
+	monitorReq  *alidns20150109.SetDnsGtmMonitorStatusRequest
+
+	instanceResp []*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances
+
+	err error
+}
+
+func (f *fakeGTMAPI) AddDnsGtmAccessStrategy(_ context.Context, req *alidns20150109.AddDnsGtmAccessStrategyRequest) (*alidns20150109.AddDnsGtmAccessStrategyResponseBody, error) {
+	f.strategyReq = req
+	return &alidns20150109.AddDnsGtmAccessStrategyResponseBody{StrategyId: tea.String("s-1")}, f.err
+}
+
+func (f *fakeGTMAPI) AddDnsGtmAddressPool(_ context.Context, req *alidns20150109.AddDnsGtmAddressPoolRequest) (*alidns20150109.AddDnsGtmAddressPoolResponseBody, error) {
+	f.poolReq = req
+	return &alidns20150109.AddDnsGtmAddressPoolResponseBody{AddrPoolId: tea.String("p-1")}, f.err
+}
+
+func (f *fakeGTMAPI) DeleteDnsGtmAccessStrategy(_ context.Context, _ *alidns20150109.DeleteDnsGtmAccessStrategyRequest) (*alidns20150109.DeleteDnsGtmAccessStrategyResponseBody, error) {
+	return &alidns20150109.DeleteDnsGtmAccessStrategyResponseBody{}, f.err
+}
+
+func (f *fakeGTMAPI) DeleteDnsGtmAddressPool(_ context.Context, _ *alidns20150109.DeleteDnsGtmAddressPoolRequest) (*alidns20150109.DeleteDnsGtmAddressPoolResponseBody, error) {
+	return &alidns20150109.DeleteDnsGtmAddressPoolResponseBody{}, f.err
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmAccessStrategies(_ context.Context, _ *alidns20150109.DescribeDnsGtmAccessStrategiesRequest) ([]*alidns20150109.DescribeDnsGtmAccessStrategiesResponseBodyStrategiesStrategy, error) {
+	return nil, f.err
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstance(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceRequest) (*alidns20150109.DescribeDnsGtmInstanceResponseBody, error) {
+	return &alidns20150109.DescribeDnsGtmInstanceResponseBody{InstanceId: req.InstanceId}, f.err
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstanceAddressPool(_ context.Context, req *alidns20150109.DescribeDnsGtmInstanceAddressPoolRequest) (*alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody, error) {
+	return &alidns20150109.DescribeDnsGtmInstanceAddressPoolResponseBody{AddrPoolId: req.AddrPoolId}, f.err
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstanceAddressPools(_ context.Context, _ *alidns20150109.DescribeDnsGtmInstanceAddressPoolsRequest) ([]*alidns20150109.DescribeDnsGtmInstanceAddressPoolsResponseBodyAddrPoolsAddrPool, error) {
+	return nil, f.err
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmInstances(_ context.Context, _ *alidns20150109.DescribeDnsGtmInstancesRequest) ([]*alidns20150109.DescribeDnsGtmInstancesResponseBodyGtmInstances, error) {
+	return f.instanceResp, f.err
+}
+
+func (f *fakeGTMAPI) DescribeDnsGtmMonitorConfig(_ context.Context, _ *alidns20150109.DescribeDnsGtmMonitorConfigRequest) (*alidns20150109.DescribeDnsGtmMonitorConfigResponseBody, error) {
+	return &alidns20150109.DescribeDnsGtmMonitorConfigResponseBody{}, f.err
+}
+
+func (f *fakeGTMAPI) SetDnsGtmMonitorStatus(_ context.Context, req *alidns20150109.SetDnsGtmMonitorStatusRequest) (*alidns20150109.SetDnsGtmMonitorStatusResponseBody, error) {
+	f.monitorReq = req
+	return &alidns20150109.SetDnsGtmMonitorStatusResponseBody{}, f.err
+}
+
+func (f *fakeGTMAPI) UpdateDnsGtmMonitor(_ context.Context, _ *alidns20150109.UpdateDnsGtmMonitorRequest) (*alidns20150109.UpdateDnsGtmMonitorResponseBody, error) {
+	return &alidns20150109.UpdateDnsGtmMonitorResponseBody{}, f.err
+}
+
+func gtmDeps(api *fakeGTMAPI, stdout *bytes.Buffer) Deps {
+	return Deps{
+		Stdout:    stdout,
+		Stderr:    &bytes.Buffer{},
+		NewAPI:    func(_, _ string) (alidns.DNSAPI, error) { return &fakeDNSAPI{}, nil },
+		NewGTMAPI: func(_, _ string) (alidns.GTMAPI, error) { return api, nil },
+	}
+}
+
+func TestRunGTMPoolAddParsesWeightedAddresses(t *testing.T) {
+	api := &fakeGTMAPI{}
+	stdout := &bytes.Buffer{}
+	err := Run([]string{"--output", "json", "gtm", "pool", "add", "-ak", "ak", "-sk", "sk",
+		"-instance", "gtm-1", "-name", "hz", "-type", "IPV4", "-lba", "ratio", "-addrs", "1.1.1.1, 2.2.2.2=3",
+		"-protocol", "tcp", "-extend", `{"port":443}`, "-nodes", "465:503"}, gtmDeps(api, stdout))
+	if err != nil {
+		t.Fatalf("gtm pool add returned error: %v", err)
+	}
+	req := api.poolReq
+	if len(req.Addr) != 2 || tea.StringValue(req.Addr[1].Addr) != "2.2.2.2" || tea.Int32Value(req.Addr[1].LbaWeight) != 3 {
+		t.Fatalf("unexpected addresses: %+v", req.Addr)
+	}
+	if tea.StringValue(req.LbaStrategy) != "RATIO" || tea.StringValue(req.ProtocolType) != "TCP" || tea.StringValue(req.IspCityNode[0].IspCode) != "465" {
+		t.Fatalf("unexpected pool request: %+v", req)
+	}
+	if !strings.Contains(stdout.String(), `"AddrPoolId":"p-1"`) {
+		t.Fatalf("unexpected output: %s", stdout.String())
+	}
+
+	err = Run([]string{"gtm", "pool", "add", "-ak", "ak", "-sk", "sk",
+		"-instance", "gtm-1", "-name", "hz", "-type", "IPV4", "-addrs", "1.1.1.1=0"}, gtmDeps(api, stdout))
+	if err == nil || !strings.Contains(err.Error(), "-addrs") {
+		t.Fatalf("expected an invalid weight to be rejected, got: %v", err)
+	}
+}
+
+func TestRunGTMMonitorDisable(t *testing.T) {
+	api := &fakeGTMAPI{}
+	if err := Run([]string{"gtm", "monitor", "disable", "-ak", "ak", "-sk", "sk", "-monitor", "m-1"}, gtmDeps(api, &bytes.Buffer{})); err != nil {
+		t.Fatalf("gtm monitor disable returned error: %v", err)
+	}
+	if tea.StringValue(api.monitorReq.MonitorConfigId) != "m-1" || tea.StringValue(api.monitorReq.Status) != "CLOSE" {
+		t.Fatalf("unexpected monitor request: %+v", api.monitorReq)
+	}
+
+	err := Run([]string{"gtm", "monitor", "restart", "-ak", "ak", "-sk", "sk"}, gtmDeps(api, &bytes.Buffer{}))
+	if err == nil || !strings.Contains(err.Error(), "show|enable|disable|update") {
+		t.Fatalf("expected unknown action error, got: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/journal"
+)
+
+var errNoStateDir = fmt.Errorf("未配置本地状态目录，请设置 ALIDNS_STATE_DIR")
+
+func runHistory(args []string, globalOutput OutputFormat, deps Deps) error {
+	fs, f := newHistoryFlagSet(deps.Stderr, globalOutput)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	j := deps.journal()
+	if j == nil {
+		return errNoStateDir
+	}
+	entries, err := j.List()
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]*journal.Entry, 0, len(entries))
+	for _, e := range entries {
+		if f.domain == "" || strings.EqualFold(e.Domain, f.domain) {
+			filtered = append(filtered, e)
+		}
+	}
+	if f.limit > 0 && len(filtered) > f.limit {
+		filtered = filtered[len(filtered)-f.limit:]
+	}
+
+	return Print(deps.Stdout, filtered, output)
+}
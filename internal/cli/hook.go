@@ -0,0 +1,145 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+const (
+	hookCertbot = "certbot"
+	hookLego    = "lego"
+)
+
+// hookCall is an ACME client invoking the binary directly as its DNS hook.
+type hookCall struct {
+	client string
+	action string
+	in     alidns.ACMEInput
+}
+
+// detectHook recognises the calling conventions of ACME clients that run
+// the binary as an external DNS hook:
+//
+//   - certbot --manual-auth-hook/--manual-cleanup-hook: no arguments,
+//     CERTBOT_DOMAIN and CERTBOT_VALIDATION set. CERTBOT_AUTH_OUTPUT is
+//     only set for the cleanup hook.
+//   - lego exec provider: "present|cleanup FQDN VALUE", or in RAW mode
+//     "present|cleanup -- DOMAIN TOKEN KEY_AUTH".
+func detectHook(args []string, deps Deps) (hookCall, bool, error) {
+	if len(args) == 0 {
+		domain, ok := deps.lookupEnv("CERTBOT_DOMAIN")
+		if !ok {
+			return hookCall{}, false, nil
+		}
+		validation, _ := deps.lookupEnv("CERTBOT_VALIDATION")
+		action := "present"
+		if _, cleanup := deps.lookupEnv("CERTBOT_AUTH_OUTPUT"); cleanup {
+			action = "cleanup"
+		}
+		return hookCall{
+			client: hookCertbot,
+			action: action,
+			in:     alidns.ACMEInput{FQDN: "_acme-challenge." + domain, Value: validation},
+		}, true, nil
+	}
+
+	action := args[0]
+	if action != "present" && action != "cleanup" {
+		return hookCall{}, false, nil
+	}
+	rest := args[1:]
+	switch {
+	case len(rest) == 4 && rest[0] == "--":
+		return hookCall{
+			client: hookLego,
+			action: action,
+			in:     alidns.ACMEInput{FQDN: "_acme-challenge." + rest[1], Value: dns01Value(rest[3])},
+		}, true, nil
+	case len(rest) == 2:
+		return hookCall{
+			client: hookLego,
+			action: action,
+			in:     alidns.ACMEInput{FQDN: rest[0], Value: rest[1]},
+		}, true, nil
+	default:
+		return hookCall{}, false, fmt.Errorf("usage: alidns %s FQDN VALUE (lego exec) or alidns %s -- DOMAIN TOKEN KEY_AUTH (lego exec RAW)", action, action)
+	}
+}
+
+func runHook(ctx context.Context, hook hookCall, deps Deps) error {
+	ak, sk, err := credentialsFromEnv(deps)
+	if err != nil {
+		return err
+	}
+
+	// certbot continues with validation as soon as the hook returns, while
+	// lego runs its own propagation check.
+	var wait waitFlags
+	if hook.client == hookCertbot {
+		wait.wait = defaultACMEWait
+	}
+	if raw, ok := deps.lookupEnv("ALIDNS_HOOK_WAIT"); ok && raw != "" {
+		wait.wait, err = time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid ALIDNS_HOOK_WAIT %q: %w", raw, err)
+		}
+	}
+
+	api, err := deps.NewAPI(ak, sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	result, err := applyACME(ctx, deps, ak, svc, hook.action, hook.in, wait)
+	if err != nil {
+		return fmt.Errorf("%s %s hook: %w", hook.client, hook.action, err)
+	}
+	return Print(deps.Stdout, result, OutputJSON)
+}
+
+// credentialsFromEnv reads the access key from the standard Alibaba Cloud
+// variables, falling back to the names used by lego's alidns provider.
+func credentialsFromEnv(deps Deps) (string, string, error) {
+	ak := firstEnv(deps, "ALIBABA_CLOUD_ACCESS_KEY_ID", "ALICLOUD_ACCESS_KEY")
+	sk := firstEnv(deps, "ALIBABA_CLOUD_ACCESS_KEY_SECRET", "ALICLOUD_SECRET_KEY")
+	if err := requireAll(
+		requiredArg{name: "ALIBABA_CLOUD_ACCESS_KEY_ID", value: ak},
+		requiredArg{name: "ALIBABA_CLOUD_ACCESS_KEY_SECRET", value: sk},
+	); err != nil {
+		return "", "", err
+	}
+	return ak, sk, nil
+}
+
+func firstEnv(deps Deps, keys ...string) string {
+	for _, key := range keys {
+		if v, _ := deps.lookupEnv(key); strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// dns01Value derives the TXT value from a key authorization (RFC 8555 §8.4).
+func dns01Value(keyAuth string) string {
+	sum := sha256.Sum256([]byte(keyAuth))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func envMap(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestRunCertbotAuthHook(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	api := &fakeDNSAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1")}}
+	var gotAK string
+
+	err := Run(nil, Deps{
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(ak, _ string) (alidns.DNSAPI, error) {
+			gotAK = ak
+			return api, nil
+		},
+		LookupEnv: envMap(map[string]string{
+			"CERTBOT_DOMAIN":                  "www.example.com",
+			"CERTBOT_VALIDATION":              "token",
+			"ALIBABA_CLOUD_ACCESS_KEY_ID":     "ak",
+			"ALIBABA_CLOUD_ACCESS_KEY_SECRET": "sk",
+			"ALIDNS_HOOK_WAIT":                "0",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if gotAK != "ak" || !api.addCalled {
+		t.Fatalf("certbot auth hook did not add the record: ak=%q api=%+v", gotAK, api)
+	}
+	if got := stdout.String(); !strings.Contains(got, `"RR":"_acme-challenge.www"`) {
+		t.Fatalf("unexpected output: %s", got)
+	}
+}
+
+func TestRunCertbotCleanupHook(t *testing.T) {
+	api := &fakeDNSAPI{queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		{RecordId: tea.String("r-1"), RR: tea.String("_acme-challenge"), Type: tea.String("TXT"), Value: tea.String("token")},
+	}}
+
+	err := Run(nil, Deps{
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		LookupEnv: envMap(map[string]string{
+			"CERTBOT_DOMAIN":      "example.com",
+			"CERTBOT_VALIDATION":  "token",
+			"CERTBOT_AUTH_OUTPUT": "",
+			"ALICLOUD_ACCESS_KEY": "ak",
+			"ALICLOUD_SECRET_KEY": "sk",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if api.addCalled || !api.deleteCalled {
+		t.Fatalf("certbot cleanup hook should delete the record: %+v", api)
+	}
+}
+
+func TestDetectHookLego(t *testing.T) {
+	hook, ok, err := detectHook([]string{"present", "_acme-challenge.example.com.", "token"}, Deps{})
+	if err != nil || !ok {
+		t.Fatalf("lego call not detected: ok=%v err=%v", ok, err)
+	}
+	if hook.client != hookLego || hook.action != "present" || hook.in.FQDN != "_acme-challenge.example.com." || hook.in.Value != "token" {
+		t.Fatalf("unexpected hook: %+v", hook)
+	}
+
+	hook, ok, err = detectHook([]string{"cleanup", "--", "example.com", "tok", "tok.thumbprint"}, Deps{})
+	if err != nil || !ok {
+		t.Fatalf("lego RAW call not detected: ok=%v err=%v", ok, err)
+	}
+	if hook.action != "cleanup" || hook.in.FQDN != "_acme-challenge.example.com" || hook.in.Value != dns01Value("tok.thumbprint") {
+		t.Fatalf("unexpected RAW hook: %+v", hook)
+	}
+
+	if _, ok, _ := detectHook([]string{"query", "-domain", "example.com"}, Deps{}); ok {
+		t.Fatal("regular command must not be treated as a hook")
+	}
+}
+
+func TestRunHookRequiresEnvCredentials(t *testing.T) {
+	err := Run([]string{"present", "_acme-challenge.example.com", "token"}, Deps{
+		Stdout:    &bytes.Buffer{},
+		Stderr:    &bytes.Buffer{},
+		NewAPI:    func(_, _ string) (alidns.DNSAPI, error) { return &fakeDNSAPI{}, nil },
+		LookupEnv: envMap(nil),
+	})
+	if err == nil || !strings.Contains(err.Error(), "ALIBABA_CLOUD_ACCESS_KEY_ID") {
+		t.Fatalf("expected missing credential error, got: %v", err)
+	}
+}
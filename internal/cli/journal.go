@@ -0,0 +1,49 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Joey-Kot/alidns/internal/journal"
+)
+
+func (d Deps) journal() *journal.Journal {
+	if d.StateDir == "" {
+		return nil
+	}
+	return journal.Open(d.StateDir)
+}
+
+// journalChange appends e to the change journal, if one is configured. The
+// change has already been applied by then, so a failed write is only a
+// warning.
+func journalChange(deps Deps, ak string, e *journal.Entry) {
+	j := deps.journal()
+	if j == nil {
+		return
+	}
+	e.Profile = profileOf(ak)
+	e.Backend = deps.backend
+	if err := j.Append(e); err != nil {
+		_, _ = fmt.Fprintf(deps.Stderr, "警告: 写入变更日志失败: %v\n", err)
+	}
+}
+
+// profileOf identifies the credentials behind a change without storing the
+// full AccessKeyId.
+func profileOf(ak string) string {
+	if len(ak) <= 8 {
+		return "****"
+	}
+	return ak[:4] + "****" + ak[len(ak)-4:]
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// lineCacheTTL is how long a domain's supported lines are trusted before
+// DescribeSupportLines is called again.
+const lineCacheTTL = 24 * time.Hour
+
+type lineCache struct {
+	Fetched time.Time
+	Lines   []cachedLine
+}
+
+type cachedLine struct {
+	Code        string
+	Name        string
+	DisplayName string
+}
+
+func runLines(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	// "alidns lines -domain example.com" lists the supported lines.
+	if len(args) > 0 && strings.HasPrefix(args[0], "-") && !isHelpFlag(args[0]) {
+		args = append([]string{"list"}, args...)
+	}
+	action, args, helpShown, err := parseAction("lines", args, func() {
+		printLinesUsage(deps.Stderr, globalOutput)
+	}, "list", "custom", "add", "del")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newLinesFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	required := []requiredArg{
+		{name: "-ak", value: f.ak},
+		{name: "-sk", value: f.sk},
+		{name: "-domain", value: f.domain},
+	}
+	switch action {
+	case "add":
+		required = append(required,
+			requiredArg{name: "-name", value: f.name},
+			requiredArg{name: "-ips", value: f.ips},
+		)
+	case "del":
+		required = append(required, requiredArg{name: "-name", value: f.name})
+	}
+	if err := requireAll(required...); err != nil {
+		return err
+	}
+	var segments []alidns.IPSegment
+	if action == "add" {
+		if segments, err = alidns.ParseIPSegments(f.ips); err != nil {
+			return fmt.Errorf("错误: -ips 无效: %w", err)
+		}
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	var resp any
+	switch action {
+	case "list":
+		var lines []*alidns.Line
+		if lines, err = svc.SupportLines(ctx, f.domain); err == nil {
+			writeStateCache(lineCachePath(deps, f.ak, f.domain), newLineCache(lines))
+			resp = lines
+		}
+	case "custom":
+		resp, err = svc.CustomLines(ctx, f.domain)
+	case "add":
+		resp, err = svc.AddCustomLine(ctx, alidns.AddCustomLineInput{DomainName: f.domain, Name: f.name, Segments: segments})
+	case "del":
+		resp, err = svc.DeleteCustomLine(ctx, f.domain, f.name)
+	}
+	if err != nil {
+		return err
+	}
+	if action == "add" || action == "del" {
+		// The supported lines include custom lines, so the cached list is
+		// stale now.
+		writeStateCache(lineCachePath(deps, f.ak, f.domain), lineCache{})
+	}
+
+	return Print(deps.Stdout, resp, output)
+}
+
+// checkLine rejects a line that domainName cannot use before a record is
+// written with it. The supported lines are cached per domain in the state
+// directory and refetched when stale or when line is not among them.
+func checkLine(ctx context.Context, deps Deps, svc *alidns.Service, accessKeyID, domainName, line string) error {
+	if line == "" || line == "default" {
+		return nil
+	}
+	if deps.backend == backendPvtz {
+		return fmt.Errorf("错误: pvtz 后端不支持解析线路，-line 只能为 default")
+	}
+	path := lineCachePath(deps, accessKeyID, domainName)
+	var cache lineCache
+	if readStateCache(path, &cache) && time.Since(cache.Fetched) < lineCacheTTL && cache.has(line) {
+		return nil
+	}
+
+	lines, err := svc.SupportLines(ctx, domainName)
+	if err != nil {
+		return fmt.Errorf("读取 %s 支持的线路失败: %w", domainName, err)
+	}
+	cache = newLineCache(lines)
+	writeStateCache(path, cache)
+	if cache.has(line) {
+		return nil
+	}
+	for _, l := range cache.Lines {
+		if strings.EqualFold(l.Code, line) || l.Name == line || l.DisplayName == line {
+			return fmt.Errorf("错误: 线路 %q 无效，是否指 %q (%s)？", line, l.Code, l.Name)
+		}
+	}
+	return fmt.Errorf("错误: %s 不支持线路 %q，可用 alidns lines -domain %s 查看支持的线路", domainName, line, domainName)
+}
+
+func lineCachePath(deps Deps, accessKeyID, domainName string) string {
+	return stateCachePath(deps, "lines", accessKeyID, strings.ToLower(domainName))
+}
+
+func newLineCache(lines []*alidns.Line) lineCache {
+	cache := lineCache{Fetched: time.Now().UTC(), Lines: make([]cachedLine, 0, len(lines))}
+	for _, l := range lines {
+		cache.Lines = append(cache.Lines, cachedLine{
+			Code:        tea.StringValue(l.LineCode),
+			Name:        tea.StringValue(l.LineName),
+			DisplayName: tea.StringValue(l.LineDisplayName),
+		})
+	}
+	return cache
+}
+
+func (c lineCache) has(code string) bool {
+	for _, l := range c.Lines {
+		if l.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+func runLogs(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	fs, f := newLogsFlagSet(deps.Stderr, globalOutput)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+	); err != nil {
+		return err
+	}
+	if f.since < 0 || f.limit < 0 {
+		return fmt.Errorf("错误: --since 与 -limit 不能为负数")
+	}
+	switch f.level {
+	case "":
+	case alidns.LogLevelRecord:
+		if err := requireAll(requiredArg{name: "-domain", value: f.domain}); err != nil {
+			return err
+		}
+	case alidns.LogLevelDomain:
+		if f.domain != "" && f.keyword != "" {
+			return fmt.Errorf("错误: -level domain 查看单个域名时不支持 -keyword")
+		}
+	default:
+		return fmt.Errorf("错误: -level 仅支持 record 或 domain")
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	in := alidns.LogsInput{Level: f.level, DomainName: f.domain, KeyWord: f.keyword, Limit: f.limit}
+	if f.since > 0 {
+		in.Since = time.Now().Add(-f.since)
+	}
+	entries, err := svc.Logs(ctx, in)
+	if err != nil {
+		return err
+	}
+
+	return Print(deps.Stdout, entries, output)
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+// withMiddleware wraps the APIs created by deps in deps.Middleware.
+func withMiddleware(deps Deps) Deps {
+	mws := deps.Middleware
+	if len(mws) == 0 {
+		return deps
+	}
+	if newAPI := deps.NewAPI; newAPI != nil {
+		deps.NewAPI = func(accessKeyID, accessKeySecret string) (alidns.DNSAPI, error) {
+			api, err := newAPI(accessKeyID, accessKeySecret)
+			if err != nil {
+				return nil, err
+			}
+			return alidns.Wrap(api, mws...), nil
+		}
+	}
+	if newGTMAPI := deps.NewGTMAPI; newGTMAPI != nil {
+		deps.NewGTMAPI = func(accessKeyID, accessKeySecret string) (alidns.GTMAPI, error) {
+			api, err := newGTMAPI(accessKeyID, accessKeySecret)
+			if err != nil {
+				return nil, err
+			}
+			return alidns.WrapGTM(api, mws...), nil
+		}
+	}
+	if newPvtzAPI := deps.NewPvtzAPI; newPvtzAPI != nil {
+		deps.NewPvtzAPI = func(accessKeyID, accessKeySecret string) (alidns.PvtzAPI, error) {
+			api, err := newPvtzAPI(accessKeyID, accessKeySecret)
+			if err != nil {
+				return nil, err
+			}
+			return alidns.WrapPvtz(api, mws...), nil
+		}
+	}
+	return deps
+}
+
+// apiLogger is the logger behind --api-log. It includes reads, which the
+// Log middleware reports at debug level.
+func apiLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	"github.com/Joey-Kot/alidns/internal/notify"
+)
+
+// notifyConfigFile in the state directory lists the notification sinks.
+const notifyConfigFile = "notify.yaml"
+
+// notifier returns nil when no notification sinks are configured.
+func (d Deps) notifier() (*notify.Notifier, error) {
+	if d.StateDir == "" {
+		return nil, nil
+	}
+	cfg, err := notify.LoadConfig(filepath.Join(d.StateDir, notifyConfigFile))
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+	return notify.New(cfg, filepath.Join(d.StateDir, "outbox")), nil
+}
+
+// notifying reports whether notification sinks are configured, so that
+// commands only read extra state for the message when it will be sent.
+func (d Deps) notifying() bool {
+	n, err := d.notifier()
+	return err == nil && n != nil
+}
+
+// recordChange journals a successful mutation and reports it, successful or
+// not, to the notification sinks. record summarizes the target and after is
+// the requested state, if any.
+func recordChange(ctx context.Context, deps Deps, ak string, entry *journal.Entry, record string, after any, err error) {
+	if err == nil {
+		journalChange(deps, ak, entry)
+	}
+	reportChange(ctx, deps, ak, &notify.Event{
+		Operation: entry.Operation,
+		Domain:    entry.Domain,
+		Record:    record,
+		Before:    entry.Before,
+		After:     after,
+		RequestId: entry.RequestId,
+		JournalId: entry.ID,
+		UndoOf:    entry.UndoOf,
+	}, err)
+}
+
+// reportChange fills in the outcome, time and actor of ev and sends it. It
+// is used directly for mutations that are not journaled: ACME challenges,
+// weights and external-dns changes.
+func reportChange(ctx context.Context, deps Deps, ak string, ev *notify.Event, err error) {
+	ev.Time = time.Now().UTC()
+	ev.Success = err == nil
+	ev.Actor = actorOf(deps)
+	ev.Profile = profileOf(ak)
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	notifyChange(ctx, deps, ev)
+}
+
+// notifyChange is best effort like the journal: the change has been made,
+// and deliveries that fail are kept in the outbox.
+func notifyChange(ctx context.Context, deps Deps, ev *notify.Event) {
+	n, err := deps.notifier()
+	if err != nil {
+		_, _ = fmt.Fprintf(deps.Stderr, "警告: 读取通知配置失败: %v\n", err)
+		return
+	}
+	if n == nil {
+		return
+	}
+	if err := n.Notify(ctx, ev); err != nil {
+		_, _ = fmt.Fprintf(deps.Stderr, "警告: 通知发送失败，已保存到待发送队列: %v\n", err)
+	}
+}
+
+// actorOf names the local user running the command.
+func actorOf(deps Deps) string {
+	for _, key := range []string{"USER", "USERNAME"} {
+		if user, ok := deps.lookupEnv(key); ok && user != "" {
+			return user
+		}
+	}
+	return "unknown"
+}
+
+// recordSummary renders a record target as "www.example.com A 1.2.3.4".
+func recordSummary(domainName, name, rType, value string) string {
+	return strings.TrimSpace(strings.Join([]string{alidns.JoinFQDN(domainName, name), rType, value}, " "))
+}
+
+func runNotify(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	action, args, helpShown, err := parseAction("notify", args, func() {
+		printNotifyUsage(deps.Stderr, globalOutput)
+	}, "test", "flush")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newNotifyFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	if deps.StateDir == "" {
+		return errNoStateDir
+	}
+	n, err := deps.notifier()
+	if err != nil {
+		return err
+	}
+	if n == nil {
+		return fmt.Errorf("错误: 未配置通知，请创建 %s", filepath.Join(deps.StateDir, notifyConfigFile))
+	}
+
+	switch action {
+	case "test":
+		ev := &notify.Event{
+			Time:      time.Now().UTC(),
+			Operation: "test",
+			Success:   true,
+			Domain:    f.domain,
+			Record:    recordSummary(f.domain, "", "", ""),
+			Actor:     actorOf(deps),
+			Profile:   f.profile,
+		}
+		if err := n.Notify(ctx, ev); err != nil {
+			return fmt.Errorf("通知发送失败，已保存到待发送队列: %w", err)
+		}
+		return Print(deps.Stdout, ev, output)
+	default:
+		left, err := n.Flush(ctx)
+		if printErr := Print(deps.Stdout, struct{ Pending int }{left}, output); printErr != nil {
+			return printErr
+		}
+		return err
+	}
+}
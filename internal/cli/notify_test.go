@@ -0,0 +1,235 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/notify"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// failingAddAPI rejects the add itself but not the lookups before it.
+type failingAddAPI struct {
+	*fakeDNSAPI
+}
+
+func (failingAddAPI) AddDomainRecord(context.Context, *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	return nil, errors.New("quota exceeded")
+}
+
+// failingRemarkAPI updates records but rejects remark changes.
+type failingRemarkAPI struct {
+	*fakeDNSAPI
+}
+
+func (failingRemarkAPI) UpdateDomainRecordRemark(context.Context, *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	return nil, errors.New("remark too long")
+}
+
+func TestRunAddNotifiesWebhook(t *testing.T) {
+	var events []notify.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev notify.Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		events = append(events, ev)
+	}))
+	defer srv.Close()
+
+	stateDir := t.TempDir()
+	config := "retries: 0\nsinks:\n  - type: webhook\n    url: " + srv.URL + "\n"
+	if err := os.WriteFile(filepath.Join(stateDir, notifyConfigFile), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeDNSAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1"), RequestId: tea.String("req-add")}}
+	stderr := &bytes.Buffer{}
+	deps := Deps{
+		Stdout:    &bytes.Buffer{},
+		Stderr:    stderr,
+		NewAPI:    func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		LookupEnv: func(key string) (string, bool) { return "alice", key == "USER" },
+		StateDir:  stateDir,
+	}
+	args := []string{"add", "-ak", "LTAIexample1234", "-sk", "sk", "-domain", "example.com", "-name", "www", "-type", "A", "-value", "1.2.3.4"}
+
+	if err := Run(args, deps); err != nil {
+		t.Fatalf("add returned error: %v", err)
+	}
+	deps.NewAPI = func(_, _ string) (alidns.DNSAPI, error) { return failingAddAPI{api}, nil }
+	if err := Run(args, deps); err == nil {
+		t.Fatal("expected add to fail")
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d (stderr: %s)", len(events), stderr)
+	}
+	ok, failed := events[0], events[1]
+	if ok.Operation != "add" || !ok.Success || ok.Domain != "example.com" || ok.Record != "www.example.com A 1.2.3.4" ||
+		ok.RequestId != "req-add" || ok.Actor != "alice" || ok.Profile != "LTAI****1234" || ok.JournalId == "" {
+		t.Fatalf("unexpected event: %+v", ok)
+	}
+	if failed.Success || !strings.Contains(failed.Error, "quota exceeded") || failed.JournalId != "" {
+		t.Fatalf("unexpected failure event: %+v", failed)
+	}
+}
+
+func TestRunUpdateJournalsFieldsWhenRemarkFails(t *testing.T) {
+	var events []notify.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev notify.Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		events = append(events, ev)
+	}))
+	defer srv.Close()
+
+	stateDir := t.TempDir()
+	config := "retries: 0\nsinks:\n  - type: webhook\n    url: " + srv.URL + "\n"
+	if err := os.WriteFile(filepath.Join(stateDir, notifyConfigFile), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeDNSAPI{
+		infoResp: &alidns20150109.DescribeDomainRecordInfoResponseBody{
+			RecordId: tea.String("r-1"), DomainName: tea.String("example.com"), RR: tea.String("www"),
+			Type: tea.String("A"), Value: tea.String("1.2.3.4"), TTL: tea.Int64(600), Line: tea.String("default"),
+		},
+		updateResp: &alidns20150109.UpdateDomainRecordResponseBody{RecordId: tea.String("r-1"), RequestId: tea.String("req-update")},
+	}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return failingRemarkAPI{api}, nil },
+		StateDir: stateDir,
+	}
+
+	err := Run([]string{"update", "-ak", "ak", "-sk", "sk", "-id", "r-1", "-ttl", "60", "-remark", "owner: ops"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "记录已修改") {
+		t.Fatalf("expected the remark failure to mention the applied update, got: %v", err)
+	}
+
+	entries, err := deps.journal().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].RequestId != "req-update" {
+		t.Fatalf("the applied update should be journaled: %+v", entries)
+	}
+	if len(events) != 2 || !events[0].Success || events[0].JournalId != entries[0].ID || events[1].Success || !strings.Contains(events[1].Error, "remark too long") {
+		t.Fatalf("expected a success and a failure event, got %+v", events)
+	}
+}
+
+func TestRunAddJournalsRecordWhenRemarkFails(t *testing.T) {
+	var events []notify.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev notify.Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		events = append(events, ev)
+	}))
+	defer srv.Close()
+
+	stateDir := t.TempDir()
+	config := "retries: 0\nsinks:\n  - type: webhook\n    url: " + srv.URL + "\n"
+	if err := os.WriteFile(filepath.Join(stateDir, notifyConfigFile), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeDNSAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1"), RequestId: tea.String("req-add")}}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return failingRemarkAPI{api}, nil },
+		StateDir: stateDir,
+	}
+
+	err := Run([]string{"add", "-ak", "ak", "-sk", "sk", "-domain", "example.com", "-name", "www", "-type", "A", "-value", "1.2.3.4", "-remark", "owner: ops"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "remark too long") {
+		t.Fatalf("expected the remark failure, got: %v", err)
+	}
+
+	entries, err := deps.journal().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Operation != "add" || len(entries[0].RecordIds) != 1 || entries[0].RecordIds[0] != "r-1" {
+		t.Fatalf("the created record should be journaled: %+v", entries)
+	}
+	if len(events) != 2 || !events[0].Success || events[0].JournalId != entries[0].ID || events[1].Success || !strings.Contains(events[1].Error, "remark too long") {
+		t.Fatalf("expected a success and a failure event, got %+v", events)
+	}
+}
+
+func TestRunACMEAndWeightNotify(t *testing.T) {
+	var events []notify.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev notify.Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		events = append(events, ev)
+	}))
+	defer srv.Close()
+
+	stateDir := t.TempDir()
+	config := "retries: 0\nsinks:\n  - type: webhook\n    url: " + srv.URL + "\n"
+	if err := os.WriteFile(filepath.Join(stateDir, notifyConfigFile), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeDNSAPI{
+		addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1"), RequestId: tea.String("req-add")},
+		infoResp: &alidns20150109.DescribeDomainRecordInfoResponseBody{
+			RecordId: tea.String("r-2"), DomainName: tea.String("example.com"), RR: tea.String("www"),
+			Type: tea.String("A"), Value: tea.String("1.2.3.4"), TTL: tea.Int64(600),
+		},
+	}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		StateDir: stateDir,
+	}
+
+	if err := Run([]string{"acme", "present", "-ak", "ak", "-sk", "sk", "-fqdn", "_acme-challenge.example.com", "-value", "token", "-wait", "0"}, deps); err != nil {
+		t.Fatalf("acme present returned error: %v", err)
+	}
+	if err := Run([]string{"weight", "set", "-ak", "ak", "-sk", "sk", "-id", "r-2", "-weight", "30"}, deps); err != nil {
+		t.Fatalf("weight set returned error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected one event per command, got %+v", events)
+	}
+	if ev := events[0]; ev.Operation != "add" || !ev.Success || ev.Domain != "example.com" || ev.Record != "_acme-challenge.example.com TXT token" || ev.RequestId != "req-add" {
+		t.Fatalf("unexpected acme event: %+v", ev)
+	}
+	if ev := events[1]; ev.Operation != "update" || ev.Record != "www.example.com A 1.2.3.4" || len(ev.Before) != 1 {
+		t.Fatalf("unexpected weight event: %+v", ev)
+	}
+	if entries, err := deps.journal().List(); err != nil || len(entries) != 0 {
+		t.Fatalf("acme and weight changes should not be journaled: %+v %v", entries, err)
+	}
+}
@@ -15,8 +15,9 @@ import (
 	"context"
 	"fmt"
 
-	"alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/alidns"
 	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
 )
 
 func runQuery(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
@@ -32,10 +33,16 @@ func runQuery(ctx context.Context, args []string, globalOutput OutputFormat, dep
 	if err := requireAll(
 		requiredArg{name: "-ak", value: f.ak},
 		requiredArg{name: "-sk", value: f.sk},
-		requiredArg{name: "-domain", value: f.domain},
 	); err != nil {
 		return err
 	}
+	if f.group != "" {
+		if f.domain != "" || f.fqdn != "" {
+			return fmt.Errorf("错误: -group 不能与 -domain、-fqdn 同时使用")
+		}
+	} else if err := requireTarget(f.fqdn, f.domain, f.name, false); err != nil {
+		return err
+	}
 
 	output, err := ParseOutputFormat(f.output)
 	if err != nil {
@@ -47,13 +54,36 @@ func runQuery(ctx context.Context, args []string, globalOutput OutputFormat, dep
 		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
 	}
 	svc := alidns.NewService(api)
+	if f.fqdn != "" {
+		if f.domain, f.name, err = resolveFQDN(ctx, deps, svc, f.ak, f.fqdn); err != nil {
+			return err
+		}
+	}
 
-	records, err := svc.Query(ctx, alidns.QueryInput{DomainName: f.domain})
-	if err != nil {
-		return err
+	domains := []string{f.domain}
+	if f.group != "" {
+		if domains, err = targetDomains(ctx, svc, f.group); err != nil {
+			return err
+		}
+	}
+
+	records := []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{}
+	for _, domain := range domains {
+		found, err := svc.Query(ctx, alidns.QueryInput{DomainName: domain, Name: f.name, Remark: f.remark})
+		if err != nil {
+			return err
+		}
+		records = append(records, found...)
 	}
-	if records == nil {
-		records = []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{}
+	if f.unicode {
+		for _, record := range records {
+			if record.DomainName != nil {
+				record.DomainName = tea.String(alidns.ToUnicode(*record.DomainName))
+			}
+			if record.RR != nil {
+				record.RR = tea.String(alidns.ToUnicode(*record.RR))
+			}
+		}
 	}
 
 	return Print(deps.Stdout, records, output)
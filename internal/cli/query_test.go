@@ -16,8 +16,9 @@ import (
 	"strings"
 	"testing"
 
-	"alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/alidns"
 	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
 )
 
 func TestQueryEmptyOutputPretty(t *testing.T) {
@@ -59,3 +60,24 @@ func TestQueryJSONOutputWithRecord(t *testing.T) {
 		t.Fatalf("unexpected json output: %q", got)
 	}
 }
+
+func TestQueryGroupCoversEveryDomain(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	api := &fakeDNSAPI{
+		groupResp:  []*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup{{GroupId: tea.String("g-1"), GroupName: tea.String("retail")}},
+		domainResp: []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain{{DomainName: tea.String("a.com")}, {DomainName: tea.String("b.com")}},
+		queryResp:  []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{{RecordId: tea.String("r-1")}},
+	}
+
+	err := Run([]string{"query", "-ak", "ak", "-sk", "sk", "-group", "retail", "--output", "json"}, Deps{
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if got := strings.Count(stdout.String(), `"RecordId":"r-1"`); got != 2 {
+		t.Fatalf("expected records from both domains, got %d: %s", got, stdout.String())
+	}
+}
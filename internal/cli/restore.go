@@ -0,0 +1,134 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	"github.com/Joey-Kot/alidns/internal/notify"
+	"github.com/Joey-Kot/alidns/internal/snapshot"
+)
+
+type restoreResult struct {
+	Domain  string
+	Applied bool
+	Diff    *alidns.RecordDiff
+}
+
+func runRestore(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	fs, f := newRestoreFlagSet(deps.Stderr, globalOutput)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+		requiredArg{name: "-f", value: f.file},
+	); err != nil {
+		return err
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	snap, err := snapshot.Read(f.file)
+	if err != nil {
+		return err
+	}
+	domain := snap.Domain
+	if f.domain != "" {
+		domain = f.domain
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	live, err := svc.Records(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("读取 %s 当前记录失败: %w", domain, err)
+	}
+	result := restoreResult{Domain: domain, Diff: alidns.DiffRecords(live, snap.Records)}
+	if !f.dryRun && !result.Diff.Empty() {
+		err := svc.ApplyDiff(ctx, domain, result.Diff, func(step alidns.DiffStep) {
+			journalChange(deps, f.ak, restoreEntry(domain, step))
+		})
+		notifyChange(ctx, deps, restoreEvent(deps, f.ak, domain, result.Diff, err))
+		if err != nil {
+			return fmt.Errorf("恢复 %s 失败: %w", domain, err)
+		}
+		result.Applied = true
+	}
+
+	return Print(deps.Stdout, result, output)
+}
+
+// restoreEntry journals one mutation of a restore the way add, del and update
+// journal theirs, so that undo can revert a restore step by step.
+func restoreEntry(domain string, step alidns.DiffStep) *journal.Entry {
+	entry := &journal.Entry{Domain: domain, RequestId: step.RequestID}
+	switch {
+	case step.Before == nil:
+		in := addInputFromRecord(step.After)
+		in.DomainName = domain
+		entry.Operation = journal.OpAdd
+		entry.Request = in
+		entry.RecordIds = []string{step.RecordID}
+	case step.After == nil:
+		entry.Operation = journal.OpDel
+		entry.Request = map[string][]string{"RecordIds": {step.RecordID}}
+		entry.Before = []*journal.Record{step.Before}
+	default:
+		entry.Operation = journal.OpUpdate
+		entry.Request = step.After
+		entry.Before = []*journal.Record{step.Before}
+	}
+	return entry
+}
+
+// restoreEvent reports a restore as one change: Before holds the records it
+// removed or changed and After the whole diff.
+func restoreEvent(deps Deps, ak, domain string, diff *alidns.RecordDiff, err error) *notify.Event {
+	before := slices.Clone(diff.Removed)
+	for _, change := range diff.Changed {
+		before = append(before, change.Before)
+	}
+	ev := &notify.Event{
+		Time:      time.Now().UTC(),
+		Operation: "restore",
+		Success:   err == nil,
+		Domain:    domain,
+		Record:    fmt.Sprintf("%s: %d 条新增, %d 条删除, %d 条修改", domain, len(diff.Added), len(diff.Removed), len(diff.Changed)),
+		Before:    before,
+		After:     diff,
+		Actor:     actorOf(deps),
+		Profile:   profileOf(ak),
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	return ev
+}
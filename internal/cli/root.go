@@ -13,19 +13,67 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
 
-	"alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/alidns"
 )
 
 type APIFactory func(accessKeyID, accessKeySecret string) (alidns.DNSAPI, error)
 
+type GTMAPIFactory func(accessKeyID, accessKeySecret string) (alidns.GTMAPI, error)
+
+type PvtzAPIFactory func(accessKeyID, accessKeySecret string) (alidns.PvtzAPI, error)
+
 type Deps struct {
 	Stdout io.Writer
 	Stderr io.Writer
 	NewAPI APIFactory
+	// NewGTMAPI is only needed by the gtm commands.
+	NewGTMAPI GTMAPIFactory
+	// NewPvtzAPI is needed by the zone commands and by --backend pvtz.
+	NewPvtzAPI PvtzAPIFactory
+	// LookupEnv reads the environment. A nil LookupEnv behaves as an empty
+	// environment.
+	LookupEnv func(key string) (string, bool)
+	// StateDir holds local state such as the change journal. Empty disables
+	// it.
+	StateDir string
+	// Middleware wraps every API created by the factories above, the first
+	// middleware outermost.
+	Middleware []alidns.Middleware
+
+	// backend is set by --backend when it is not alidns.
+	backend string
+}
+
+// ExitError is an error that should end the process with Code instead of the
+// usual status 1.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit status for an error returned by Run.
+func ExitCode(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
 }
 
 func NewDefaultDeps(stdout, stderr io.Writer) Deps {
@@ -39,7 +87,43 @@ func NewDefaultDeps(stdout, stderr io.Writer) Deps {
 			}
 			return alidns.NewSDKClient(client), nil
 		},
+		NewGTMAPI: func(accessKeyID, accessKeySecret string) (alidns.GTMAPI, error) {
+			client, err := alidns.CreateClient(accessKeyID, accessKeySecret)
+			if err != nil {
+				return nil, err
+			}
+			return alidns.NewGTMSDKClient(client), nil
+		},
+		NewPvtzAPI: func(accessKeyID, accessKeySecret string) (alidns.PvtzAPI, error) {
+			client, err := alidns.CreatePvtzClient(accessKeyID, accessKeySecret)
+			if err != nil {
+				return nil, err
+			}
+			return alidns.NewPvtzSDKClient(client), nil
+		},
+		LookupEnv: os.LookupEnv,
+		StateDir:  defaultStateDir(),
+	}
+}
+
+// defaultStateDir is $ALIDNS_STATE_DIR, or alidns under the user config
+// directory.
+func defaultStateDir() string {
+	if dir := os.Getenv("ALIDNS_STATE_DIR"); dir != "" {
+		return dir
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "alidns")
+}
+
+func (d Deps) lookupEnv(key string) (string, bool) {
+	if d.LookupEnv == nil {
+		return "", false
 	}
+	return d.LookupEnv(key)
 }
 
 func Run(args []string, deps Deps) error {
@@ -50,9 +134,19 @@ func Run(args []string, deps Deps) error {
 		return fmt.Errorf("invalid deps: NewAPI is nil")
 	}
 
+	hook, isHook, err := detectHook(args, deps)
+	if err != nil {
+		return err
+	}
+	if isHook {
+		return runHook(context.Background(), hook, withMiddleware(deps))
+	}
+
 	rootFlags := flag.NewFlagSet("alidns", flag.ContinueOnError)
 	rootFlags.SetOutput(deps.Stderr)
 	outputRaw := rootFlags.String("output", string(OutputPretty), "output format: json|pretty")
+	backend := rootFlags.String("backend", backendAlidns, "record backend: alidns|pvtz")
+	apiLog := rootFlags.Bool("api-log", false, "log every API call to stderr")
 	help := rootFlags.Bool("help", false, "show help")
 	rootFlags.BoolVar(help, "h", false, "show help")
 	rootFlags.Usage = func() {
@@ -71,6 +165,13 @@ func Run(args []string, deps Deps) error {
 	if err != nil {
 		return err
 	}
+	if *apiLog {
+		deps.Middleware = append(slices.Clone(deps.Middleware), alidns.Log(apiLogger(deps.Stderr)))
+	}
+	deps = withMiddleware(deps)
+	if deps, err = withBackend(deps, *backend); err != nil {
+		return err
+	}
 
 	rest := rootFlags.Args()
 	if len(rest) == 0 {
@@ -89,6 +190,44 @@ func Run(args []string, deps Deps) error {
 		return runQuery(ctx, cmdArgs, globalOutput, deps)
 	case "update":
 		return runUpdate(ctx, cmdArgs, globalOutput, deps)
+	case "acme":
+		return runACME(ctx, cmdArgs, globalOutput, deps)
+	case "weight":
+		return runWeight(ctx, cmdArgs, globalOutput, deps)
+	case "history":
+		return runHistory(cmdArgs, globalOutput, deps)
+	case "undo":
+		return runUndo(ctx, cmdArgs, globalOutput, deps)
+	case "group":
+		return runGroup(ctx, cmdArgs, globalOutput, deps)
+	case "domain":
+		return runDomain(ctx, cmdArgs, globalOutput, deps)
+	case "logs":
+		return runLogs(ctx, cmdArgs, globalOutput, deps)
+	case "stats":
+		return runStats(ctx, cmdArgs, globalOutput, deps)
+	case "lines":
+		return runLines(ctx, cmdArgs, globalOutput, deps)
+	case "dnssec":
+		return runDNSSEC(ctx, cmdArgs, globalOutput, deps)
+	case "gtm":
+		return runGTM(ctx, cmdArgs, globalOutput, deps)
+	case "zone":
+		return runZone(ctx, cmdArgs, globalOutput, deps)
+	case "exporter":
+		return runExporter(ctx, cmdArgs, deps)
+	case "watch":
+		return runWatch(ctx, cmdArgs, deps)
+	case "external-dns-webhook":
+		return runExternalDNS(ctx, cmdArgs, deps)
+	case "notify":
+		return runNotify(ctx, cmdArgs, globalOutput, deps)
+	case "backup":
+		return runBackup(ctx, cmdArgs, globalOutput, deps)
+	case "restore":
+		return runRestore(ctx, cmdArgs, globalOutput, deps)
+	case "diff":
+		return runDiff(ctx, cmdArgs, globalOutput, deps)
 	case "help":
 		if len(cmdArgs) == 0 {
 			rootFlags.Usage()
@@ -15,48 +15,179 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
-	"alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	"github.com/Joey-Kot/alidns/internal/snapshot"
 	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
 	"github.com/alibabacloud-go/tea/tea"
 )
 
 type fakeDNSAPI struct {
 	addCalled    bool
+	deleteCalled bool
 	delCalled    bool
 	queryCalled  bool
 	updateCalled bool
+	remarkCalled bool
+	linesCalled  int
+	dnssecStatus string
+
+	addReq    *alidns20150109.AddDomainRecordRequest
+	updateReq *alidns20150109.UpdateDomainRecordRequest
+	statusReq []*alidns20150109.SetDomainRecordStatusRequest
+	slbReq    []*alidns20150109.SetDNSSLBStatusRequest
+	weightReq []*alidns20150109.UpdateDNSSLBWeightRequest
 
 	addResp    *alidns20150109.AddDomainRecordResponseBody
 	delResp    *alidns20150109.DeleteSubDomainRecordsResponseBody
 	queryResp  []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+	infoResp   *alidns20150109.DescribeDomainRecordInfoResponseBody
+	groupResp  []*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup
+	statResp   []*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic
+	domainResp []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain
+	lineResp   []*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine
+	dnssecResp *alidns20150109.DescribeDomainDnssecInfoResponseBody
 	updateResp *alidns20150109.UpdateDomainRecordResponseBody
 
 	err error
 }
 
-func (f *fakeDNSAPI) AddDomainRecord(_ context.Context, _ *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+func (f *fakeDNSAPI) AddDomainRecord(_ context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
 	f.addCalled = true
+	f.addReq = req
 	return f.addResp, f.err
 }
 
+func (f *fakeDNSAPI) DeleteDomainRecord(_ context.Context, _ *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	f.deleteCalled = true
+	return &alidns20150109.DeleteDomainRecordResponseBody{}, f.err
+}
+
 func (f *fakeDNSAPI) DeleteSubDomainRecords(_ context.Context, _ *alidns20150109.DeleteSubDomainRecordsRequest) (*alidns20150109.DeleteSubDomainRecordsResponseBody, error) {
 	f.delCalled = true
 	return f.delResp, f.err
 }
 
+func (f *fakeDNSAPI) DescribeDNSSLBSubDomains(_ context.Context, _ *alidns20150109.DescribeDNSSLBSubDomainsRequest) ([]*alidns20150109.DescribeDNSSLBSubDomainsResponseBodySlbSubDomainsSlbSubDomain, error) {
+	return nil, f.err
+}
+
+func (f *fakeDNSAPI) DescribeDomainRecordInfo(_ context.Context, _ *alidns20150109.DescribeDomainRecordInfoRequest) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+	return f.infoResp, f.err
+}
+
+func (f *fakeDNSAPI) DescribeDomains(_ context.Context, _ *alidns20150109.DescribeDomainsRequest) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	return f.domainResp, f.err
+}
+
 func (f *fakeDNSAPI) DescribeDomainRecords(_ context.Context, _ *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
 	f.queryCalled = true
 	return f.queryResp, f.err
 }
 
-func (f *fakeDNSAPI) UpdateDomainRecord(_ context.Context, _ *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+func (f *fakeDNSAPI) SetDNSSLBStatus(_ context.Context, req *alidns20150109.SetDNSSLBStatusRequest) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+	f.slbReq = append(f.slbReq, req)
+	return &alidns20150109.SetDNSSLBStatusResponseBody{}, f.err
+}
+
+func (f *fakeDNSAPI) SetDomainRecordStatus(_ context.Context, req *alidns20150109.SetDomainRecordStatusRequest) (*alidns20150109.SetDomainRecordStatusResponseBody, error) {
+	f.statusReq = append(f.statusReq, req)
+	return &alidns20150109.SetDomainRecordStatusResponseBody{}, f.err
+}
+
+func (f *fakeDNSAPI) UpdateDNSSLBWeight(_ context.Context, req *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	f.weightReq = append(f.weightReq, req)
+	return &alidns20150109.UpdateDNSSLBWeightResponseBody{}, f.err
+}
+
+func (f *fakeDNSAPI) UpdateDomainRecord(_ context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
 	f.updateCalled = true
+	f.updateReq = req
 	return f.updateResp, f.err
 }
 
+func (f *fakeDNSAPI) UpdateDomainRecordRemark(_ context.Context, _ *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	f.remarkCalled = true
+	return &alidns20150109.UpdateDomainRecordRemarkResponseBody{}, f.err
+}
+
+func (f *fakeDNSAPI) AddCustomLine(_ context.Context, _ *alidns20150109.AddCustomLineRequest) (*alidns20150109.AddCustomLineResponseBody, error) {
+	return &alidns20150109.AddCustomLineResponseBody{LineId: tea.Int64(1)}, f.err
+}
+
+func (f *fakeDNSAPI) DeleteCustomLines(_ context.Context, _ *alidns20150109.DeleteCustomLinesRequest) (*alidns20150109.DeleteCustomLinesResponseBody, error) {
+	return &alidns20150109.DeleteCustomLinesResponseBody{}, f.err
+}
+
+func (f *fakeDNSAPI) DescribeCustomLines(_ context.Context, _ *alidns20150109.DescribeCustomLinesRequest) ([]*alidns20150109.DescribeCustomLinesResponseBodyCustomLines, error) {
+	return nil, f.err
+}
+
+func (f *fakeDNSAPI) DescribeSupportLines(_ context.Context, _ *alidns20150109.DescribeSupportLinesRequest) ([]*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine, error) {
+	f.linesCalled++
+	return f.lineResp, f.err
+}
+
+func (f *fakeDNSAPI) DescribeDomainDnssecInfo(_ context.Context, _ *alidns20150109.DescribeDomainDnssecInfoRequest) (*alidns20150109.DescribeDomainDnssecInfoResponseBody, error) {
+	return f.dnssecResp, f.err
+}
+
+func (f *fakeDNSAPI) SetDomainDnssecStatus(_ context.Context, req *alidns20150109.SetDomainDnssecStatusRequest) (*alidns20150109.SetDomainDnssecStatusResponseBody, error) {
+	f.dnssecStatus = tea.StringValue(req.Status)
+	return &alidns20150109.SetDomainDnssecStatusResponseBody{}, f.err
+}
+
+func (f *fakeDNSAPI) AddDomainGroup(_ context.Context, req *alidns20150109.AddDomainGroupRequest) (*alidns20150109.AddDomainGroupResponseBody, error) {
+	return &alidns20150109.AddDomainGroupResponseBody{GroupName: req.GroupName}, f.err
+}
+
+func (f *fakeDNSAPI) ChangeDomainGroup(_ context.Context, req *alidns20150109.ChangeDomainGroupRequest) (*alidns20150109.ChangeDomainGroupResponseBody, error) {
+	return &alidns20150109.ChangeDomainGroupResponseBody{GroupId: req.GroupId}, f.err
+}
+
+func (f *fakeDNSAPI) DeleteDomainGroup(_ context.Context, _ *alidns20150109.DeleteDomainGroupRequest) (*alidns20150109.DeleteDomainGroupResponseBody, error) {
+	return &alidns20150109.DeleteDomainGroupResponseBody{}, f.err
+}
+
+func (f *fakeDNSAPI) DescribeDomainGroups(_ context.Context, _ *alidns20150109.DescribeDomainGroupsRequest) ([]*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error) {
+	return f.groupResp, f.err
+}
+
+func (f *fakeDNSAPI) UpdateDomainGroup(_ context.Context, req *alidns20150109.UpdateDomainGroupRequest) (*alidns20150109.UpdateDomainGroupResponseBody, error) {
+	return &alidns20150109.UpdateDomainGroupResponseBody{GroupId: req.GroupId, GroupName: req.GroupName}, f.err
+}
+
+func (f *fakeDNSAPI) DescribeDomainLogs(_ context.Context, _ *alidns20150109.DescribeDomainLogsRequest) ([]*alidns20150109.DescribeDomainLogsResponseBodyDomainLogsDomainLog, error) {
+	return nil, f.err
+}
+
+func (f *fakeDNSAPI) DescribeRecordLogs(_ context.Context, _ *alidns20150109.DescribeRecordLogsRequest) ([]*alidns20150109.DescribeRecordLogsResponseBodyRecordLogsRecordLog, error) {
+	return nil, f.err
+}
+
+func (f *fakeDNSAPI) DescribeDomainStatistics(_ context.Context, _ *alidns20150109.DescribeDomainStatisticsRequest) ([]*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic, error) {
+	return f.statResp, f.err
+}
+
+func (f *fakeDNSAPI) DescribeDomainStatisticsSummary(_ context.Context, _ *alidns20150109.DescribeDomainStatisticsSummaryRequest) ([]*alidns20150109.DescribeDomainStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	return nil, f.err
+}
+
+func (f *fakeDNSAPI) DescribeRecordStatistics(_ context.Context, _ *alidns20150109.DescribeRecordStatisticsRequest) ([]*alidns20150109.DescribeRecordStatisticsResponseBodyStatisticsStatistic, error) {
+	return nil, f.err
+}
+
+func (f *fakeDNSAPI) DescribeRecordStatisticsSummary(_ context.Context, _ *alidns20150109.DescribeRecordStatisticsSummaryRequest) ([]*alidns20150109.DescribeRecordStatisticsSummaryResponseBodyStatisticsStatistic, error) {
+	return nil, f.err
+}
+
 func TestRunDispatchAdd(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -198,3 +329,524 @@ func TestRunSubcommandHelpFlagReturnsNil(t *testing.T) {
 		t.Fatalf("subcommand help should include add usage, got: %s", stderr.String())
 	}
 }
+
+func TestRunACMEPresentWithoutWait(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	api := &fakeDNSAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1")}}
+
+	err := Run([]string{"acme", "present", "-ak", "ak", "-sk", "sk", "-fqdn", "_acme-challenge.www.example.com", "-value", "token", "-wait", "0", "--output", "json"}, Deps{
+		Stdout: stdout,
+		Stderr: stderr,
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if !api.addCalled || api.delCalled {
+		t.Fatalf("unexpected api calls: %+v", api)
+	}
+	if got := stdout.String(); !strings.Contains(got, `"RR":"_acme-challenge.www"`) || !strings.Contains(got, `"DomainName":"example.com"`) {
+		t.Fatalf("unexpected output: %s", got)
+	}
+}
+
+func TestRunWeightSetRejectsOutOfRange(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		want string
+	}{
+		"zero":    {args: []string{"-weight", "0"}, want: "-weight 必须在 1-100 之间"},
+		"missing": {want: "缺少必需的参数: -weight"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			called := false
+			err := Run(append([]string{"weight", "set", "-ak", "ak", "-sk", "sk", "-id", "r-1"}, tt.args...), Deps{
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+				NewAPI: func(_, _ string) (alidns.DNSAPI, error) {
+					called = true
+					return &fakeDNSAPI{}, nil
+				},
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q error, got: %v", tt.want, err)
+			}
+			if called {
+				t.Fatal("NewAPI should not be called for invalid input")
+			}
+		})
+	}
+}
+
+func TestRunDelJournalsAndUndoRestores(t *testing.T) {
+	stateDir := t.TempDir()
+	api := &fakeDNSAPI{
+		queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+			{RecordId: tea.String("r-1"), DomainName: tea.String("example.com"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.2.3.4"), TTL: tea.Int64(60), Line: tea.String("telecom"), Status: tea.String("DISABLE"), Weight: tea.Int32(30)},
+		},
+		delResp: &alidns20150109.DeleteSubDomainRecordsResponseBody{RequestId: tea.String("req-del")},
+		addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-2"), RequestId: tea.String("req-add")},
+	}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		StateDir: stateDir,
+	}
+
+	if err := Run([]string{"del", "-ak", "LTAIexample1234", "-sk", "sk", "-domain", "example.com", "-name", "www", "-type", "A"}, deps); err != nil {
+		t.Fatalf("del returned error: %v", err)
+	}
+
+	history := &bytes.Buffer{}
+	deps.Stdout = history
+	if err := Run([]string{"history", "--output", "json"}, deps); err != nil {
+		t.Fatalf("history returned error: %v", err)
+	}
+	if got := history.String(); !strings.Contains(got, `"Operation":"del"`) || !strings.Contains(got, `"RequestId":"req-del"`) || !strings.Contains(got, `"Value":"1.2.3.4"`) || !strings.Contains(got, `"Profile":"LTAI****1234"`) {
+		t.Fatalf("unexpected history: %s", got)
+	}
+
+	undone := &bytes.Buffer{}
+	deps.Stdout = undone
+	if err := Run([]string{"undo", "-ak", "ak", "-sk", "sk", "--output", "json"}, deps); err != nil {
+		t.Fatalf("undo returned error: %v", err)
+	}
+	if !api.addCalled {
+		t.Fatal("undo of del should re-add the deleted record")
+	}
+	if len(api.statusReq) != 1 || tea.StringValue(api.statusReq[0].RecordId) != "r-2" || tea.StringValue(api.statusReq[0].Status) != "Disable" {
+		t.Fatalf("undo should keep the re-added record disabled: %+v", api.statusReq)
+	}
+	if len(api.slbReq) != 1 || !tea.BoolValue(api.slbReq[0].Open) || tea.StringValue(api.slbReq[0].Line) != "telecom" {
+		t.Fatalf("undo should reopen weighted round-robin: %+v", api.slbReq)
+	}
+	if len(api.weightReq) != 1 || tea.StringValue(api.weightReq[0].RecordId) != "r-2" || tea.Int32Value(api.weightReq[0].Weight) != 30 {
+		t.Fatalf("undo should restore the weight: %+v", api.weightReq)
+	}
+	if got := undone.String(); !strings.Contains(got, `"Operation":"add"`) || !strings.Contains(got, `"UndoOf"`) || !strings.Contains(got, `"Line":"telecom"`) {
+		t.Fatalf("unexpected undo output: %s", got)
+	}
+
+	if err := Run([]string{"undo", "-ak", "ak", "-sk", "sk"}, deps); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Fatalf("expected nothing to undo, got: %v", err)
+	}
+}
+
+func TestRunDiffExitsWithDriftStatus(t *testing.T) {
+	desired := filepath.Join(t.TempDir(), "desired.yaml")
+	if err := os.WriteFile(desired, []byte("domain: example.com\nrecords:\n  - name: www\n    type: A\n    value: 1.2.3.4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeDNSAPI{
+		queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+			{RecordId: tea.String("r-1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.2.3.4"), TTL: tea.Int64(600), Line: tea.String("default"), Status: tea.String("ENABLE")},
+		},
+	}
+	deps := Deps{
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	}
+
+	if err := Run([]string{"diff", "-ak", "ak", "-sk", "sk", "-f", desired}, deps); err != nil {
+		t.Fatalf("expected no drift, got: %v", err)
+	}
+
+	api.queryResp[0].TTL = tea.Int64(60)
+	stdout := &bytes.Buffer{}
+	deps.Stdout = stdout
+	err := Run([]string{"diff", "-ak", "ak", "-sk", "sk", "-f", desired, "--output", "text", "-color", "never"}, deps)
+	if ExitCode(err) != 2 {
+		t.Fatalf("expected exit code 2, got %d (%v)", ExitCode(err), err)
+	}
+	if !strings.Contains(stdout.String(), "~ www A default 1.2.3.4 (TTL 60, ENABLE): TTL 60 -> 600") {
+		t.Fatalf("unexpected text diff:\n%s", stdout.String())
+	}
+}
+
+func TestRunUpdateSkipsUnchangedRecord(t *testing.T) {
+	api := &fakeDNSAPI{
+		infoResp: &alidns20150109.DescribeDomainRecordInfoResponseBody{
+			RecordId: tea.String("r-1"), DomainName: tea.String("example.com"), RR: tea.String("www"),
+			Type: tea.String("A"), Value: tea.String("1.2.3.4"), TTL: tea.Int64(60), Line: tea.String("telecom"),
+		},
+	}
+	stdout := &bytes.Buffer{}
+	err := Run([]string{"--output", "json", "update", "-ak", "ak", "-sk", "sk", "-id", "r-1", "-ttl", "60"}, Deps{
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	})
+	if err != nil {
+		t.Fatalf("update returned error: %v", err)
+	}
+	if api.updateCalled {
+		t.Fatal("UpdateDomainRecord should not be called when nothing changes")
+	}
+	if !strings.Contains(stdout.String(), `"Unchanged":true`) {
+		t.Fatalf("unexpected output: %s", stdout.String())
+	}
+}
+
+func TestRunUpdateRejectsEmptyGivenFields(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "empty value", args: []string{"-value", ""}, want: "-value 不能为空"},
+		{name: "zero ttl", args: []string{"-ttl", "0"}, want: "-ttl 不能为 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			err := Run(append([]string{"update", "-ak", "ak", "-sk", "sk", "-id", "r-1"}, tt.args...), Deps{
+				Stdout: &bytes.Buffer{},
+				Stderr: &bytes.Buffer{},
+				NewAPI: func(_, _ string) (alidns.DNSAPI, error) {
+					called = true
+					return &fakeDNSAPI{}, nil
+				},
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q error, got: %v", tt.want, err)
+			}
+			if called {
+				t.Fatal("NewAPI should not be called for invalid input")
+			}
+		})
+	}
+}
+
+func TestRunUpdateAcceptsZeroValue(t *testing.T) {
+	api := &fakeDNSAPI{
+		infoResp: &alidns20150109.DescribeDomainRecordInfoResponseBody{
+			RecordId: tea.String("r-1"), DomainName: tea.String("example.com"), RR: tea.String("flag"), Type: tea.String("TXT"),
+			Value: tea.String("1"), TTL: tea.Int64(600), Line: tea.String("default"),
+		},
+		updateResp: &alidns20150109.UpdateDomainRecordResponseBody{RecordId: tea.String("r-1")},
+	}
+	err := Run([]string{"update", "-ak", "ak", "-sk", "sk", "-id", "r-1", "-value", "0"}, Deps{
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	})
+	if err != nil {
+		t.Fatalf("update returned error: %v", err)
+	}
+	if api.updateReq == nil || tea.StringValue(api.updateReq.Value) != "0" || tea.StringValue(api.updateReq.RR) != "flag" {
+		t.Fatalf("unexpected update request: %+v", api.updateReq)
+	}
+}
+
+func TestRunAddResolvesFQDNWithCachedDomains(t *testing.T) {
+	stateDir := t.TempDir()
+	api := &fakeDNSAPI{
+		domainResp: []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain{
+			{DomainName: tea.String("example.com")},
+			{DomainName: tea.String("dev.example.com")},
+		},
+		addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1")},
+	}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		StateDir: stateDir,
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	}
+
+	if err := Run([]string{"add", "-ak", "ak", "-sk", "sk", "-fqdn", "api.dev.example.com", "-type", "A", "-value", "1.2.3.4"}, deps); err != nil {
+		t.Fatalf("add returned error: %v", err)
+	}
+	if tea.StringValue(api.addReq.DomainName) != "dev.example.com" || tea.StringValue(api.addReq.RR) != "api" {
+		t.Fatalf("unexpected add request: %+v", api.addReq)
+	}
+
+	api.domainResp = nil
+	if err := Run([]string{"add", "-ak", "ak", "-sk", "sk", "-fqdn", "example.com", "-type", "TXT", "-value", "v"}, deps); err != nil {
+		t.Fatalf("add with cached domains returned error: %v", err)
+	}
+	if tea.StringValue(api.addReq.DomainName) != "example.com" || tea.StringValue(api.addReq.RR) != "@" {
+		t.Fatalf("unexpected add request: %+v", api.addReq)
+	}
+
+	err := Run([]string{"add", "-ak", "ak", "-sk", "sk", "-fqdn", "www.example.com", "-domain", "example.com", "-type", "A", "-value", "1.2.3.4"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "-fqdn") {
+		t.Fatalf("expected -fqdn conflict error, got: %v", err)
+	}
+}
+
+func TestRunUpdateRemarkOnlySkipsRecordUpdate(t *testing.T) {
+	api := &fakeDNSAPI{
+		infoResp: &alidns20150109.DescribeDomainRecordInfoResponseBody{
+			RecordId: tea.String("r-1"), DomainName: tea.String("example.com"), RR: tea.String("www"),
+			Type: tea.String("A"), Value: tea.String("1.2.3.4"), TTL: tea.Int64(600), Line: tea.String("default"),
+		},
+	}
+	err := Run([]string{"update", "-ak", "ak", "-sk", "sk", "-id", "r-1", "-remark", "owner: ops"}, Deps{
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	})
+	if err != nil {
+		t.Fatalf("update returned error: %v", err)
+	}
+	if api.updateCalled || !api.remarkCalled {
+		t.Fatalf("expected only the remark to be updated, update=%v remark=%v", api.updateCalled, api.remarkCalled)
+	}
+}
+
+func TestRunStatsTableWithSparkline(t *testing.T) {
+	api := &fakeDNSAPI{statResp: []*alidns20150109.DescribeDomainStatisticsResponseBodyStatisticsStatistic{
+		{Timestamp: tea.Int64(1759248000000), Count: tea.Int64(0)},
+		{Timestamp: tea.Int64(1759334400000), Count: tea.Int64(50)},
+		{Timestamp: tea.Int64(1759420800000), Count: tea.Int64(100)},
+	}}
+	deps := Deps{
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	}
+
+	stdout := &bytes.Buffer{}
+	deps.Stdout = stdout
+	err := Run([]string{"stats", "-ak", "ak", "-sk", "sk", "-domain", "example.com", "-start", "2025-10-01", "--output", "table"}, deps)
+	if err != nil {
+		t.Fatalf("stats returned error: %v", err)
+	}
+	for _, want := range []string{"2025-10-01 00:00  0", "TOTAL", "150", "[ +@]"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("table output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	err = Run([]string{"stats", "-ak", "ak", "-sk", "sk", "-domain", "example.com", "-start", "2025-10-01", "--output", "csv"}, deps)
+	if err != nil {
+		t.Fatalf("stats returned error: %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "time,timestamp,count\n2025-10-01 00:00,1759248000000,0\n") {
+		t.Fatalf("unexpected csv output:\n%s", stdout.String())
+	}
+
+	if err := Run([]string{"stats", "-ak", "ak", "-sk", "sk", "-domain", "example.com", "-start", "10/01"}, deps); err == nil {
+		t.Fatal("expected an invalid -start date to be rejected")
+	}
+}
+
+func TestRunAddValidatesLineWithCachedLines(t *testing.T) {
+	api := &fakeDNSAPI{
+		lineResp: []*alidns20150109.DescribeSupportLinesResponseBodyRecordLinesRecordLine{
+			{LineCode: tea.String("default"), LineName: tea.String("Default")},
+			{LineCode: tea.String("telecom"), LineName: tea.String("China Telecom"), LineDisplayName: tea.String("电信")},
+		},
+		addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1")},
+	}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		StateDir: t.TempDir(),
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	}
+	add := func(line string) error {
+		return Run([]string{"add", "-ak", "ak", "-sk", "sk", "-domain", "example.com", "-name", "www", "-type", "A", "-value", "1.2.3.4", "-line", line}, deps)
+	}
+
+	if err := add("telecom"); err != nil {
+		t.Fatalf("add returned error: %v", err)
+	}
+	if err := add("telecom"); err != nil {
+		t.Fatalf("add with cached lines returned error: %v", err)
+	}
+	if api.linesCalled != 1 {
+		t.Fatalf("expected supported lines to be fetched once, got %d", api.linesCalled)
+	}
+
+	api.addCalled = false
+	err := add("电信")
+	if err == nil || !strings.Contains(err.Error(), `"telecom"`) {
+		t.Fatalf("expected a suggestion of the line code, got: %v", err)
+	}
+	if err := add("telecon"); err == nil {
+		t.Fatal("expected an unsupported line to be rejected")
+	}
+	if api.addCalled {
+		t.Fatal("AddDomainRecord should not be called with an invalid line")
+	}
+}
+
+func TestRunDNSSECEnablePrintsDSRecord(t *testing.T) {
+	api := &fakeDNSAPI{dnssecResp: &alidns20150109.DescribeDomainDnssecInfoResponseBody{
+		DomainName: tea.String("example.com"),
+		Status:     tea.String("ON"),
+		DsRecord:   tea.String("example.com. 3600 IN DS 2371 13 2 C1A0424B"),
+	}}
+	stdout := &bytes.Buffer{}
+	err := Run([]string{"--output", "json", "dnssec", "enable", "-ak", "ak", "-sk", "sk", "-domain", "example.com"}, Deps{
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+	})
+	if err != nil {
+		t.Fatalf("dnssec enable returned error: %v", err)
+	}
+	if api.dnssecStatus != "ON" {
+		t.Fatalf("expected DNSSEC to be turned on, got %q", api.dnssecStatus)
+	}
+	if !strings.Contains(stdout.String(), `"DS":{"KeyTag":2371,"Algorithm":13,"DigestType":2,"Digest":"C1A0424B"}`) {
+		t.Fatalf("unexpected output: %s", stdout.String())
+	}
+}
+
+func TestRunAppliesDepsMiddlewareAndAPILog(t *testing.T) {
+	api := &fakeDNSAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-1")}}
+	var ops []string
+	stderr := &bytes.Buffer{}
+	deps := Deps{
+		Stdout: &bytes.Buffer{},
+		Stderr: stderr,
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		Middleware: []alidns.Middleware{alidns.Observe(func(op string, _ time.Duration, _ error) {
+			ops = append(ops, op)
+		})},
+	}
+
+	if err := Run([]string{"--api-log", "add", "-ak", "ak", "-sk", "sk", "-domain", "example.com", "-name", "www", "-type", "A", "-value", "1.2.3.4"}, deps); err != nil {
+		t.Fatalf("add returned error: %v", err)
+	}
+	if !slices.Contains(ops, "AddDomainRecord") {
+		t.Fatalf("middleware did not see the add, saw %v", ops)
+	}
+	if got := stderr.String(); !strings.Contains(got, "op=AddDomainRecord") {
+		t.Fatalf("--api-log did not log the add: %s", got)
+	}
+
+	deps.Middleware = []alidns.Middleware{alidns.ReadOnly()}
+	if err := Run([]string{"del", "-ak", "ak", "-sk", "sk", "-domain", "example.com", "-name", "www", "-type", "A"}, deps); !errors.Is(err, alidns.ErrReadOnly) {
+		t.Fatalf("expected the read-only policy to refuse del, got %v", err)
+	}
+	if api.delCalled {
+		t.Fatal("del should not reach the API")
+	}
+}
+
+func TestRunRestoreJournalsEachStep(t *testing.T) {
+	snap := snapshot.New("example.com", []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+		{RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.1.1.1"), TTL: tea.Int64(600), Line: tea.String("default"), Status: tea.String("ENABLE")},
+		{RR: tea.String("api"), Type: tea.String("A"), Value: tea.String("3.3.3.3"), TTL: tea.Int64(600), Line: tea.String("default"), Status: tea.String("ENABLE")},
+	})
+	file, err := snap.WriteDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := &fakeDNSAPI{
+		queryResp: []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{
+			{RecordId: tea.String("r-1"), DomainName: tea.String("example.com"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("2.2.2.2"), TTL: tea.Int64(600), Line: tea.String("default"), Status: tea.String("ENABLE")},
+			{RecordId: tea.String("r-2"), DomainName: tea.String("example.com"), RR: tea.String("old"), Type: tea.String("A"), Value: tea.String("4.4.4.4"), TTL: tea.Int64(600), Line: tea.String("default"), Status: tea.String("ENABLE")},
+		},
+		addResp:    &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-3")},
+		updateResp: &alidns20150109.UpdateDomainRecordResponseBody{},
+	}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		StateDir: t.TempDir(),
+	}
+
+	if err := Run([]string{"restore", "-ak", "ak", "-sk", "sk", "-f", file}, deps); err != nil {
+		t.Fatalf("restore returned error: %v", err)
+	}
+	if !api.updateCalled || !api.deleteCalled || !api.addCalled {
+		t.Fatalf("restore should update www in place, add api and delete old: %+v", api)
+	}
+
+	entries, err := deps.journal().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ops []string
+	for _, e := range entries {
+		ops = append(ops, e.Operation)
+	}
+	if want := []string{journal.OpAdd, journal.OpUpdate, journal.OpDel}; !slices.Equal(ops, want) {
+		t.Fatalf("journaled %v, want %v", ops, want)
+	}
+	if entries[0].RecordIds[0] != "r-3" || tea.StringValue(entries[1].Before[0].Value) != "2.2.2.2" || tea.StringValue(entries[2].Before[0].RecordId) != "r-2" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestRunUndoUpdateRestoresStatus(t *testing.T) {
+	api := &fakeDNSAPI{infoResp: &alidns20150109.DescribeDomainRecordInfoResponseBody{
+		RecordId: tea.String("r-1"), DomainName: tea.String("example.com"), RR: tea.String("www"), Type: tea.String("A"),
+		Value: tea.String("1.1.1.1"), TTL: tea.Int64(600), Line: tea.String("default"), Status: tea.String("ENABLE"),
+	}}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		StateDir: t.TempDir(),
+	}
+	if err := deps.journal().Append(&journal.Entry{Domain: "example.com", Operation: journal.OpUpdate, Before: []*journal.Record{{
+		RecordId: tea.String("r-1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.1.1.1"),
+		TTL: tea.Int64(600), Line: tea.String("default"), Status: tea.String("DISABLE"),
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Run([]string{"undo", "-ak", "ak", "-sk", "sk"}, deps); err != nil {
+		t.Fatalf("undo returned error: %v", err)
+	}
+	if api.updateCalled {
+		t.Fatal("undo should not update unchanged fields")
+	}
+	if len(api.statusReq) != 1 || tea.StringValue(api.statusReq[0].Status) != "Disable" {
+		t.Fatalf("undo should disable the record again: %+v", api.statusReq)
+	}
+}
+
+// failingWeightAPI accepts every call except weight changes.
+type failingWeightAPI struct {
+	*fakeDNSAPI
+}
+
+func (failingWeightAPI) UpdateDNSSLBWeight(context.Context, *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	return nil, errors.New("weight rejected")
+}
+
+func TestRunUndoJournalsPartialRestore(t *testing.T) {
+	api := &fakeDNSAPI{addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-9"), RequestId: tea.String("req-add")}}
+	deps := Deps{
+		Stdout:   &bytes.Buffer{},
+		Stderr:   &bytes.Buffer{},
+		NewAPI:   func(_, _ string) (alidns.DNSAPI, error) { return failingWeightAPI{api}, nil },
+		StateDir: t.TempDir(),
+	}
+	if err := deps.journal().Append(&journal.Entry{Domain: "example.com", Operation: journal.OpDel, Before: []*journal.Record{
+		{RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.1.1.1")},
+		{RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("2.2.2.2"), Weight: tea.Int32(30)},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Run([]string{"undo", "-ak", "ak", "-sk", "sk"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "weight rejected") || !strings.Contains(err.Error(), "未完成") {
+		t.Fatalf("expected the partial undo to be reported, got: %v", err)
+	}
+
+	entries, err := deps.journal().List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("the re-added records should be journaled: %+v", entries)
+	}
+	undo := entries[1]
+	if undo.Operation != journal.OpAdd || undo.UndoOf != entries[0].ID || len(undo.RecordIds) != 2 {
+		t.Fatalf("unexpected partial undo entry: %+v", undo)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+// sparkRamp maps query counts onto characters of increasing density.
+const sparkRamp = " .:-=+*#%@"
+
+type statsResult struct {
+	Domain string `json:",omitempty"`
+	RR     string `json:",omitempty"`
+	Start  string
+	End    string `json:",omitempty"`
+	Total  int64
+	Points []*alidns.StatPoint `json:",omitempty"`
+	Totals []*alidns.StatTotal `json:",omitempty"`
+}
+
+func runStats(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	fs, f := newStatsFlagSet(deps.Stderr, globalOutput)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+		requiredArg{name: "-start", value: f.start},
+	); err != nil {
+		return err
+	}
+	if !f.summary {
+		if err := requireAll(requiredArg{name: "-domain", value: f.domain}); err != nil {
+			return err
+		}
+	} else if f.rr != "" {
+		return fmt.Errorf("错误: -summary 不能与 -rr 同时使用")
+	}
+	for _, date := range []string{f.start, f.end} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return fmt.Errorf("错误: 日期 %q 格式无效，应为 YYYY-MM-DD", date)
+		}
+	}
+
+	var output OutputFormat
+	switch f.output {
+	case "table", "csv":
+	default:
+		if output, err = ParseOutputFormat(f.output); err != nil {
+			return fmt.Errorf("invalid --output value %q, expected json|pretty|table|csv", f.output)
+		}
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	in := alidns.StatsInput{DomainName: f.domain, RR: f.rr, StartDate: f.start, EndDate: f.end}
+	result := statsResult{Domain: f.domain, RR: f.rr, Start: f.start, End: f.end}
+	if f.summary {
+		if result.Totals, err = svc.StatsSummary(ctx, in); err != nil {
+			return err
+		}
+		for _, total := range result.Totals {
+			result.Total += total.Count
+		}
+	} else {
+		if result.Points, err = svc.Stats(ctx, in); err != nil {
+			return err
+		}
+		for _, point := range result.Points {
+			result.Total += point.Count
+		}
+	}
+
+	switch f.output {
+	case "table":
+		return printStatsTable(deps.Stdout, result, f.summary)
+	case "csv":
+		return printStatsCSV(deps.Stdout, result, f.summary)
+	}
+	return Print(deps.Stdout, result, output)
+}
+
+func printStatsTable(w io.Writer, result statsResult, summary bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if summary {
+		fmt.Fprintln(tw, "NAME\tCOUNT")
+		for _, total := range result.Totals {
+			fmt.Fprintf(tw, "%s\t%d\n", total.Name, total.Count)
+		}
+	} else {
+		fmt.Fprintln(tw, "TIME\tCOUNT")
+		for _, point := range result.Points {
+			fmt.Fprintf(tw, "%s\t%d\n", formatStatTime(point.Timestamp), point.Count)
+		}
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\n", result.Total)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if summary || len(result.Points) == 0 {
+		return nil
+	}
+	counts := make([]int64, 0, len(result.Points))
+	for _, point := range result.Points {
+		counts = append(counts, point.Count)
+	}
+	_, err := fmt.Fprintf(w, "\n[%s]\n", sparkline(counts))
+	return err
+}
+
+func printStatsCSV(w io.Writer, result statsResult, summary bool) error {
+	cw := csv.NewWriter(w)
+	if summary {
+		_ = cw.Write([]string{"name", "count"})
+		for _, total := range result.Totals {
+			_ = cw.Write([]string{total.Name, strconv.FormatInt(total.Count, 10)})
+		}
+	} else {
+		_ = cw.Write([]string{"time", "timestamp", "count"})
+		for _, point := range result.Points {
+			_ = cw.Write([]string{
+				formatStatTime(point.Timestamp),
+				strconv.FormatInt(point.Timestamp, 10),
+				strconv.FormatInt(point.Count, 10),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatStatTime(ms int64) string {
+	return time.UnixMilli(ms).In(alidns.APIZone).Format("2006-01-02 15:04")
+}
+
+// sparkline scales counts linearly between zero and the maximum, so an
+// all-blank line means no queries at all.
+func sparkline(counts []int64) string {
+	var peak int64
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	var b strings.Builder
+	last := int64(len(sparkRamp) - 1)
+	for _, c := range counts {
+		i := int64(0)
+		if peak > 0 {
+			i = (c*last + peak - 1) / peak
+		}
+		b.WriteByte(sparkRamp[i])
+	}
+	return b.String()
+}
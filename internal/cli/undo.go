@@ -0,0 +1,255 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func runUndo(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	fs, f := newUndoFlagSet(deps.Stderr, globalOutput)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+	if fs.NArg() > 1 {
+		return fmt.Errorf("undo 仅支持一个变更 ID")
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+	); err != nil {
+		return err
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	j := deps.journal()
+	if j == nil {
+		return errNoStateDir
+	}
+	entry, err := j.Undoable(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if entry.Backend != deps.backend {
+		return fmt.Errorf("错误: 变更 %s 属于 %s 后端，请使用 --backend %s 撤销", entry.ID, backendName(entry.Backend), backendName(entry.Backend))
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+
+	undo, err := undoChange(ctx, svc, entry)
+	if err != nil && undo != nil && len(undo.RecordIds) > 0 {
+		// Some records were re-added before the failure. Journal them so
+		// that they can be undone, and report the failure on its own.
+		summary := undoSummary(entry, undo)
+		recordChange(ctx, deps, f.ak, undo, summary, undo.Request, nil)
+		failed := &journal.Entry{Domain: undo.Domain, Operation: undo.Operation, Before: entry.Before, UndoOf: entry.ID}
+		recordChange(ctx, deps, f.ak, failed, summary, nil, err)
+		return fmt.Errorf("撤销 %s 未完成，已恢复的 %d 条记录已记为变更 %s: %w", entry.ID, len(undo.RecordIds), undo.ID, err)
+	}
+	if undo != nil {
+		recordChange(ctx, deps, f.ak, undo, undoSummary(entry, undo), undo.Request, err)
+	}
+	if err != nil {
+		return fmt.Errorf("撤销 %s 失败: %w", entry.ID, err)
+	}
+
+	return Print(deps.Stdout, undo, output)
+}
+
+// undoChange applies the inverse of entry and describes it as a new entry.
+// On error the entry describes what was attempted.
+func undoChange(ctx context.Context, svc *alidns.Service, entry *journal.Entry) (*journal.Entry, error) {
+	undo := &journal.Entry{Domain: entry.Domain, UndoOf: entry.ID}
+
+	switch entry.Operation {
+	case journal.OpAdd:
+		undo.Operation = journal.OpDel
+		undo.Request = map[string][]string{"RecordIds": entry.RecordIds}
+		for _, id := range entry.RecordIds {
+			info, err := svc.RecordInfo(ctx, id)
+			if err != nil {
+				return undo, err
+			}
+			undo.Before = append(undo.Before, alidns.RecordFromInfo(info))
+			resp, err := svc.DeleteRecord(ctx, id)
+			if err != nil {
+				return undo, err
+			}
+			if resp != nil {
+				undo.RequestId = tea.StringValue(resp.RequestId)
+			}
+		}
+	case journal.OpDel:
+		undo.Operation = journal.OpAdd
+		inputs := make([]alidns.AddInput, 0, len(entry.Before))
+		weighted := map[string]bool{}
+		for _, record := range entry.Before {
+			in := addInputFromRecord(record)
+			if in.DomainName == "" {
+				in.DomainName = entry.Domain
+			}
+			// Add returns the record along with the error when only its
+			// remark failed; the record exists and must be accounted for.
+			resp, err := svc.Add(ctx, in)
+			if err == nil || resp != nil {
+				inputs = append(inputs, in)
+			}
+			if resp != nil {
+				id := tea.StringValue(resp.RecordId)
+				undo.RequestId = tea.StringValue(resp.RequestId)
+				undo.RecordIds = append(undo.RecordIds, id)
+				if err == nil {
+					err = restoreRecordState(ctx, svc, in, id, record, weighted)
+				}
+			}
+			if err != nil {
+				undo.Request = inputs
+				return undo, err
+			}
+		}
+		undo.Request = inputs
+	case journal.OpUpdate:
+		if len(entry.Before) != 1 {
+			return nil, fmt.Errorf("entry %s has no previous record state", entry.ID)
+		}
+		undo.Operation = journal.OpUpdate
+		prev := entry.Before[0]
+		info, err := svc.RecordInfo(ctx, tea.StringValue(prev.RecordId))
+		if err != nil {
+			return undo, err
+		}
+		undo.Before = append(undo.Before, alidns.RecordFromInfo(info))
+		in := alidns.UpdateInput{
+			RecordID: tea.StringValue(prev.RecordId),
+			Name:     tea.StringValue(prev.RR),
+			Type:     tea.StringValue(prev.Type),
+			Value:    tea.StringValue(prev.Value),
+			TTL:      tea.Int64Value(prev.TTL),
+			Priority: tea.Int64Value(prev.Priority),
+			Line:     tea.StringValue(prev.Line),
+		}
+		in, changed := alidns.MergeUpdate(info, in)
+		if changed {
+			resp, err := svc.Update(ctx, in)
+			if err != nil {
+				return undo, err
+			}
+			if resp != nil {
+				undo.RequestId = tea.StringValue(resp.RequestId)
+			}
+		}
+		remarkChanged := tea.StringValue(prev.Remark) != tea.StringValue(info.Remark)
+		if remarkChanged {
+			resp, err := svc.SetRemark(ctx, tea.StringValue(prev.RecordId), tea.StringValue(prev.Remark))
+			if err != nil {
+				return undo, err
+			}
+			if resp != nil && !changed {
+				undo.RequestId = tea.StringValue(resp.RequestId)
+			}
+		}
+		if prev.Status != nil && !strings.EqualFold(tea.StringValue(prev.Status), tea.StringValue(info.Status)) {
+			resp, err := svc.SetStatus(ctx, tea.StringValue(prev.RecordId), strings.EqualFold(tea.StringValue(prev.Status), "ENABLE"))
+			if err != nil {
+				return undo, err
+			}
+			if resp != nil && !changed && !remarkChanged {
+				undo.RequestId = tea.StringValue(resp.RequestId)
+			}
+		}
+		undo.Request = updateRequest{UpdateInput: in, Remark: remarkOf(remarkChanged, tea.StringValue(prev.Remark))}
+	default:
+		return nil, fmt.Errorf("entry %s has unknown operation %q", entry.ID, entry.Operation)
+	}
+	return undo, nil
+}
+
+// undoSummary names the first record an undo touches.
+func undoSummary(entry, undo *journal.Entry) string {
+	records := undo.Before
+	if len(records) == 0 {
+		records = entry.Before
+	}
+	if len(records) == 0 {
+		return strings.Join(entry.RecordIds, ",")
+	}
+	r := records[0]
+	summary := recordSummary(entry.Domain, tea.StringValue(r.RR), tea.StringValue(r.Type), tea.StringValue(r.Value))
+	if len(records) > 1 {
+		summary += fmt.Sprintf(" 等 %d 条", len(records))
+	}
+	return summary
+}
+
+// restoreRecordState gives a re-added record the status and weight it had
+// before it was deleted. A new record is enabled with weight 1, so only other
+// values are written; weighted round-robin is turned on once per RR set,
+// tracked in weighted.
+func restoreRecordState(ctx context.Context, svc *alidns.Service, in alidns.AddInput, id string, record *alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord, weighted map[string]bool) error {
+	if strings.EqualFold(tea.StringValue(record.Status), "DISABLE") {
+		if _, err := svc.SetStatus(ctx, id, false); err != nil {
+			return err
+		}
+	}
+	weight := tea.Int32Value(record.Weight)
+	if weight == 0 || weight == 1 {
+		return nil
+	}
+	key := strings.Join([]string{in.DomainName, in.Name, in.Type, in.Line}, "\x00")
+	if !weighted[key] {
+		if _, err := svc.SetSLBStatus(ctx, alidns.SLBStatusInput{
+			DomainName: in.DomainName,
+			Name:       in.Name,
+			Type:       in.Type,
+			Line:       in.Line,
+			Open:       true,
+		}); err != nil {
+			return err
+		}
+		weighted[key] = true
+	}
+	_, err := svc.SetWeight(ctx, alidns.WeightInput{RecordID: id, Weight: weight})
+	return err
+}
+
+func addInputFromRecord(record *alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord) alidns.AddInput {
+	return alidns.AddInput{
+		DomainName: tea.StringValue(record.DomainName),
+		Name:       tea.StringValue(record.RR),
+		Type:       tea.StringValue(record.Type),
+		Value:      tea.StringValue(record.Value),
+		TTL:        tea.Int64Value(record.TTL),
+		Priority:   tea.Int64Value(record.Priority),
+		Line:       tea.StringValue(record.Line),
+		Remark:     tea.StringValue(record.Remark),
+	}
+}
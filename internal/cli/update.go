@@ -13,9 +13,15 @@ package cli
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"strings"
 
-	"alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/dnscheck"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
 )
 
 func runUpdate(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
@@ -32,13 +38,15 @@ func runUpdate(ctx context.Context, args []string, globalOutput OutputFormat, de
 		requiredArg{name: "-ak", value: f.ak},
 		requiredArg{name: "-sk", value: f.sk},
 		requiredArg{name: "-id", value: f.recordID},
-		requiredArg{name: "-name", value: f.name},
-		requiredArg{name: "-type", value: f.rType},
-		requiredArg{name: "-value", value: f.value},
 	); err != nil {
 		return err
 	}
 
+	given, err := updateInputFromFlags(fs, f)
+	if err != nil {
+		return err
+	}
+
 	output, err := ParseOutputFormat(f.output)
 	if err != nil {
 		return err
@@ -50,18 +58,138 @@ func runUpdate(ctx context.Context, args []string, globalOutput OutputFormat, de
 	}
 	svc := alidns.NewService(api)
 
-	resp, err := svc.Update(ctx, alidns.UpdateInput{
-		RecordID: f.recordID,
-		Name:     f.name,
-		Type:     f.rType,
-		Value:    f.value,
-		TTL:      f.ttl,
-		Priority: f.priority,
-		Line:     f.line,
-	})
+	info, err := svc.RecordInfo(ctx, f.recordID)
+	if err != nil {
+		return fmt.Errorf("读取当前记录失败: %w", err)
+	}
+	if given.Line != "" && given.Line != tea.StringValue(info.Line) {
+		if err := checkLine(ctx, deps, svc, f.ak, tea.StringValue(info.DomainName), given.Line); err != nil {
+			return err
+		}
+	}
+	in, changed := alidns.MergeUpdate(info, given)
+	remarkChanged := flagPassed(fs, "remark") && f.remark != tea.StringValue(info.Remark)
+	if !changed && !remarkChanged {
+		return Print(deps.Stdout, updateOutput{
+			UpdateDomainRecordResponseBody: &alidns20150109.UpdateDomainRecordResponseBody{RecordId: info.RecordId},
+			Unchanged:                      true,
+		}, output)
+	}
+
+	request := updateRequest{UpdateInput: in, Remark: remarkOf(remarkChanged, f.remark)}
+	resp, updated, err := updateRecord(ctx, svc, in, changed, request.Remark)
+	entry := &journal.Entry{
+		Domain:    tea.StringValue(info.DomainName),
+		Operation: journal.OpUpdate,
+		Request:   request,
+		Before:    []*alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord{alidns.RecordFromInfo(info)},
+	}
+	if resp != nil {
+		entry.RequestId = tea.StringValue(resp.RequestId)
+	}
+	summary := recordSummary(entry.Domain, in.Name, in.Type, in.Value)
+	if err != nil && updated {
+		// The fields changed before the remark failed. Journal and report
+		// that change on its own so that it can be undone, and the remark
+		// as a separate failure.
+		entry.Request = updateRequest{UpdateInput: in}
+		recordChange(ctx, deps, f.ak, entry, summary, entry.Request, nil)
+		failed := &journal.Entry{
+			Domain:    entry.Domain,
+			Operation: journal.OpUpdate,
+			Request:   updateRequest{UpdateInput: alidns.UpdateInput{RecordID: in.RecordID}, Remark: request.Remark},
+			Before:    entry.Before,
+		}
+		recordChange(ctx, deps, f.ak, failed, summary, failed.Request, err)
+		return fmt.Errorf("记录已修改，但修改备注失败: %w", err)
+	}
+	recordChange(ctx, deps, f.ak, entry, summary, request, err)
 	if err != nil {
 		return err
 	}
+	if f.wait.wait <= 0 {
+		return Print(deps.Stdout, resp, output)
+	}
+
+	domainName := tea.StringValue(info.DomainName)
+	report, waitErr := waitPropagation(ctx, f.wait, dnscheck.Expect{
+		DomainName: domainName,
+		FQDN:       alidns.JoinFQDN(domainName, in.Name),
+		Type:       in.Type,
+		Value:      in.Value,
+	})
+	return printWithWaitError(deps, updateOutput{UpdateDomainRecordResponseBody: resp, Propagation: report}, output, waitErr)
+}
+
+// updateInputFromFlags takes the fields whose flags were given; the others
+// keep the record's current value. Since an empty string or a zero number
+// means "keep", a given flag must not be one.
+func updateInputFromFlags(fs *flag.FlagSet, f *updateFlags) (alidns.UpdateInput, error) {
+	for _, name := range []string{"name", "type", "value", "line"} {
+		if flagPassed(fs, name) && strings.TrimSpace(fs.Lookup(name).Value.String()) == "" {
+			return alidns.UpdateInput{}, fmt.Errorf("错误: -%s 不能为空", name)
+		}
+	}
+	for _, name := range []string{"ttl", "priority"} {
+		if flagPassed(fs, name) && fs.Lookup(name).Value.String() == "0" {
+			return alidns.UpdateInput{}, fmt.Errorf("错误: -%s 不能为 0", name)
+		}
+	}
+
+	in := alidns.UpdateInput{RecordID: f.recordID}
+	if flagPassed(fs, "name") {
+		in.Name = f.name
+	}
+	if flagPassed(fs, "type") {
+		in.Type = f.rType
+	}
+	if flagPassed(fs, "value") {
+		in.Value = f.value
+	}
+	if flagPassed(fs, "ttl") {
+		in.TTL = f.ttl
+	}
+	if flagPassed(fs, "priority") {
+		in.Priority = f.priority
+	}
+	if flagPassed(fs, "line") {
+		in.Line = f.line
+	}
+	return in, nil
+}
+
+// updateRecord applies the merged fields when they changed and then the
+// remark, if one is given. updated reports whether the fields were changed,
+// which they may have been even when the remark then failed.
+func updateRecord(ctx context.Context, svc *alidns.Service, in alidns.UpdateInput, changed bool, remark *string) (resp *alidns20150109.UpdateDomainRecordResponseBody, updated bool, err error) {
+	resp = &alidns20150109.UpdateDomainRecordResponseBody{RecordId: tea.String(in.RecordID)}
+	if changed {
+		if resp, err = svc.Update(ctx, in); err != nil {
+			return resp, false, err
+		}
+	}
+	if remark != nil {
+		remarkResp, err := svc.SetRemark(ctx, in.RecordID, *remark)
+		if err != nil {
+			return resp, changed, err
+		}
+		if !changed && remarkResp != nil {
+			resp.RequestId = remarkResp.RequestId
+		}
+	}
+	return resp, changed, nil
+}
 
-	return Print(deps.Stdout, resp, output)
+// updateRequest is how an update is journaled: the merged record fields plus
+// the remark when it was changed.
+type updateRequest struct {
+	alidns.UpdateInput
+	Remark *string `json:",omitempty"`
+}
+
+func remarkOf(changed bool, remark string) *string {
+	if !changed {
+		return nil
+	}
+	return tea.String(remark)
 }
@@ -16,35 +16,48 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"time"
 )
 
 type addFlags struct {
 	ak       string
 	sk       string
 	domain   string
+	fqdn     string
 	name     string
 	rType    string
 	value    string
 	ttl      int64
 	priority int64
 	line     string
+	remark   string
 	output   string
+	wait     waitFlags
 }
 
 type delFlags struct {
 	ak     string
 	sk     string
 	domain string
+	fqdn   string
 	name   string
 	rType  string
+	value  string
 	output string
 }
 
 type queryFlags struct {
-	ak     string
-	sk     string
-	domain string
-	output string
+	ak      string
+	sk      string
+	domain  string
+	name    string
+	fqdn    string
+	remark  string
+	group   string
+	unicode bool
+	output  string
 }
 
 type updateFlags struct {
@@ -57,9 +70,213 @@ type updateFlags struct {
 	ttl      int64
 	priority int64
 	line     string
+	remark   string
+	output   string
+	wait     waitFlags
+}
+
+type acmeFlags struct {
+	ak     string
+	sk     string
+	fqdn   string
+	value  string
+	output string
+	wait   waitFlags
+}
+
+type weightFlags struct {
+	ak       string
+	sk       string
+	domain   string
+	fqdn     string
+	name     string
+	rType    string
+	line     string
+	recordID string
+	weight   int64
 	output   string
 }
 
+type historyFlags struct {
+	domain string
+	limit  int
+	output string
+}
+
+type undoFlags struct {
+	ak     string
+	sk     string
+	output string
+}
+
+type groupFlags struct {
+	ak      string
+	sk      string
+	name    string
+	newName string
+	output  string
+}
+
+type domainFlags struct {
+	ak     string
+	sk     string
+	domain string
+	group  string
+	output string
+}
+
+type logsFlags struct {
+	ak      string
+	sk      string
+	level   string
+	domain  string
+	keyword string
+	since   time.Duration
+	limit   int
+	output  string
+}
+
+type gtmFlags struct {
+	ak            string
+	sk            string
+	instance      string
+	keyword       string
+	pool          string
+	strategy      string
+	monitor       string
+	name          string
+	poolType      string
+	lba           string
+	mode          string
+	addrs         string
+	lines         string
+	pools         string
+	failoverPools string
+	minAvailable  int
+	protocol      string
+	interval      int
+	timeout       int
+	count         int
+	extend        string
+	nodes         string
+	output        string
+}
+
+type dnssecFlags struct {
+	ak          string
+	sk          string
+	domain      string
+	check       bool
+	force       bool
+	nameservers string
+	resolver    string
+	timeout     time.Duration
+	output      string
+}
+
+type linesFlags struct {
+	ak     string
+	sk     string
+	domain string
+	name   string
+	ips    string
+	output string
+}
+
+type zoneFlags struct {
+	ak      string
+	sk      string
+	zone    string
+	vpc     string
+	region  string
+	replace bool
+	output  string
+}
+
+type statsFlags struct {
+	ak      string
+	sk      string
+	domain  string
+	rr      string
+	start   string
+	end     string
+	summary bool
+	output  string
+}
+
+type exporterFlags struct {
+	ak        string
+	sk        string
+	domain    string
+	group     string
+	listen    string
+	interval  time.Duration
+	valueInfo bool
+}
+
+type watchFlags struct {
+	ak       string
+	sk       string
+	domain   string
+	group    string
+	interval time.Duration
+	initial  bool
+}
+
+type externalDNSFlags struct {
+	ak     string
+	sk     string
+	domain string
+	listen string
+}
+
+type notifyFlags struct {
+	domain  string
+	profile string
+	output  string
+}
+
+type backupFlags struct {
+	ak         string
+	sk         string
+	domain     string
+	group      string
+	allDomains bool
+	dir        string
+	output     string
+}
+
+type restoreFlags struct {
+	ak     string
+	sk     string
+	file   string
+	domain string
+	dryRun bool
+	output string
+}
+
+type diffFlags struct {
+	ak     string
+	sk     string
+	domain string
+	file   string
+	from   string
+	color  string
+	output string
+}
+
+type waitFlags struct {
+	wait        time.Duration
+	nameservers string
+	resolver    string
+}
+
+func (w *waitFlags) register(fs *flag.FlagSet, defaultWait time.Duration) {
+	fs.DurationVar(&w.wait, "wait", defaultWait, "等待全部权威 DNS 返回新值的最长时间，0 表示不等待")
+	fs.StringVar(&w.nameservers, "nameserver", "", "覆盖要检查的权威 DNS，逗号分隔的 host[:port]")
+	fs.StringVar(&w.resolver, "resolver", "", "查询 NS 记录及其地址使用的 DNS host[:port]，默认系统解析器")
+}
+
 func parseFlagSet(fs *flag.FlagSet, args []string) (bool, error) {
 	if err := fs.Parse(args); err != nil {
 		if errors.Is(err, flag.ErrHelp) {
@@ -70,6 +287,47 @@ func parseFlagSet(fs *flag.FlagSet, args []string) (bool, error) {
 	return false, nil
 }
 
+// flagPassed reports whether the flag name was given on the command line,
+// which tells an explicit empty value apart from an omitted flag.
+func flagPassed(fs *flag.FlagSet, name string) bool {
+	passed := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+	return passed
+}
+
+// parseAction splits the leading action off a command family such as
+// "acme present". usage is printed when the action is missing or unknown, or
+// when help is requested, which helpShown reports.
+func parseAction(command string, args []string, usage func(), actions ...string) (action string, rest []string, helpShown bool, err error) {
+	if len(args) == 0 {
+		usage()
+		return "", nil, false, fmt.Errorf("missing %s action, expected %s", command, strings.Join(actions, "|"))
+	}
+
+	action = args[0]
+	if isHelpFlag(action) {
+		usage()
+		return "", nil, true, nil
+	}
+	if !slices.Contains(actions, action) {
+		usage()
+		return "", nil, false, fmt.Errorf("unknown %s action %q, expected %s", command, action, strings.Join(actions, "|"))
+	}
+	return action, args[1:], false, nil
+}
+
+func isHelpFlag(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
+
 func newAddFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *addFlags) {
 	f := &addFlags{}
 	fs := flag.NewFlagSet("add", flag.ContinueOnError)
@@ -77,14 +335,17 @@ func newAddFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet,
 
 	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
 	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
-	fs.StringVar(&f.domain, "domain", "", "要添加记录的主域名 (必需)")
-	fs.StringVar(&f.name, "name", "", "主机记录 (必需)")
+	fs.StringVar(&f.domain, "domain", "", "要添加记录的主域名 (未指定 -fqdn 时必需)")
+	fs.StringVar(&f.name, "name", "", "主机记录 (未指定 -fqdn 时必需)")
+	fs.StringVar(&f.fqdn, "fqdn", "", "完整域名，如 www.example.com，自动拆分为 -domain 与 -name")
 	fs.StringVar(&f.rType, "type", "", "记录类型 (必需)")
 	fs.StringVar(&f.value, "value", "", "记录值 (必需)")
 	fs.Int64Var(&f.ttl, "ttl", 600, "TTL")
 	fs.Int64Var(&f.priority, "priority", 1, "优先级")
 	fs.StringVar(&f.line, "line", "default", "线路")
+	fs.StringVar(&f.remark, "remark", "", "备注，如负责人或工单号")
 	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	f.wait.register(fs, 0)
 	fs.Usage = func() {
 		printAddUsage(stderr, globalOutput)
 	}
@@ -99,9 +360,11 @@ func newDelFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet,
 
 	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
 	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
-	fs.StringVar(&f.domain, "domain", "", "要删除记录的主域名 (必需)")
-	fs.StringVar(&f.name, "name", "", "主机记录 (必需)")
+	fs.StringVar(&f.domain, "domain", "", "要删除记录的主域名 (未指定 -fqdn 时必需)")
+	fs.StringVar(&f.name, "name", "", "主机记录 (未指定 -fqdn 时必需)")
+	fs.StringVar(&f.fqdn, "fqdn", "", "完整域名，如 www.example.com，自动拆分为 -domain 与 -name")
 	fs.StringVar(&f.rType, "type", "", "记录类型 (必需)")
+	fs.StringVar(&f.value, "value", "", "仅删除该记录值的记录，默认删除全部")
 	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
 	fs.Usage = func() {
 		printDelUsage(stderr, globalOutput)
@@ -117,7 +380,12 @@ func newQueryFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet
 
 	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
 	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
-	fs.StringVar(&f.domain, "domain", "", "要查询的主域名 (必需)")
+	fs.StringVar(&f.domain, "domain", "", "要查询的主域名 (未指定 -fqdn 时必需)")
+	fs.StringVar(&f.name, "name", "", "仅查询该主机记录")
+	fs.StringVar(&f.fqdn, "fqdn", "", "完整域名，仅查询该主机记录")
+	fs.StringVar(&f.remark, "remark", "", "仅查询备注包含该文本的记录（不区分大小写）")
+	fs.StringVar(&f.group, "group", "", "查询该域名分组下全部域名，代替 -domain")
+	fs.BoolVar(&f.unicode, "unicode", false, "将国际化域名与主机记录显示为 Unicode")
 	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
 	fs.Usage = func() {
 		printQueryUsage(stderr, globalOutput)
@@ -134,13 +402,15 @@ func newUpdateFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSe
 	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
 	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
 	fs.StringVar(&f.recordID, "id", "", "解析记录ID (必需)")
-	fs.StringVar(&f.name, "name", "", "主机记录 (必需)")
-	fs.StringVar(&f.rType, "type", "", "记录类型 (必需)")
-	fs.StringVar(&f.value, "value", "", "记录值 (必需)")
-	fs.Int64Var(&f.ttl, "ttl", 600, "TTL")
-	fs.Int64Var(&f.priority, "priority", 1, "优先级")
-	fs.StringVar(&f.line, "line", "default", "线路")
+	fs.StringVar(&f.name, "name", "", "主机记录，默认保持不变")
+	fs.StringVar(&f.rType, "type", "", "记录类型，默认保持不变")
+	fs.StringVar(&f.value, "value", "", "记录值，默认保持不变")
+	fs.Int64Var(&f.ttl, "ttl", 0, "TTL，默认保持不变")
+	fs.Int64Var(&f.priority, "priority", 0, "优先级，默认保持不变")
+	fs.StringVar(&f.line, "line", "", "线路，默认保持不变")
+	fs.StringVar(&f.remark, "remark", "", "备注，如负责人或工单号；指定空字符串可清除，默认保持不变")
 	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	f.wait.register(fs, 0)
 	fs.Usage = func() {
 		printUpdateUsage(stderr, globalOutput)
 	}
@@ -148,107 +418,1057 @@ func newUpdateFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSe
 	return fs, f
 }
 
-func printRootUsage(w io.Writer) {
-	_, _ = fmt.Fprint(w, `用法:
-  alidns [--output json|pretty] <command> [flags]
-  alidns help [command]
+func newACMEFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *acmeFlags) {
+	f := &acmeFlags{}
+	fs := flag.NewFlagSet("acme "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
 
-全局参数:
-  --output string
-    	output format: json|pretty (default "pretty")
-  -h, --help
-    	显示帮助
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.fqdn, "fqdn", "", "挑战记录的完整域名，如 _acme-challenge.www.example.com (必需)")
+	fs.StringVar(&f.value, "value", "", "挑战 TXT 记录值 (必需)")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	f.wait.register(fs, defaultACMEWait)
+	fs.Usage = func() {
+		printACMEUsage(stderr, globalOutput)
+	}
 
-命令:
-  add      添加 DNS 记录
-  del      删除 DNS 记录
-  query    查询 DNS 记录
-  update   修改 DNS 记录
-  help     显示帮助
+	return fs, f
+}
 
-示例:
-  alidns add -ak AK -sk SK -domain example.com -name www -type A -value 1.2.3.4
-  alidns query -ak AK -sk SK -domain example.com --output json
-`)
+func newWeightFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *weightFlags) {
+	f := &weightFlags{}
+	fs := flag.NewFlagSet("weight "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
 
-	_, _ = fmt.Fprintln(w, "子命令参数说明:")
-	printAddUsage(w, OutputPretty)
-	printDelUsage(w, OutputPretty)
-	printQueryUsage(w, OutputPretty)
-	printUpdateUsage(w, OutputPretty)
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	switch action {
+	case "enable", "disable":
+		fs.StringVar(&f.domain, "domain", "", "主域名 (未指定 -fqdn 时必需)")
+		fs.StringVar(&f.name, "name", "", "主机记录 (未指定 -fqdn 时必需)")
+		fs.StringVar(&f.fqdn, "fqdn", "", "完整域名，自动拆分为 -domain 与 -name")
+		fs.StringVar(&f.rType, "type", "", "记录类型 (必需)")
+		fs.StringVar(&f.line, "line", "", "仅修改该线路，默认全部线路")
+	case "set":
+		fs.StringVar(&f.recordID, "id", "", "解析记录ID (必需)")
+		fs.Int64Var(&f.weight, "weight", 0, "权重，1-100 (必需)")
+	case "list":
+		fs.StringVar(&f.domain, "domain", "", "主域名 (未指定 -fqdn 时必需)")
+		fs.StringVar(&f.name, "name", "", "仅查看该主机记录")
+		fs.StringVar(&f.fqdn, "fqdn", "", "完整域名，仅查看该主机记录")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printWeightUsage(stderr, globalOutput)
+	}
+
+	return fs, f
 }
 
-func printAddUsage(w io.Writer, globalOutput OutputFormat) {
-	_, _ = fmt.Fprint(w, `
-用法:
-  alidns add [flags]
+func newHistoryFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *historyFlags) {
+	f := &historyFlags{}
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.SetOutput(stderr)
 
-说明:
-  添加 DNS 记录。
+	fs.StringVar(&f.domain, "domain", "", "仅显示该主域名的变更")
+	fs.IntVar(&f.limit, "limit", 20, "最多显示最近的条数，0 表示全部")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printHistoryUsage(stderr, globalOutput)
+	}
 
-参数:
-`)
-	fs, _ := newAddFlagSet(w, globalOutput)
-	fs.PrintDefaults()
-	_, _ = fmt.Fprint(w, `
-示例:
-  alidns add -ak AK -sk SK -domain example.com -name www -type A -value 1.2.3.4
-  alidns add -ak AK -sk SK -domain example.com -name @ -type TXT -value hello --output json
-`)
+	return fs, f
 }
 
-func printDelUsage(w io.Writer, globalOutput OutputFormat) {
-	_, _ = fmt.Fprint(w, `
-用法:
-  alidns del [flags]
+func newUndoFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *undoFlags) {
+	f := &undoFlags{}
+	fs := flag.NewFlagSet("undo", flag.ContinueOnError)
+	fs.SetOutput(stderr)
 
-说明:
-  删除 DNS 记录。
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printUndoUsage(stderr, globalOutput)
+	}
 
-参数:
-`)
-	fs, _ := newDelFlagSet(w, globalOutput)
-	fs.PrintDefaults()
-	_, _ = fmt.Fprint(w, `
-示例:
-  alidns del -ak AK -sk SK -domain example.com -name www -type A
-`)
+	return fs, f
 }
 
-func printQueryUsage(w io.Writer, globalOutput OutputFormat) {
-	_, _ = fmt.Fprint(w, `
-用法:
-  alidns query [flags]
+func newGroupFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *groupFlags) {
+	f := &groupFlags{}
+	fs := flag.NewFlagSet("group "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
 
-说明:
-  查询 DNS 记录列表。
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	switch action {
+	case "add":
+		fs.StringVar(&f.name, "name", "", "分组名称 (必需)")
+	case "rename":
+		fs.StringVar(&f.name, "name", "", "分组名称或ID (必需)")
+		fs.StringVar(&f.newName, "new-name", "", "新的分组名称 (必需)")
+	case "del":
+		fs.StringVar(&f.name, "name", "", "分组名称或ID (必需)")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printGroupUsage(stderr, globalOutput)
+	}
 
-参数:
-`)
-	fs, _ := newQueryFlagSet(w, globalOutput)
-	fs.PrintDefaults()
-	_, _ = fmt.Fprint(w, `
-示例:
-  alidns query -ak AK -sk SK -domain example.com
-  alidns query -ak AK -sk SK -domain example.com --output json
-`)
+	return fs, f
 }
 
-func printUpdateUsage(w io.Writer, globalOutput OutputFormat) {
-	_, _ = fmt.Fprint(w, `
-用法:
-  alidns update [flags]
+func newDomainFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *domainFlags) {
+	f := &domainFlags{}
+	fs := flag.NewFlagSet("domain "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
 
-说明:
-  修改 DNS 记录。
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	switch action {
+	case "list":
+		fs.StringVar(&f.group, "group", "", "仅列出该分组的域名")
+	case "move":
+		fs.StringVar(&f.domain, "domain", "", "主域名 (必需)")
+		fs.StringVar(&f.group, "group", "", "目标分组名称或ID (必需)")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printDomainUsage(stderr, globalOutput)
+	}
 
-参数:
-`)
-	fs, _ := newUpdateFlagSet(w, globalOutput)
-	fs.PrintDefaults()
-	_, _ = fmt.Fprint(w, `
-示例:
-  alidns update -ak AK -sk SK -id RECORD_ID -name www -type A -value 1.2.3.4
+	return fs, f
+}
+
+func newLogsFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *logsFlags) {
+	f := &logsFlags{}
+	fs := flag.NewFlagSet("logs", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.level, "level", "", "日志类型: record|domain，默认指定 -domain 时为 record，否则为 domain")
+	fs.StringVar(&f.domain, "domain", "", "主域名，record 日志必需；domain 日志只查看该域名")
+	fs.StringVar(&f.keyword, "keyword", "", "按关键字过滤，如主机记录；不能与 -level domain -domain 同时使用")
+	fs.DurationVar(&f.since, "since", 0, "只显示该时长内的日志，如 24h，默认不限")
+	fs.IntVar(&f.limit, "limit", 100, "最多显示的条数，0 表示不限")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printLogsUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newGTMFlagSet(resource, action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *gtmFlags) {
+	f := &gtmFlags{}
+	fs := flag.NewFlagSet("gtm "+resource+" "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	monitorFlags := func(required string) {
+		fs.StringVar(&f.protocol, "protocol", "", "健康检查协议: HTTP|HTTPS|PING|TCP"+required)
+		fs.IntVar(&f.interval, "interval", 0, "健康检查间隔（秒），默认 60")
+		fs.IntVar(&f.timeout, "timeout", 0, "健康检查超时（毫秒），默认 5000")
+		fs.IntVar(&f.count, "count", 0, "连续失败多少次判定为异常，默认 1")
+		fs.StringVar(&f.extend, "extend", "", `协议相关的 JSON 配置，如 {"port":80,"host":"example.com","path":"/"}`)
+		fs.StringVar(&f.nodes, "nodes", "", "监控节点，逗号分隔的 运营商代码:城市代码")
+	}
+	switch resource + " " + action {
+	case "instance list":
+		fs.StringVar(&f.keyword, "keyword", "", "按实例名称或ID过滤")
+	case "instance show", "pool list":
+		fs.StringVar(&f.instance, "instance", "", "GTM 实例ID (必需)")
+	case "pool show", "pool del":
+		fs.StringVar(&f.pool, "pool", "", "地址池ID (必需)")
+	case "pool add":
+		fs.StringVar(&f.instance, "instance", "", "GTM 实例ID (必需)")
+		fs.StringVar(&f.name, "name", "", "地址池名称 (必需)")
+		fs.StringVar(&f.poolType, "type", "", "地址池类型: IPV4|IPV6|DOMAIN (必需)")
+		fs.StringVar(&f.addrs, "addrs", "", "地址，逗号分隔，可用 =权重 指定权重，如 1.1.1.1,2.2.2.2=3 (必需)")
+		fs.StringVar(&f.lba, "lba", "", "负载均衡策略: ALL_RR|RATIO，默认 ALL_RR")
+		fs.StringVar(&f.mode, "mode", "", "地址模式: SMART|ONLINE|OFFLINE，默认 SMART")
+		fs.StringVar(&f.lines, "lines", "", "地址归属的线路，逗号分隔，默认 default")
+		monitorFlags("，指定后开启健康检查")
+	case "strategy list":
+		fs.StringVar(&f.instance, "instance", "", "GTM 实例ID (必需)")
+		fs.StringVar(&f.mode, "mode", "", "策略类型: GEO|LATENCY，默认实例当前类型")
+	case "strategy add":
+		fs.StringVar(&f.instance, "instance", "", "GTM 实例ID (必需)")
+		fs.StringVar(&f.name, "name", "", "策略名称 (必需)")
+		fs.StringVar(&f.poolType, "type", "", "地址池类型: IPV4|IPV6|DOMAIN (必需)")
+		fs.StringVar(&f.pools, "pools", "", "主地址池ID，逗号分隔，可用 =权重 指定权重 (必需)")
+		fs.StringVar(&f.failoverPools, "failover-pools", "", "备地址池ID，主地址池可用地址不足时切换")
+		fs.StringVar(&f.lines, "lines", "", "请求来源线路，逗号分隔，默认 default")
+		fs.StringVar(&f.lba, "lba", "", "地址池集合的负载均衡策略: ALL_RR|RATIO，默认 ALL_RR")
+		fs.IntVar(&f.minAvailable, "min-available", 0, "最少可用地址数，低于该值时切换到备地址池，默认 1")
+		fs.StringVar(&f.mode, "mode", "", "策略类型: GEO|LATENCY，默认 GEO")
+	case "strategy del":
+		fs.StringVar(&f.strategy, "strategy", "", "策略ID (必需)")
+	case "monitor show", "monitor enable", "monitor disable":
+		fs.StringVar(&f.monitor, "monitor", "", "健康检查ID，见地址池的 MonitorConfigId (必需)")
+	case "monitor update":
+		fs.StringVar(&f.monitor, "monitor", "", "健康检查ID，见地址池的 MonitorConfigId (必需)")
+		monitorFlags("，默认保持不变")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printGTMUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newDNSSECFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *dnssecFlags) {
+	f := &dnssecFlags{}
+	fs := flag.NewFlagSet("dnssec "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.domain, "domain", "", "域名，如 example.com (必需)")
+	switch action {
+	case "status":
+		fs.BoolVar(&f.check, "check", true, "查询父域 DS 记录并与当前密钥比对")
+	case "disable":
+		fs.BoolVar(&f.force, "force", false, "父域仍有 DS 记录时也关闭 DNSSEC")
+	}
+	if action != "enable" {
+		fs.StringVar(&f.nameservers, "nameserver", "", "覆盖要查询的父域权威 DNS，逗号分隔的 host[:port]")
+		fs.StringVar(&f.resolver, "resolver", "", "查询父域 NS 记录及其地址使用的 DNS host[:port]，默认系统解析器")
+		fs.DurationVar(&f.timeout, "timeout", 10*time.Second, "DS 检查的超时时间")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printDNSSECUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newLinesFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *linesFlags) {
+	f := &linesFlags{}
+	fs := flag.NewFlagSet("lines "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.domain, "domain", "", "域名，如 example.com (必需)")
+	switch action {
+	case "add":
+		fs.StringVar(&f.name, "name", "", "自定义线路名称 (必需)")
+		fs.StringVar(&f.ips, "ips", "", "IP 段，逗号分隔，如 1.1.1.0-1.1.1.255,2.2.2.2 (必需)")
+	case "del":
+		fs.StringVar(&f.name, "name", "", "自定义线路名称、代码或ID (必需)")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printLinesUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newZoneFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *zoneFlags) {
+	f := &zoneFlags{}
+	fs := flag.NewFlagSet("zone "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	if action == "bind" {
+		fs.StringVar(&f.zone, "zone", "", "PrivateZone 名称或 Zone ID (必需)")
+		fs.StringVar(&f.vpc, "vpc", "", "VPC ID，逗号分隔 (必需)")
+		fs.StringVar(&f.region, "region", "", "VPC 所在地域，如 cn-hangzhou (必需)")
+		fs.BoolVar(&f.replace, "replace", false, "以 -vpc 替换全部已绑定的 VPC，默认保留已有绑定")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printZoneUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newStatsFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *statsFlags) {
+	f := &statsFlags{}
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.domain, "domain", "", "域名，如 example.com (未指定 -summary 时必需)")
+	fs.StringVar(&f.rr, "rr", "", "只统计该主机记录，如 www")
+	fs.StringVar(&f.start, "start", "", "开始日期 YYYY-MM-DD (必需)")
+	fs.StringVar(&f.end, "end", "", "结束日期 YYYY-MM-DD，默认今天")
+	fs.BoolVar(&f.summary, "summary", false, "按子域名汇总（指定 -domain 时）或按域名汇总，按请求量降序")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty|table|csv")
+	fs.Usage = func() {
+		printStatsUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newExporterFlagSet(stderr io.Writer) (*flag.FlagSet, *exporterFlags) {
+	f := &exporterFlags{}
+	fs := flag.NewFlagSet("exporter", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.domain, "domain", "", "要采集的域名，逗号分隔")
+	fs.StringVar(&f.group, "group", "", "采集该域名分组下的全部域名（启动时解析），代替 -domain")
+	fs.StringVar(&f.listen, "listen", ":9853", "HTTP 监听地址，指标位于 /metrics")
+	fs.DurationVar(&f.interval, "interval", time.Minute, "采集间隔")
+	fs.BoolVar(&f.valueInfo, "value-info", false, "为每条记录输出 alidns_record_value_info 指标")
+	fs.Usage = func() {
+		printExporterUsage(stderr)
+	}
+
+	return fs, f
+}
+
+func newWatchFlagSet(stderr io.Writer) (*flag.FlagSet, *watchFlags) {
+	f := &watchFlags{}
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.domain, "domain", "", "要监视的域名，逗号分隔")
+	fs.StringVar(&f.group, "group", "", "监视该域名分组下的全部域名（启动时解析），代替 -domain")
+	fs.DurationVar(&f.interval, "interval", 30*time.Second, "轮询间隔")
+	fs.BoolVar(&f.initial, "initial", false, "启动时将现有记录全部输出为 added 事件")
+	fs.Usage = func() {
+		printWatchUsage(stderr)
+	}
+
+	return fs, f
+}
+
+func newExternalDNSFlagSet(stderr io.Writer) (*flag.FlagSet, *externalDNSFlags) {
+	f := &externalDNSFlags{}
+	fs := flag.NewFlagSet("external-dns-webhook", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.domain, "domain", "", "交给 external-dns 管理的域名，逗号分隔 (必需)")
+	fs.StringVar(&f.listen, "listen", "127.0.0.1:8888", "HTTP 监听地址")
+	fs.Usage = func() {
+		printExternalDNSUsage(stderr)
+	}
+
+	return fs, f
+}
+
+func newNotifyFlagSet(action string, stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *notifyFlags) {
+	f := &notifyFlags{}
+	fs := flag.NewFlagSet("notify "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	if action == "test" {
+		fs.StringVar(&f.domain, "domain", "example.com", "测试消息中的域名")
+		fs.StringVar(&f.profile, "profile", "", "测试消息的凭据标识，用于检验各通道的 profiles 过滤")
+	}
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printNotifyUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newBackupFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *backupFlags) {
+	f := &backupFlags{}
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.domain, "domain", "", "要备份的主域名")
+	fs.StringVar(&f.group, "group", "", "备份该域名分组下的全部域名")
+	fs.BoolVar(&f.allDomains, "all-domains", false, "备份账号下的全部域名")
+	fs.StringVar(&f.dir, "dir", ".", "快照输出目录")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printBackupUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newRestoreFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *restoreFlags) {
+	f := &restoreFlags{}
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (必需)")
+	fs.StringVar(&f.file, "f", "", "快照文件 (必需)")
+	fs.StringVar(&f.domain, "domain", "", "恢复到该主域名，默认快照中的域名")
+	fs.BoolVar(&f.dryRun, "dry-run", false, "只输出差异，不做修改")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty")
+	fs.Usage = func() {
+		printRestoreUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func newDiffFlagSet(stderr io.Writer, globalOutput OutputFormat) (*flag.FlagSet, *diffFlags) {
+	f := &diffFlags{}
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&f.ak, "ak", "", "Alibaba Cloud Access Key ID (对比线上记录时必需)")
+	fs.StringVar(&f.sk, "sk", "", "Alibaba Cloud Access Key Secret (对比线上记录时必需)")
+	fs.StringVar(&f.domain, "domain", "", "主域名，默认取 -f 文件中的域名")
+	fs.StringVar(&f.file, "f", "", "期望状态: YAML 文件或快照 (必需)")
+	fs.StringVar(&f.from, "from", "", "作为当前状态的快照，默认读取线上记录")
+	fs.StringVar(&f.color, "color", "auto", "文本输出着色: auto|always|never")
+	fs.StringVar(&f.output, "output", string(globalOutput), "output format: json|pretty|text")
+	fs.Usage = func() {
+		printDiffUsage(stderr, globalOutput)
+	}
+
+	return fs, f
+}
+
+func printRootUsage(w io.Writer) {
+	_, _ = fmt.Fprint(w, `用法:
+  alidns [--output json|pretty] [--backend alidns|pvtz] [--api-log] <command> [flags]
+  alidns help [command]
+
+全局参数:
+  --output string
+    	output format: json|pretty (default "pretty")
+  --backend string
+    	记录后端: alidns|pvtz，pvtz 时 add/del/query/update 操作 PrivateZone (default "alidns")
+  --api-log
+    	在标准错误输出每次 API 调用的操作名、耗时与错误码，变更类调用附带请求参数
+  -h, --help
+    	显示帮助
+
+命令:
+  add      添加 DNS 记录
+  del      删除 DNS 记录
+  query    查询 DNS 记录
+  update   修改 DNS 记录
+  acme     ACME DNS-01 挑战记录 (present|cleanup)
+  weight   权重轮询 (enable|disable|set|list)
+  group    域名分组 (list|add|rename|del)
+  domain   域名列表与分组调整 (list|move)
+  history  查看本地变更日志
+  undo     撤销一次变更
+  logs     查看阿里云侧的操作日志
+  stats    查看解析请求量统计
+  lines    解析线路与自定义线路 (list|custom|add|del)
+  dnssec   DNSSEC 状态与 DS 记录 (status|enable|disable)
+  gtm      全局流量管理 (instance|pool|strategy|monitor)
+  zone     PrivateZone 内网解析域 (list|bind)
+  exporter 以 Prometheus exporter 方式输出记录与 API 指标
+  watch    监视记录变化，输出 NDJSON 事件流
+  external-dns-webhook
+           作为 external-dns 的 webhook provider 运行
+  notify   变更通知 (test|flush)
+  backup   备份域名全部记录为快照
+  restore  按快照恢复域名记录
+  diff     对比期望状态与线上记录
+  help     显示帮助
+
+示例:
+  alidns add -ak AK -sk SK -domain example.com -name www -type A -value 1.2.3.4
+  alidns query -ak AK -sk SK -domain example.com --output json
+`)
+
+	_, _ = fmt.Fprintln(w, "子命令参数说明:")
+	printAddUsage(w, OutputPretty)
+	printDelUsage(w, OutputPretty)
+	printQueryUsage(w, OutputPretty)
+	printUpdateUsage(w, OutputPretty)
+	printACMEUsage(w, OutputPretty)
+	printWeightUsage(w, OutputPretty)
+	printGroupUsage(w, OutputPretty)
+	printDomainUsage(w, OutputPretty)
+	printHistoryUsage(w, OutputPretty)
+	printUndoUsage(w, OutputPretty)
+	printLogsUsage(w, OutputPretty)
+	printStatsUsage(w, OutputPretty)
+	printLinesUsage(w, OutputPretty)
+	printDNSSECUsage(w, OutputPretty)
+	printGTMUsage(w, OutputPretty)
+	printZoneUsage(w, OutputPretty)
+	printExporterUsage(w)
+	printWatchUsage(w)
+	printExternalDNSUsage(w)
+	printNotifyUsage(w, OutputPretty)
+	printBackupUsage(w, OutputPretty)
+	printRestoreUsage(w, OutputPretty)
+	printDiffUsage(w, OutputPretty)
+}
+
+func printAddUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns add [flags]
+
+说明:
+  添加 DNS 记录。
+
+参数:
+`)
+	fs, _ := newAddFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns add -ak AK -sk SK -domain example.com -name www -type A -value 1.2.3.4
+  alidns add -ak AK -sk SK -domain example.com -name @ -type TXT -value hello --output json
+  alidns add -ak AK -sk SK -domain example.com -name www -type A -value 1.2.3.4 --wait 2m
+  alidns add -ak AK -sk SK -fqdn www.dev.example.com -type A -value 1.2.3.4
+`)
+}
+
+func printDelUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns del [flags]
+
+说明:
+  删除 DNS 记录。
+
+参数:
+`)
+	fs, _ := newDelFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns del -ak AK -sk SK -domain example.com -name www -type A
+  alidns del -ak AK -sk SK -domain example.com -name _acme-challenge -type TXT -value TOKEN
+  alidns del -ak AK -sk SK -fqdn www.example.com -type A
+`)
+}
+
+func printQueryUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns query [flags]
+
+说明:
+  查询 DNS 记录列表。
+
+参数:
+`)
+	fs, _ := newQueryFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns query -ak AK -sk SK -domain example.com
+  alidns query -ak AK -sk SK -domain example.com --output json
+  alidns query -ak AK -sk SK -fqdn www.example.com
+  alidns query -ak AK -sk SK -domain 例子.中国 --unicode
+  alidns query -ak AK -sk SK -group 电商 -name www
+`)
+}
+
+func printUpdateUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns update [flags]
+
+说明:
+  修改 DNS 记录。只更新指定的字段，其余字段保持当前值；
+  记录已与指定值一致时不发送修改请求，输出中 Unchanged 为 true。
+
+参数:
+`)
+	fs, _ := newUpdateFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns update -ak AK -sk SK -id RECORD_ID -name www -type A -value 1.2.3.4
+  alidns update -ak AK -sk SK -id RECORD_ID -ttl 60
+  alidns update -ak AK -sk SK -id RECORD_ID -name www -type A -value 1.2.3.4 --wait 2m --nameserver 127.0.0.1:5353
+`)
+}
+
+func printACMEUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns acme present|cleanup [flags]
+
+说明:
+  管理 ACME DNS-01 挑战 TXT 记录。自动拆分主域名与主机记录；
+  present 不影响同一主机记录下的其他 TXT 值，并等待权威 DNS 生效；
+  cleanup 仅删除与 -value 完全一致的记录。
+
+参数:
+`)
+	fs, _ := newACMEFlagSet("present", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns acme present -ak AK -sk SK -fqdn _acme-challenge.www.example.com -value TOKEN
+  alidns acme cleanup -ak AK -sk SK -fqdn _acme-challenge.www.example.com -value TOKEN
+`)
+}
+
+func printWeightUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns weight enable|disable [flags]
+  alidns weight set [flags]
+  alidns weight list [flags]
+
+说明:
+  管理同一主机记录下多条记录的权重轮询 (SLB)。
+
+参数 (enable|disable):
+`)
+	fs, _ := newWeightFlagSet("enable", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (set):
+`)
+	fs, _ = newWeightFlagSet("set", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (list):
+`)
+	fs, _ = newWeightFlagSet("list", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns weight enable -ak AK -sk SK -domain example.com -name www -type A
+  alidns weight set -ak AK -sk SK -id RECORD_ID -weight 80
+  alidns weight list -ak AK -sk SK -domain example.com -name www
+`)
+}
+
+func printHistoryUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns history [flags]
+
+说明:
+  查看 add/del/update 写入的本地变更日志，包括变更前的记录状态。
+
+参数:
+`)
+	fs, _ := newHistoryFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns history
+  alidns history -domain example.com -limit 5
+`)
+}
+
+func printUndoUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns undo [flags] [entry-id]
+
+说明:
+  按变更日志执行反向操作，默认撤销最近一次尚未撤销的变更。
+
+参数:
+`)
+	fs, _ := newUndoFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns undo -ak AK -sk SK
+  alidns undo -ak AK -sk SK 20261019T154500-1a2b
+`)
+}
+
+func printGroupUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns group list|add|rename|del [flags]
+
+说明:
+  管理域名分组。
+
+参数 (add):
+`)
+	fs, _ := newGroupFlagSet("add", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (rename):
+`)
+	fs, _ = newGroupFlagSet("rename", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (del):
+`)
+	fs, _ = newGroupFlagSet("del", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns group list -ak AK -sk SK
+  alidns group add -ak AK -sk SK -name 电商
+  alidns group rename -ak AK -sk SK -name 电商 -new-name 零售
+  alidns group del -ak AK -sk SK -name 零售
+`)
+}
+
+func printDomainUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns domain list [flags]
+  alidns domain move [flags]
+
+说明:
+  列出账号下的域名，或将域名移动到其他分组。
+
+参数 (list):
+`)
+	fs, _ := newDomainFlagSet("list", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (move):
+`)
+	fs, _ = newDomainFlagSet("move", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns domain list -ak AK -sk SK -group 电商
+  alidns domain move -ak AK -sk SK -domain example.com -group 电商
+`)
+}
+
+func printLogsUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns logs [flags]
+
+说明:
+  查看阿里云记录的操作日志（包括控制台与其他工具的修改），按时间倒序输出。
+
+参数:
+`)
+	fs, _ := newLogsFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns logs -ak AK -sk SK -domain example.com --since 24h
+  alidns logs -ak AK -sk SK -domain example.com -keyword www -limit 20
+  alidns logs -ak AK -sk SK --since 168h
+  alidns logs -ak AK -sk SK -level domain -domain example.com
+`)
+}
+
+func printGTMUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns gtm instance list|show [flags]
+  alidns gtm pool list|show|add|del [flags]
+  alidns gtm strategy list|add|del [flags]
+  alidns gtm monitor show|enable|disable|update [flags]
+
+说明:
+  管理全局流量管理 (GTM) 实例：地址池、按请求来源选择地址池的访问策略，以及将故障地址摘除的健康检查。
+`)
+	for _, resource := range []string{"instance", "pool", "strategy", "monitor"} {
+		for _, action := range gtmActions[resource] {
+			if action == "disable" {
+				continue
+			}
+			title := action
+			if action == "enable" {
+				title = "enable|disable"
+			}
+			_, _ = fmt.Fprintf(w, "\n参数 (%s %s):\n", resource, title)
+			fs, _ := newGTMFlagSet(resource, action, w, globalOutput)
+			fs.PrintDefaults()
+		}
+	}
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns gtm instance list -ak AK -sk SK
+  alidns gtm pool add -ak AK -sk SK -instance gtm-cn-xxx -name hz -type IPV4 -addrs 1.1.1.1,2.2.2.2 -protocol HTTP -extend '{"port":80,"host":"example.com","path":"/"}' -nodes 465:503,465:504
+  alidns gtm strategy add -ak AK -sk SK -instance gtm-cn-xxx -name cn -type IPV4 -lines default -pools hz-pool-id -failover-pools sh-pool-id
+  alidns gtm monitor disable -ak AK -sk SK -monitor monitor-id
+`)
+}
+
+func printDNSSECUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns dnssec status|enable|disable [flags]
+
+说明:
+  status 输出 DNSSEC 状态与需在注册商处填写的 DS 记录（KeyTag、Algorithm、DigestType、Digest），
+  并查询父域权威 DNS 比对 DS 记录，不一致时退出码为 2；enable/disable 开启或关闭 DNSSEC（仅付费版）。
+  disable 前会确认父域已不再发布 DS 记录，否则验证型解析器将无法解析该域名。
+
+参数 (status):
+`)
+	fs, _ := newDNSSECFlagSet("status", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (enable):
+`)
+	fs, _ = newDNSSECFlagSet("enable", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (disable):
+`)
+	fs, _ = newDNSSECFlagSet("disable", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns dnssec status -ak AK -sk SK -domain example.com
+  alidns dnssec status -ak AK -sk SK -domain example.com -nameserver 127.0.0.1:5353
+  alidns dnssec enable -ak AK -sk SK -domain example.com
+  alidns dnssec disable -ak AK -sk SK -domain example.com
+`)
+}
+
+func printLinesUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns lines [list|custom|add|del] [flags]
+
+说明:
+  list 列出域名支持的解析线路（包括自定义线路），为默认操作；custom 列出自定义线路及其 IP 段；
+  add/del 添加或删除自定义线路。add 与 update 的 -line 取值为这里的线路代码。
+
+参数 (list|custom):
+`)
+	fs, _ := newLinesFlagSet("list", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (add):
+`)
+	fs, _ = newLinesFlagSet("add", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (del):
+`)
+	fs, _ = newLinesFlagSet("del", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns lines -ak AK -sk SK -domain example.com
+  alidns lines custom -ak AK -sk SK -domain example.com
+  alidns lines add -ak AK -sk SK -domain example.com -name 办公网 -ips 1.1.1.0-1.1.1.255,2.2.2.2
+  alidns lines del -ak AK -sk SK -domain example.com -name 办公网
+`)
+}
+
+func printZoneUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns zone <list|bind> [flags]
+
+说明:
+  管理 PrivateZone (pvtz) 内网解析域。list 列出全部 Zone；bind 将 Zone 绑定到 VPC，
+  默认保留已有绑定。Zone 内的记录通过 --backend pvtz 使用 add/del/query/update 管理。
+
+参数 (list):
+`)
+	fs, _ := newZoneFlagSet("list", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (bind):
+`)
+	fs, _ = newZoneFlagSet("bind", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns zone list -ak AK -sk SK
+  alidns zone bind -ak AK -sk SK -zone corp.internal -region cn-hangzhou -vpc vpc-aaa,vpc-bbb
+  alidns --backend pvtz add -ak AK -sk SK -domain corp.internal -name db -type A -value 10.0.0.5
+`)
+}
+
+func printStatsUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns stats [flags]
+
+说明:
+  查看域名或主机记录的解析请求量。table 输出附带请求量走势的字符迷你图；
+  -summary 可找出长期无请求、可安全删除的记录，以及需要调低 TTL 的热点记录。
+
+参数:
+`)
+	fs, _ := newStatsFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns stats -ak AK -sk SK -domain example.com -start 2026-10-01 --output table
+  alidns stats -ak AK -sk SK -domain example.com -rr www -start 2026-10-01 -end 2026-10-07 --output csv
+  alidns stats -ak AK -sk SK -domain example.com -start 2026-10-01 -summary --output table
+  alidns stats -ak AK -sk SK -start 2026-10-01 -summary
+`)
+}
+
+func printExporterUsage(w io.Writer) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns exporter [flags]
+
+说明:
+  以 Prometheus exporter 方式运行，定期读取各域名的全部记录，在 /metrics 输出：
+  alidns_records                         按类型、线路、状态统计的记录数，消失的组合保留为 0
+  alidns_record_value_info               每条记录一个序列，值为 1 (需 -value-info)
+  alidns_last_sync_timestamp_seconds     最近一次成功采集的时间
+  alidns_sync_errors_total               采集失败次数
+  alidns_api_request_duration_seconds    API 调用耗时
+  alidns_api_errors_total                API 错误次数，按错误码 (如 Throttling.User) 区分
+  收到 SIGINT/SIGTERM 后退出。
+
+参数:
+`)
+	fs, _ := newExporterFlagSet(w)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns exporter -ak AK -sk SK -domain example.com,example.org -listen :9853 -interval 5m
+  alidns exporter -ak AK -sk SK -group 电商
+`)
+}
+
+func printWatchUsage(w io.Writer) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns watch [flags]
+
+说明:
+  定期读取域名的全部记录，与上一次对比，每个变化输出一行 JSON (NDJSON) 到标准输出：
+  Event 为 added、removed 或 modified；added/removed 带 Record，modified 带 Before、After 与变化的 Fields。
+  记录按 ID 对比，控制台直接修改的值、TTL、状态等都会被发现。首次读取作为基线，不输出事件。
+  读取失败时在标准错误输出警告并在下一轮重试；收到 SIGINT/SIGTERM 后退出。
+
+参数:
+`)
+	fs, _ := newWatchFlagSet(w)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns watch -ak AK -sk SK -domain example.com -interval 30s
+  alidns watch -ak AK -sk SK -domain example.com,example.org | vector --config watch.toml
+  alidns watch -ak AK -sk SK -group 电商
+`)
+}
+
+func printExternalDNSUsage(w io.Writer) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns external-dns-webhook [flags]
+
+说明:
+  实现 external-dns 的 webhook provider 协议 (/、/records、/adjustendpoints、/healthz)，
+  通常作为 external-dns 的 sidecar 运行，external-dns 使用 --provider=webhook。
+  每个 Endpoint 的各个目标对应同一 RR、类型、线路下的一条记录：
+  - 线路由 provider-specific 属性 webhook/alidns-line 指定，默认 default；
+  - 带 setIdentifier 的加权记录集将标识保存在记录备注 (external-dns-set:<id>)，
+    权重 (1-100) 由 webhook/alidns-weight 指定并开启权重轮询；
+  - TXT 所有权记录按 external-dns 的格式加引号返回，MX 目标为 "<优先级> <主机>"。
+  收到 SIGINT/SIGTERM 后退出。
+
+参数:
+`)
+	fs, _ := newExternalDNSFlagSet(w)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns external-dns-webhook -ak AK -sk SK -domain example.com,example.org
+`)
+}
+
+func printNotifyUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns notify <test|flush> [flags]
+
+说明:
+  add、update、del、undo 与 restore 的每次变更（无论成功或失败）都会发送到状态目录下
+  notify.yaml 中配置的通知通道：webhook (HMAC-SHA256 签名)、slack、dingtalk、feishu。
+  重试后仍失败的通知保存在状态目录的 outbox 中，下次变更时或执行 flush 时重发。
+  test 向所有通道发送一条测试消息；flush 立即重发 outbox 中的通知。
+
+参数 (test):
+`)
+	fs, _ := newNotifyFlagSet("test", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+参数 (flush):
+`)
+	fs, _ = newNotifyFlagSet("flush", w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns notify test
+  alidns notify flush
+`)
+}
+
+func printBackupUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns backup [flags]
+
+说明:
+  将域名的全部记录（包括已暂停的记录）写入带版本号与时间戳的快照文件。
+
+参数:
+`)
+	fs, _ := newBackupFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns backup -ak AK -sk SK -domain example.com -dir ./backups
+  alidns backup -ak AK -sk SK --all-domains -dir ./backups
+  alidns backup -ak AK -sk SK -group 电商 -dir ./backups
+`)
+}
+
+func printRestoreUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns restore [flags]
+
+说明:
+  对比快照与当前记录，仅重放差异：删除快照中没有的记录，添加缺失的记录，
+  并恢复 TTL、优先级与启用状态。
+
+参数:
+`)
+	fs, _ := newRestoreFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns restore -ak AK -sk SK -f backups/example.com-20261019T154500Z.json --dry-run
+  alidns restore -ak AK -sk SK -f backups/example.com-20261019T154500Z.json
+`)
+}
+
+func printDiffUsage(w io.Writer, globalOutput OutputFormat) {
+	_, _ = fmt.Fprint(w, `
+用法:
+  alidns diff [flags]
+
+说明:
+  对比期望状态（YAML 或快照）与线上记录，或两个快照之间的差异。
+  无差异时退出码为 0，存在差异时为 2，其他错误为 1。
+
+参数:
+`)
+	fs, _ := newDiffFlagSet(w, globalOutput)
+	fs.PrintDefaults()
+	_, _ = fmt.Fprint(w, `
+示例:
+  alidns diff -ak AK -sk SK -domain example.com -f desired.yaml --output text
+  alidns diff -from example.com-20261001T000000Z.json -f example.com-20261019T000000Z.json
 `)
 }
 
@@ -262,6 +1482,44 @@ func printCommandUsage(command string, w io.Writer, globalOutput OutputFormat) e
 		printQueryUsage(w, globalOutput)
 	case "update":
 		printUpdateUsage(w, globalOutput)
+	case "acme":
+		printACMEUsage(w, globalOutput)
+	case "weight":
+		printWeightUsage(w, globalOutput)
+	case "history":
+		printHistoryUsage(w, globalOutput)
+	case "undo":
+		printUndoUsage(w, globalOutput)
+	case "logs":
+		printLogsUsage(w, globalOutput)
+	case "stats":
+		printStatsUsage(w, globalOutput)
+	case "lines":
+		printLinesUsage(w, globalOutput)
+	case "dnssec":
+		printDNSSECUsage(w, globalOutput)
+	case "gtm":
+		printGTMUsage(w, globalOutput)
+	case "zone":
+		printZoneUsage(w, globalOutput)
+	case "exporter":
+		printExporterUsage(w)
+	case "watch":
+		printWatchUsage(w)
+	case "external-dns-webhook":
+		printExternalDNSUsage(w)
+	case "notify":
+		printNotifyUsage(w, globalOutput)
+	case "backup":
+		printBackupUsage(w, globalOutput)
+	case "restore":
+		printRestoreUsage(w, globalOutput)
+	case "group":
+		printGroupUsage(w, globalOutput)
+	case "domain":
+		printDomainUsage(w, globalOutput)
+	case "diff":
+		printDiffUsage(w, globalOutput)
 	default:
 		return fmt.Errorf("unknown help command %q", command)
 	}
@@ -21,6 +21,18 @@ type requiredArg struct {
 	value string
 }
 
+// countSet counts the options that were given, for mutually exclusive
+// flags.
+func countSet(set ...bool) int {
+	n := 0
+	for _, s := range set {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
 func requireAll(args ...requiredArg) error {
 	missing := make([]string, 0)
 	for _, arg := range args {
@@ -0,0 +1,81 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/dnscheck"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+)
+
+type addOutput struct {
+	*alidns20150109.AddDomainRecordResponseBody
+	Propagation *dnscheck.Report `json:",omitempty"`
+}
+
+type updateOutput struct {
+	*alidns20150109.UpdateDomainRecordResponseBody
+	// Unchanged is set when the record already matched and no update was
+	// sent.
+	Unchanged   bool             `json:",omitempty"`
+	Propagation *dnscheck.Report `json:",omitempty"`
+}
+
+func (w waitFlags) checker() *dnscheck.Checker {
+	return newChecker(w.resolver, w.nameservers)
+}
+
+// newChecker builds a checker from the -resolver and -nameserver flags.
+func newChecker(resolver, nameservers string) *dnscheck.Checker {
+	checker := &dnscheck.Checker{Resolver: strings.TrimSpace(resolver)}
+	for _, ns := range strings.Split(nameservers, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			checker.Nameservers = append(checker.Nameservers, ns)
+		}
+	}
+	return checker
+}
+
+// waitPropagation blocks until every authoritative nameserver serves want or
+// the -wait timeout expires. The report is returned even on timeout so that
+// the per-nameserver state can still be printed.
+func waitPropagation(ctx context.Context, w waitFlags, want dnscheck.Expect) (*dnscheck.Report, error) {
+	var err error
+	if want.DomainName, err = alidns.ToASCII(want.DomainName); err != nil {
+		return nil, err
+	}
+	if want.FQDN, err = alidns.ToASCII(want.FQDN); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.wait)
+	defer cancel()
+
+	report, err := w.checker().Wait(ctx, want)
+	if err != nil {
+		return report, fmt.Errorf("等待 DNS 生效失败: %w", err)
+	}
+	return report, nil
+}
+
+// printWithWaitError prints v and then reports waitErr, so that a timed out
+// propagation check still shows which nameservers lag behind.
+func printWithWaitError(deps Deps, v any, output OutputFormat, waitErr error) error {
+	if err := Print(deps.Stdout, v, output); err != nil {
+		return err
+	}
+	return waitErr
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+func runWatch(ctx context.Context, args []string, deps Deps) error {
+	fs, f := newWatchFlagSet(deps.Stderr)
+	helpShown, err := parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	if err := requireAll(
+		requiredArg{name: "-ak", value: f.ak},
+		requiredArg{name: "-sk", value: f.sk},
+	); err != nil {
+		return err
+	}
+	if countSet(f.domain != "", f.group != "") != 1 {
+		return fmt.Errorf("错误: 需要且只能指定 -domain 或 -group 之一")
+	}
+	if f.interval <= 0 {
+		return fmt.Errorf("错误: -interval 必须大于 0")
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+	domains, err := listedDomains(ctx, svc, f.domain, f.group)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return watchDomains(ctx, deps, svc, domains, f.interval, f.initial)
+}
+
+// watchDomains reads every domain each interval and writes the differences
+// to the previous read as NDJSON until ctx is done. The first read of a
+// domain is the baseline and only reported when initial is set. A failed
+// read is a warning; the domain is compared again on the next tick.
+func watchDomains(ctx context.Context, deps Deps, svc *alidns.Service, domains []string, interval time.Duration, initial bool) error {
+	enc := json.NewEncoder(deps.Stdout)
+	last := map[string][]*alidns.Record{}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, domain := range domains {
+			records, err := svc.Records(ctx, domain)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				_, _ = fmt.Fprintf(deps.Stderr, "警告: 读取 %s 失败: %v\n", domain, err)
+				continue
+			}
+			before, seen := last[domain]
+			last[domain] = records
+			if !seen && !initial {
+				continue
+			}
+			now := time.Now().UTC()
+			for _, event := range alidns.RecordEvents(domain, before, records) {
+				event.Time = now
+				if err := enc.Encode(event); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// sequenceAPI answers each DescribeDomainRecords call with the next read and
+// cancels the watch after the last one.
+type sequenceAPI struct {
+	*fakeDNSAPI
+	reads  [][]*alidns.Record
+	cancel context.CancelFunc
+}
+
+func (s *sequenceAPI) DescribeDomainRecords(_ context.Context, _ *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns.Record, error) {
+	records := s.reads[0]
+	if len(s.reads) > 1 {
+		s.reads = s.reads[1:]
+	} else {
+		s.cancel()
+	}
+	return records, nil
+}
+
+func TestWatchDomainsEmitsChangesAsNDJSON(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	www := &alidns.Record{RecordId: tea.String("1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.2.3.4")}
+	edited := &alidns.Record{RecordId: tea.String("1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("5.6.7.8")}
+	api := &sequenceAPI{
+		fakeDNSAPI: &fakeDNSAPI{},
+		reads:      [][]*alidns.Record{{www}, {www}, {edited}},
+		cancel:     cancel,
+	}
+	stdout := &bytes.Buffer{}
+	deps := Deps{Stdout: stdout, Stderr: &bytes.Buffer{}}
+
+	if err := watchDomains(ctx, deps, alidns.NewService(api), []string{"example.com"}, time.Millisecond, false); err != nil {
+		t.Fatalf("watch returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one event, got %q", stdout.String())
+	}
+	var event alidns.RecordEvent
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatalf("event is not JSON: %v", err)
+	}
+	if event.Event != alidns.EventModified || event.Domain != "example.com" || tea.StringValue(event.Before.Value) != "1.2.3.4" || tea.StringValue(event.After.Value) != "5.6.7.8" || event.Time.IsZero() {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
+
+func TestWatchTakesDomainsFromGroup(t *testing.T) {
+	api := &fakeDNSAPI{
+		groupResp:  []*alidns20150109.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup{{GroupId: tea.String("g-1"), GroupName: tea.String("retail")}},
+		domainResp: []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain{{DomainName: tea.String("a.com")}, {DomainName: tea.String("b.com")}},
+	}
+	domains, err := listedDomains(context.Background(), alidns.NewService(api), "", "retail")
+	if err != nil {
+		t.Fatalf("listedDomains returned error: %v", err)
+	}
+	if strings.Join(domains, ",") != "a.com,b.com" {
+		t.Fatalf("unexpected domains: %v", domains)
+	}
+
+	for _, command := range []string{"watch", "exporter"} {
+		err := Run([]string{command, "-ak", "ak", "-sk", "sk", "-domain", "a.com", "-group", "retail"}, Deps{
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+			NewAPI: func(_, _ string) (alidns.DNSAPI, error) { return api, nil },
+		})
+		if err == nil || !strings.Contains(err.Error(), "-domain 或 -group") {
+			t.Fatalf("%s: expected -domain and -group to be exclusive, got: %v", command, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/internal/journal"
+	"github.com/Joey-Kot/alidns/internal/notify"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func runWeight(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	action, args, helpShown, err := parseAction("weight", args, func() {
+		printWeightUsage(deps.Stderr, globalOutput)
+	}, "enable", "disable", "set", "list")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newWeightFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	required := []requiredArg{
+		{name: "-ak", value: f.ak},
+		{name: "-sk", value: f.sk},
+	}
+	switch action {
+	case "enable", "disable":
+		required = append(required, requiredArg{name: "-type", value: f.rType})
+	case "set":
+		// -weight has no default so that a forgotten flag does not move
+		// traffic.
+		weight := ""
+		if flagPassed(fs, "weight") {
+			weight = fs.Lookup("weight").Value.String()
+		}
+		required = append(required, requiredArg{name: "-id", value: f.recordID}, requiredArg{name: "-weight", value: weight})
+	}
+	if err := requireAll(required...); err != nil {
+		return err
+	}
+	if action != "set" {
+		if err := requireTarget(f.fqdn, f.domain, f.name, action != "list"); err != nil {
+			return err
+		}
+	}
+	if action == "set" && (f.weight < 1 || f.weight > 100) {
+		return fmt.Errorf("错误: -weight 必须在 1-100 之间")
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	api, err := deps.NewAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 Alidns Client 失败: %w", err)
+	}
+	svc := alidns.NewService(api)
+	if f.fqdn != "" {
+		if f.domain, f.name, err = resolveFQDN(ctx, deps, svc, f.ak, f.fqdn); err != nil {
+			return err
+		}
+	}
+
+	var resp any
+	switch action {
+	case "enable", "disable":
+		in := alidns.SLBStatusInput{
+			DomainName: f.domain,
+			Name:       f.name,
+			Type:       f.rType,
+			Line:       f.line,
+			Open:       action == "enable",
+		}
+		var status *alidns20150109.SetDNSSLBStatusResponseBody
+		status, err = svc.SetSLBStatus(ctx, in)
+		ev := &notify.Event{Operation: journal.OpUpdate, Domain: f.domain, Record: recordSummary(f.domain, f.name, f.rType, ""), After: in}
+		if status != nil {
+			ev.RequestId = tea.StringValue(status.RequestId)
+		}
+		reportChange(ctx, deps, f.ak, ev, err)
+		resp = status
+	case "set":
+		in := alidns.WeightInput{RecordID: f.recordID, Weight: int32(f.weight)}
+		ev := &notify.Event{Operation: journal.OpUpdate, Record: f.recordID, After: in}
+		if deps.notifying() {
+			// The message names the record and its previous weight; a
+			// failed lookup only makes it less detailed.
+			if info, infoErr := svc.RecordInfo(ctx, f.recordID); infoErr == nil && info != nil {
+				ev.Domain = tea.StringValue(info.DomainName)
+				ev.Record = recordSummary(ev.Domain, tea.StringValue(info.RR), tea.StringValue(info.Type), tea.StringValue(info.Value))
+				ev.Before = []*alidns.Record{alidns.RecordFromInfo(info)}
+			}
+		}
+		var weight *alidns20150109.UpdateDNSSLBWeightResponseBody
+		weight, err = svc.SetWeight(ctx, in)
+		if weight != nil {
+			ev.RequestId = tea.StringValue(weight.RequestId)
+		}
+		reportChange(ctx, deps, f.ak, ev, err)
+		resp = weight
+	case "list":
+		resp, err = svc.Weights(ctx, alidns.WeightsInput{DomainName: f.domain, Name: f.name})
+	}
+	if err != nil {
+		return err
+	}
+
+	return Print(deps.Stdout, resp, output)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+func runZone(ctx context.Context, args []string, globalOutput OutputFormat, deps Deps) error {
+	action, args, helpShown, err := parseAction("zone", args, func() {
+		printZoneUsage(deps.Stderr, globalOutput)
+	}, "list", "bind")
+	if err != nil || helpShown {
+		return err
+	}
+
+	fs, f := newZoneFlagSet(action, deps.Stderr, globalOutput)
+	helpShown, err = parseFlagSet(fs, args)
+	if err != nil {
+		return err
+	}
+	if helpShown {
+		return nil
+	}
+
+	required := []requiredArg{
+		{name: "-ak", value: f.ak},
+		{name: "-sk", value: f.sk},
+	}
+	if action == "bind" {
+		required = append(required,
+			requiredArg{name: "-zone", value: f.zone},
+			requiredArg{name: "-vpc", value: f.vpc},
+			requiredArg{name: "-region", value: f.region},
+		)
+	}
+	if err := requireAll(required...); err != nil {
+		return err
+	}
+
+	output, err := ParseOutputFormat(f.output)
+	if err != nil {
+		return err
+	}
+
+	if deps.NewPvtzAPI == nil {
+		return fmt.Errorf("invalid deps: NewPvtzAPI is nil")
+	}
+	api, err := deps.NewPvtzAPI(f.ak, f.sk)
+	if err != nil {
+		return fmt.Errorf("创建 PrivateZone Client 失败: %w", err)
+	}
+	svc := alidns.NewZoneService(api)
+
+	switch action {
+	case "list":
+		zones, err := svc.Zones(ctx)
+		if err != nil {
+			return err
+		}
+		return Print(deps.Stdout, zones, output)
+	default:
+		in := alidns.ZoneBindInput{Zone: f.zone, Replace: f.replace}
+		for _, vpc := range splitList(f.vpc) {
+			in.Vpcs = append(in.Vpcs, alidns.PvtzVpc{RegionId: f.region, VpcId: vpc})
+		}
+		resp, err := svc.Bind(ctx, in)
+		if err != nil {
+			return err
+		}
+		return Print(deps.Stdout, resp, output)
+	}
+}
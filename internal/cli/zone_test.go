@@ -0,0 +1,107 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type fakePvtzAPI struct {
+	*fakeDNSAPI
+
+	zones   []*alidns.PvtzZone
+	info    *alidns.PvtzZone
+	bindReq *alidns.BindZoneVpcRequest
+}
+
+func (f *fakePvtzAPI) BindZoneVpc(_ context.Context, req *alidns.BindZoneVpcRequest) (*alidns.BindZoneVpcResponseBody, error) {
+	f.bindReq = req
+	return &alidns.BindZoneVpcResponseBody{RequestId: "req-bind"}, nil
+}
+
+func (f *fakePvtzAPI) DescribeZoneInfo(_ context.Context, _ string) (*alidns.PvtzZone, error) {
+	return f.info, nil
+}
+
+func (f *fakePvtzAPI) DescribeZones(_ context.Context) ([]*alidns.PvtzZone, error) {
+	return f.zones, nil
+}
+
+func pvtzDeps(api *fakePvtzAPI, stdout *bytes.Buffer) Deps {
+	return Deps{
+		Stdout: stdout,
+		Stderr: &bytes.Buffer{},
+		NewAPI: func(_, _ string) (alidns.DNSAPI, error) {
+			return nil, errors.New("alidns backend should not be used")
+		},
+		NewPvtzAPI: func(_, _ string) (alidns.PvtzAPI, error) { return api, nil },
+	}
+}
+
+func TestRunBackendPvtzRoutesRecordCommands(t *testing.T) {
+	api := &fakePvtzAPI{fakeDNSAPI: &fakeDNSAPI{
+		addResp: &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("101"), RequestId: tea.String("req-add")},
+	}}
+	deps := pvtzDeps(api, &bytes.Buffer{})
+	deps.StateDir = t.TempDir()
+
+	if err := Run([]string{"--backend", "pvtz", "add", "-ak", "ak", "-sk", "sk", "-domain", "corp.internal", "-name", "db", "-type", "A", "-value", "10.0.0.5"}, deps); err != nil {
+		t.Fatalf("add returned error: %v", err)
+	}
+	if !api.addCalled || tea.StringValue(api.addReq.DomainName) != "corp.internal" {
+		t.Fatalf("add should go to the pvtz backend: %+v", api.addReq)
+	}
+
+	err := Run([]string{"undo", "-ak", "ak", "-sk", "sk"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "--backend pvtz") {
+		t.Fatalf("undo without --backend pvtz should be refused, got: %v", err)
+	}
+
+	err = Run([]string{"--backend", "pvtz", "add", "-ak", "ak", "-sk", "sk", "-domain", "corp.internal", "-name", "db", "-type", "A", "-value", "10.0.0.5", "-line", "telecom"}, deps)
+	if err == nil || !strings.Contains(err.Error(), "pvtz") {
+		t.Fatalf("expected lines to be rejected on pvtz, got: %v", err)
+	}
+
+	if err := Run([]string{"--backend", "route53", "query"}, deps); err == nil || !strings.Contains(err.Error(), "unsupported backend") {
+		t.Fatalf("expected unsupported backend error, got: %v", err)
+	}
+}
+
+func TestRunZoneBindMergesVpcs(t *testing.T) {
+	api := &fakePvtzAPI{
+		fakeDNSAPI: &fakeDNSAPI{},
+		zones:      []*alidns.PvtzZone{{ZoneId: "z-1", ZoneName: "corp.internal"}},
+		info: &alidns.PvtzZone{ZoneId: "z-1", ZoneName: "corp.internal", Vpcs: []alidns.PvtzVpc{
+			{RegionId: "cn-shanghai", VpcId: "vpc-old"},
+		}},
+	}
+	stdout := &bytes.Buffer{}
+	err := Run([]string{"--output", "json", "zone", "bind", "-ak", "ak", "-sk", "sk",
+		"-zone", "corp.internal", "-region", "cn-hangzhou", "-vpc", "vpc-a, vpc-b"}, pvtzDeps(api, stdout))
+	if err != nil {
+		t.Fatalf("zone bind returned error: %v", err)
+	}
+	if req := api.bindReq; req == nil || req.ZoneId != "z-1" || len(req.Vpcs) != 3 || req.Vpcs[2].VpcId != "vpc-b" || req.Vpcs[2].RegionId != "cn-hangzhou" {
+		t.Fatalf("unexpected bind request: %+v", api.bindReq)
+	}
+	if got := stdout.String(); !strings.Contains(got, `"VpcId":"vpc-old"`) || !strings.Contains(got, `"RequestId":"req-bind"`) {
+		t.Fatalf("unexpected output: %s", got)
+	}
+}
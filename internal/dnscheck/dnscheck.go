@@ -0,0 +1,236 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package dnscheck asks a zone's authoritative nameservers directly whether
+// a record change is already being served.
+package dnscheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultQueryTimeout = 5 * time.Second
+	defaultInterval     = 5 * time.Second
+)
+
+// Checker polls authoritative nameservers over DNS. The zero value looks up
+// the zone's NS records with the system resolver.
+type Checker struct {
+	// Resolver is the host:port used to look up NS records and nameserver
+	// addresses. Empty means the system resolver.
+	Resolver string
+	// Nameservers replaces the zone's NS records with fixed servers
+	// (host or host:port).
+	Nameservers []string
+	// Timeout bounds a single query. Zero means 5s.
+	Timeout time.Duration
+	// Interval is the pause between polling rounds. Zero means 5s.
+	Interval time.Duration
+}
+
+// Expect describes the record that should become visible.
+type Expect struct {
+	DomainName string
+	FQDN       string
+	Type       string
+	Value      string
+}
+
+// Report is the propagation state of one record across all nameservers.
+type Report struct {
+	FQDN        string
+	Type        string
+	Value       string
+	Synced      bool
+	Nameservers []*NameserverStatus
+}
+
+type NameserverStatus struct {
+	Nameserver string
+	Address    string
+	Synced     bool
+	Answers    []string
+	Error      string `json:",omitempty"`
+}
+
+// Wait polls every nameserver until each one answers with the expected
+// value or ctx is done. The report is returned in both cases.
+func (c *Checker) Wait(ctx context.Context, want Expect) (*Report, error) {
+	qtype, err := parseType(want.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	servers, err := c.nameservers(ctx, want.DomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		FQDN:        want.FQDN,
+		Type:        strings.ToUpper(want.Type),
+		Value:       want.Value,
+		Nameservers: servers,
+	}
+	expected := normalize(qtype, want.Value)
+	resolver := c.resolver()
+
+	ticker := time.NewTicker(c.interval())
+	defer ticker.Stop()
+	for {
+		report.Synced = true
+		for _, ns := range report.Nameservers {
+			if ns.Synced {
+				continue
+			}
+			if ns.Address == "" {
+				// The first lookup may have hit a transient resolver error;
+				// retry it instead of waiting out ctx.
+				address, err := resolveAddress(ctx, resolver, ns.Nameserver)
+				if err != nil {
+					ns.Error = err.Error()
+					report.Synced = false
+					continue
+				}
+				ns.Address = address
+			}
+			answers, err := c.query(ctx, ns.Address, want.FQDN, qtype)
+			if err != nil {
+				ns.Error = err.Error()
+				report.Synced = false
+				continue
+			}
+			ns.Error = ""
+			ns.Answers = answers
+			ns.Synced = slices.ContainsFunc(answers, func(v string) bool {
+				return normalize(qtype, v) == expected
+			})
+			if !ns.Synced {
+				report.Synced = false
+			}
+		}
+		if report.Synced {
+			return report, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return report, fmt.Errorf("%s %s not visible on %s: %w", report.Type, want.FQDN, strings.Join(report.pending(), ", "), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Report) pending() []string {
+	names := make([]string, 0, len(r.Nameservers))
+	for _, ns := range r.Nameservers {
+		if !ns.Synced {
+			names = append(names, ns.Nameserver)
+		}
+	}
+	return names
+}
+
+// nameservers returns the servers to poll, resolving the zone's NS records
+// unless they were overridden.
+func (c *Checker) nameservers(ctx context.Context, domainName string) ([]*NameserverStatus, error) {
+	resolver := c.resolver()
+
+	hosts := c.Nameservers
+	if len(hosts) == 0 {
+		records, err := resolver.LookupNS(ctx, domainName)
+		if err != nil {
+			return nil, fmt.Errorf("lookup NS of %s: %w", domainName, err)
+		}
+		for _, ns := range records {
+			hosts = append(hosts, strings.TrimSuffix(ns.Host, "."))
+		}
+		if len(hosts) == 0 {
+			return nil, fmt.Errorf("no NS records found for %s", domainName)
+		}
+	}
+
+	servers := make([]*NameserverStatus, 0, len(hosts))
+	for _, host := range hosts {
+		ns := &NameserverStatus{Nameserver: host, Answers: []string{}}
+		address, err := resolveAddress(ctx, resolver, host)
+		if err != nil {
+			ns.Error = err.Error()
+		}
+		ns.Address = address
+		servers = append(servers, ns)
+	}
+	return servers, nil
+}
+
+// resolveAddress turns host or host:port into ip:port, preferring IPv4.
+func resolveAddress(ctx context.Context, resolver *net.Resolver, hostport string) (string, error) {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host, port = hostport, "53"
+	}
+	if net.ParseIP(host) != nil {
+		return net.JoinHostPort(host, port), nil
+	}
+
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return "", fmt.Errorf("lookup %s: %w", host, err)
+	}
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("no address found for %s", host)
+	}
+	address := addrs[0]
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() != nil {
+			address = addr
+			break
+		}
+	}
+	return net.JoinHostPort(address, port), nil
+}
+
+func (c *Checker) resolver() *net.Resolver {
+	if c.Resolver == "" {
+		return net.DefaultResolver
+	}
+	address := c.Resolver
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
+
+func (c *Checker) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return defaultQueryTimeout
+	}
+	return c.Timeout
+}
+
+func (c *Checker) interval() time.Duration {
+	if c.Interval <= 0 {
+		return defaultInterval
+	}
+	return c.Interval
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package dnscheck
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testServer is a minimal authoritative server answering from a fixed
+// table over UDP and TCP on the same port.
+type testServer struct {
+	addr string
+
+	mu       sync.Mutex
+	records  map[string][]dnsmessage.ResourceBody
+	truncate bool
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen tcp: %v", err)
+	}
+	udp, err := net.ListenPacket("udp", tcp.Addr().String())
+	if err != nil {
+		tcp.Close()
+		t.Fatalf("listen udp: %v", err)
+	}
+	t.Cleanup(func() {
+		tcp.Close()
+		udp.Close()
+	})
+
+	s := &testServer{addr: tcp.Addr().String(), records: map[string][]dnsmessage.ResourceBody{}}
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, peer, err := udp.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := s.answer(buf[:n], true); resp != nil {
+				_, _ = udp.WriteTo(resp, peer)
+			}
+		}
+	}()
+	go func() {
+		for {
+			conn, err := tcp.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var length [2]byte
+				if _, err := io.ReadFull(conn, length[:]); err != nil {
+					return
+				}
+				req := make([]byte, binary.BigEndian.Uint16(length[:]))
+				if _, err := io.ReadFull(conn, req); err != nil {
+					return
+				}
+				resp := s.answer(req, false)
+				_, _ = conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(resp))), resp...))
+			}()
+		}
+	}()
+	return s
+}
+
+func (s *testServer) set(name string, qtype dnsmessage.Type, bodies ...dnsmessage.ResourceBody) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[name+"/"+qtype.String()] = bodies
+}
+
+func (s *testServer) answer(raw []byte, udp bool) []byte {
+	var req dnsmessage.Message
+	if err := req.Unpack(raw); err != nil || len(req.Questions) != 1 {
+		return nil
+	}
+	q := req.Questions[0]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: req.Header.ID, Response: true, Authoritative: true},
+		Questions: req.Questions,
+	}
+	if udp && s.truncate {
+		resp.Header.Truncated = true
+	} else {
+		for _, body := range s.records[strings.ToLower(q.Name.String())+"/"+q.Type.String()] {
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 60},
+				Body:   body,
+			})
+		}
+	}
+	packed, err := resp.Pack()
+	if err != nil {
+		return nil
+	}
+	return packed
+}
+
+func TestWaitReportsEachNameserver(t *testing.T) {
+	synced := newTestServer(t)
+	synced.set("www.example.com.", dnsmessage.TypeA, &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}})
+	stale := newTestServer(t)
+	stale.set("www.example.com.", dnsmessage.TypeA, &dnsmessage.AResource{A: [4]byte{5, 6, 7, 8}})
+
+	checker := &Checker{Nameservers: []string{synced.addr, stale.addr}, Interval: 10 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	report, err := checker.Wait(ctx, Expect{DomainName: "example.com", FQDN: "www.example.com", Type: "A", Value: "1.2.3.4"})
+	if err == nil {
+		t.Fatal("expected timeout while one nameserver is stale")
+	}
+	if report == nil || report.Synced || len(report.Nameservers) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if !report.Nameservers[0].Synced || report.Nameservers[1].Synced {
+		t.Fatalf("unexpected nameserver states: %+v %+v", report.Nameservers[0], report.Nameservers[1])
+	}
+	if got := report.Nameservers[1].Answers; len(got) != 1 || got[0] != "5.6.7.8" {
+		t.Fatalf("unexpected stale answers: %v", got)
+	}
+}
+
+func TestWaitSucceedsOnceServed(t *testing.T) {
+	server := newTestServer(t)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		server.set("_acme-challenge.example.com.", dnsmessage.TypeTXT, &dnsmessage.TXTResource{TXT: []string{"token"}})
+	}()
+
+	checker := &Checker{Nameservers: []string{server.addr}, Interval: 10 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	report, err := checker.Wait(ctx, Expect{DomainName: "example.com", FQDN: "_acme-challenge.example.com", Type: "TXT", Value: `"token"`})
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if !report.Synced || !report.Nameservers[0].Synced {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestWaitRetriesFailedNameserverLookup(t *testing.T) {
+	server := newTestServer(t)
+	server.set("www.example.com.", dnsmessage.TypeA, &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}})
+	_, port, _ := net.SplitHostPort(server.addr)
+
+	// The resolver knows no address for the nameserver until later.
+	resolver := newTestServer(t)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		resolver.set("ns1.example.test.", dnsmessage.TypeA, &dnsmessage.AResource{A: [4]byte{127, 0, 0, 1}})
+	}()
+
+	checker := &Checker{
+		Resolver:    resolver.addr,
+		Nameservers: []string{net.JoinHostPort("ns1.example.test.", port)},
+		Interval:    10 * time.Millisecond,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	report, err := checker.Wait(ctx, Expect{DomainName: "example.com", FQDN: "www.example.com", Type: "A", Value: "1.2.3.4"})
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if ns := report.Nameservers[0]; !ns.Synced || ns.Address != server.addr || ns.Error != "" {
+		t.Fatalf("unexpected nameserver state: %+v", ns)
+	}
+}
+
+func TestQueryFallsBackToTCPWhenTruncated(t *testing.T) {
+	server := newTestServer(t)
+	server.truncate = true
+	server.set("example.com.", dnsmessage.TypeMX, &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx.example.com.")})
+
+	answers, err := (&Checker{}).Query(context.Background(), server.addr, "example.com", "MX")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(answers) != 1 || answers[0] != "mx.example.com" {
+		t.Fatalf("unexpected answers: %v", answers)
+	}
+}
+
+func TestWaitRejectsUnverifiableType(t *testing.T) {
+	_, err := (&Checker{Nameservers: []string{"127.0.0.1:1"}}).Wait(context.Background(), Expect{FQDN: "www.example.com", Type: "REDIRECT_URL", Value: "https://example.net"})
+	if err == nil {
+		t.Fatal("expected error for a type that has no DNS representation")
+	}
+}
+
+func TestCheckDSComparesParentAnswers(t *testing.T) {
+	digest := []byte{0xC1, 0xA0, 0x42, 0x4B}
+	ds := func(keyTag uint16) dnsmessage.ResourceBody {
+		data := append(binary.BigEndian.AppendUint16(nil, keyTag), 13, 2)
+		return &dnsmessage.UnknownResource{Type: typeDS, Data: append(data, digest...)}
+	}
+	current := newTestServer(t)
+	current.set("example.com.", typeDS, ds(2371))
+	stale := newTestServer(t)
+	stale.set("example.com.", typeDS, ds(1111))
+
+	checker := &Checker{Nameservers: []string{current.addr}}
+	report, err := checker.CheckDS(context.Background(), "example.com", []string{"2371 13 2 c1a0424b"})
+	if err != nil {
+		t.Fatalf("CheckDS returned error: %v", err)
+	}
+	if !report.Matched || report.Parent != "com" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if got := report.Nameservers[0].Answers; len(got) != 1 || got[0] != "2371 13 2 C1A0424B" {
+		t.Fatalf("unexpected answers: %v", got)
+	}
+
+	checker.Nameservers = append(checker.Nameservers, stale.addr)
+	report, err = checker.CheckDS(context.Background(), "example.com", []string{"2371 13 2 C1A0424B"})
+	if err != nil {
+		t.Fatalf("CheckDS returned error: %v", err)
+	}
+	if report.Matched || !report.Nameservers[0].Synced || report.Nameservers[1].Synced {
+		t.Fatalf("expected the stale parent nameserver to be reported: %+v", report)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package dnscheck
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DSReport compares the DS records a parent zone publishes for a domain with
+// the ones expected from the domain's signing key.
+type DSReport struct {
+	DomainName string
+	Parent     string
+	Expected   []string
+	// Matched is set when every reachable parent nameserver publishes at
+	// least one of the expected DS records.
+	Matched     bool
+	Nameservers []*NameserverStatus
+}
+
+// CheckDS asks each nameserver of the parent zone of domainName for its DS
+// records. Nameservers, when set, replaces the parent's NS records.
+func (c *Checker) CheckDS(ctx context.Context, domainName string, expected []string) (*DSReport, error) {
+	domainName = strings.TrimSuffix(domainName, ".")
+	_, parent, ok := strings.Cut(domainName, ".")
+	if !ok || parent == "" {
+		return nil, fmt.Errorf("%s has no parent zone", domainName)
+	}
+	servers, err := c.nameservers(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DSReport{
+		DomainName:  domainName,
+		Parent:      parent,
+		Expected:    expected,
+		Matched:     true,
+		Nameservers: servers,
+	}
+	want := make([]string, 0, len(expected))
+	for _, ds := range expected {
+		want = append(want, normalize(typeDS, ds))
+	}
+	for _, ns := range servers {
+		if ns.Address == "" {
+			report.Matched = false
+			continue
+		}
+		answers, err := c.query(ctx, ns.Address, domainName, typeDS)
+		if err != nil {
+			ns.Error = err.Error()
+			report.Matched = false
+			continue
+		}
+		ns.Answers = answers
+		ns.Synced = slices.ContainsFunc(answers, func(v string) bool {
+			return slices.Contains(want, normalize(typeDS, v))
+		})
+		if !ns.Synced {
+			report.Matched = false
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,220 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package dnscheck
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	typeDS  dnsmessage.Type = 43
+	typeCAA dnsmessage.Type = 257
+)
+
+var recordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"NS":    dnsmessage.TypeNS,
+	"MX":    dnsmessage.TypeMX,
+	"TXT":   dnsmessage.TypeTXT,
+	"SRV":   dnsmessage.TypeSRV,
+	"CAA":   typeCAA,
+	"DS":    typeDS,
+}
+
+func parseType(v string) (dnsmessage.Type, error) {
+	t, ok := recordTypes[strings.ToUpper(v)]
+	if !ok {
+		return 0, fmt.Errorf("record type %q cannot be verified over DNS", v)
+	}
+	return t, nil
+}
+
+// Query sends a single question to address (ip:port) and returns the
+// answers of qtype formatted the way Alidns stores record values.
+func (c *Checker) Query(ctx context.Context, address, name, recordType string) ([]string, error) {
+	qtype, err := parseType(recordType)
+	if err != nil {
+		return nil, err
+	}
+	return c.query(ctx, address, name, qtype)
+}
+
+func (c *Checker) query(ctx context.Context, address, name string, qtype dnsmessage.Type) ([]string, error) {
+	fqdn, err := dnsmessage.NewName(strings.TrimSuffix(name, ".") + ".")
+	if err != nil {
+		return nil, err
+	}
+	question := dnsmessage.Question{Name: fqdn, Type: qtype, Class: dnsmessage.ClassINET}
+
+	msg, err := c.exchange(ctx, "udp", address, question)
+	if err == nil && msg.Header.Truncated {
+		msg, err = c.exchange(ctx, "tcp", address, question)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch msg.Header.RCode {
+	case dnsmessage.RCodeSuccess, dnsmessage.RCodeNameError:
+	default:
+		return nil, fmt.Errorf("%s answered %s", address, msg.Header.RCode)
+	}
+
+	answers := []string{}
+	for _, rr := range msg.Answers {
+		if rr.Header.Type != qtype {
+			continue
+		}
+		if v, ok := formatAnswer(rr.Body); ok {
+			answers = append(answers, v)
+		}
+	}
+	return answers, nil
+}
+
+func (c *Checker) exchange(ctx context.Context, network, address string, question dnsmessage.Question) (*dnsmessage.Message, error) {
+	id := uint16(rand.Uint32())
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id},
+		Questions: []dnsmessage.Question{question},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	var raw []byte
+	if network == "tcp" {
+		framed := binary.BigEndian.AppendUint16(nil, uint16(len(packed)))
+		if _, err := conn.Write(append(framed, packed...)); err != nil {
+			return nil, err
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+		raw = make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, raw); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(packed); err != nil {
+			return nil, err
+		}
+		raw = make([]byte, 65535)
+		n, err := conn.Read(raw)
+		if err != nil {
+			return nil, err
+		}
+		raw = raw[:n]
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(raw); err != nil {
+		return nil, fmt.Errorf("invalid response from %s: %w", address, err)
+	}
+	if msg.Header.ID != id {
+		return nil, fmt.Errorf("mismatched response ID from %s", address)
+	}
+	return &msg, nil
+}
+
+func formatAnswer(body dnsmessage.ResourceBody) (string, bool) {
+	switch b := body.(type) {
+	case *dnsmessage.AResource:
+		return net.IP(b.A[:]).String(), true
+	case *dnsmessage.AAAAResource:
+		return net.IP(b.AAAA[:]).String(), true
+	case *dnsmessage.CNAMEResource:
+		return trimDot(b.CNAME), true
+	case *dnsmessage.NSResource:
+		return trimDot(b.NS), true
+	case *dnsmessage.MXResource:
+		return trimDot(b.MX), true
+	case *dnsmessage.TXTResource:
+		return strings.Join(b.TXT, ""), true
+	case *dnsmessage.SRVResource:
+		return fmt.Sprintf("%d %d %d %s", b.Priority, b.Weight, b.Port, trimDot(b.Target)), true
+	case *dnsmessage.UnknownResource:
+		switch b.Type {
+		case typeCAA:
+			return formatCAA(b.Data)
+		case typeDS:
+			return formatDS(b.Data)
+		}
+	}
+	return "", false
+}
+
+// formatCAA renders RFC 8659 wire data as `flags tag "value"`.
+func formatCAA(data []byte) (string, bool) {
+	if len(data) < 2 || len(data) < 2+int(data[1]) {
+		return "", false
+	}
+	tagEnd := 2 + int(data[1])
+	return fmt.Sprintf("%d %s %s", data[0], data[2:tagEnd], strconv.Quote(string(data[tagEnd:]))), true
+}
+
+// formatDS renders RFC 4034 wire data as `keytag algorithm digesttype DIGEST`.
+func formatDS(data []byte) (string, bool) {
+	if len(data) < 5 {
+		return "", false
+	}
+	return fmt.Sprintf("%d %d %d %X", binary.BigEndian.Uint16(data), data[2], data[3], data[4:]), true
+}
+
+func trimDot(name dnsmessage.Name) string {
+	return strings.TrimSuffix(name.String(), ".")
+}
+
+// normalize makes a record value comparable with a DNS answer.
+func normalize(qtype dnsmessage.Type, v string) string {
+	v = strings.TrimSpace(v)
+	switch qtype {
+	case dnsmessage.TypeTXT:
+		return strings.Trim(v, `"`)
+	case dnsmessage.TypeA, dnsmessage.TypeAAAA:
+		if ip := net.ParseIP(v); ip != nil {
+			return ip.String()
+		}
+	case dnsmessage.TypeCNAME, dnsmessage.TypeNS, dnsmessage.TypeMX, dnsmessage.TypeSRV:
+		return strings.ToLower(strings.TrimSuffix(v, "."))
+	case typeCAA:
+		return strings.ToLower(strings.ReplaceAll(v, `"`, ""))
+	case typeDS:
+		return strings.ToUpper(strings.Join(strings.Fields(v), " "))
+	}
+	return v
+}
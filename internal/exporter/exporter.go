@@ -0,0 +1,190 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package exporter polls domains and exposes their records and the health of
+// the Alidns API as Prometheus metrics.
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type Options struct {
+	Domains []string
+	// ValueInfo adds an alidns_record_value_info series per record.
+	ValueInfo bool
+}
+
+type Exporter struct {
+	svc      *alidns.Service
+	opts     Options
+	registry *prometheus.Registry
+
+	records     *prometheus.GaugeVec
+	valueInfo   *prometheus.GaugeVec
+	lastSync    *prometheus.GaugeVec
+	syncErrors  *prometheus.CounterVec
+	apiDuration *prometheus.HistogramVec
+	apiErrors   *prometheus.CounterVec
+
+	mu sync.Mutex
+	// seen holds the record count labels reported so far per domain, so a
+	// combination that disappears drops to zero instead of vanishing.
+	seen map[string]map[recordKey]bool
+}
+
+type recordKey struct {
+	Type   string
+	Line   string
+	Status string
+}
+
+func New(api alidns.DNSAPI, opts Options) *Exporter {
+	e := &Exporter{
+		opts:     opts,
+		registry: prometheus.NewRegistry(),
+		records: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "alidns_records",
+			Help: "Number of records by type, line and status.",
+		}, []string{"domain", "type", "line", "status"}),
+		valueInfo: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "alidns_record_value_info",
+			Help: "Always 1; one series per record with its value as a label.",
+		}, []string{"domain", "rr", "type", "line", "status", "value"}),
+		lastSync: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "alidns_last_sync_timestamp_seconds",
+			Help: "Unix time of the last successful poll of a domain.",
+		}, []string{"domain"}),
+		syncErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alidns_sync_errors_total",
+			Help: "Failed polls of a domain.",
+		}, []string{"domain"}),
+		apiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "alidns_api_request_duration_seconds",
+			Help:    "Latency of Alidns API calls.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"action"}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alidns_api_errors_total",
+			Help: "Failed Alidns API calls by error code, such as Throttling.User.",
+		}, []string{"action", "code"}),
+		seen: map[string]map[recordKey]bool{},
+	}
+	e.registry.MustRegister(e.records, e.lastSync, e.syncErrors, e.apiDuration, e.apiErrors)
+	if opts.ValueInfo {
+		e.registry.MustRegister(e.valueInfo)
+	}
+	e.svc = alidns.NewService(alidns.Wrap(api, alidns.Observe(e.observe)))
+	return e
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// Run polls every interval until ctx is done. Failed polls are passed to
+// onError and retried on the next tick.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Sync(ctx); err != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync polls every domain once. A domain that fails keeps its previous
+// record metrics; the error is returned after the others were polled.
+func (e *Exporter) Sync(ctx context.Context) error {
+	var errs []error
+	for _, domain := range e.opts.Domains {
+		records, err := e.svc.Records(ctx, domain)
+		if err != nil {
+			e.syncErrors.WithLabelValues(domain).Inc()
+			errs = append(errs, fmt.Errorf("%s: %w", domain, err))
+			continue
+		}
+		e.update(domain, records)
+		e.lastSync.WithLabelValues(domain).SetToCurrentTime()
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) update(domain string, records []*alidns.Record) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	counts := map[recordKey]int{}
+	for _, record := range records {
+		counts[recordKey{
+			Type:   tea.StringValue(record.Type),
+			Line:   tea.StringValue(record.Line),
+			Status: strings.ToUpper(tea.StringValue(record.Status)),
+		}]++
+	}
+	seen := e.seen[domain]
+	if seen == nil {
+		seen = map[recordKey]bool{}
+		e.seen[domain] = seen
+	}
+	for key := range counts {
+		seen[key] = true
+	}
+	for key := range seen {
+		e.records.WithLabelValues(domain, key.Type, key.Line, key.Status).Set(float64(counts[key]))
+	}
+
+	if !e.opts.ValueInfo {
+		return
+	}
+	e.valueInfo.DeletePartialMatch(prometheus.Labels{"domain": domain})
+	for _, record := range records {
+		e.valueInfo.WithLabelValues(domain,
+			tea.StringValue(record.RR),
+			tea.StringValue(record.Type),
+			tea.StringValue(record.Line),
+			strings.ToUpper(tea.StringValue(record.Status)),
+			tea.StringValue(record.Value),
+		).Set(1)
+	}
+}
+
+// observe records the duration and error code of an API call made while
+// polling.
+func (e *Exporter) observe(action string, d time.Duration, err error) {
+	e.apiDuration.WithLabelValues(action).Observe(d.Seconds())
+	if err == nil {
+		return
+	}
+	code := alidns.ErrorCode(err)
+	if code == "" {
+		code = "unknown"
+	}
+	e.apiErrors.WithLabelValues(action, code).Inc()
+}
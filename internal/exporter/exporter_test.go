@@ -0,0 +1,105 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package exporter
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type fakeAPI struct {
+	alidns.DNSAPI
+
+	records []*alidns.Record
+	err     error
+}
+
+func (f *fakeAPI) DescribeDomainRecords(_ context.Context, _ *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns.Record, error) {
+	return f.records, f.err
+}
+
+func record(rr, rType, value, status string) *alidns.Record {
+	return &alidns.Record{RR: tea.String(rr), Type: tea.String(rType), Value: tea.String(value), Line: tea.String("default"), Status: tea.String(status)}
+}
+
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestSyncReportsRecordCountsAndKeepsVanishedAtZero(t *testing.T) {
+	api := &fakeAPI{records: []*alidns.Record{
+		record("www", "A", "1.2.3.4", "ENABLE"),
+		record("www", "A", "1.2.3.5", "ENABLE"),
+		record("old", "CNAME", "a.example.net", "DISABLE"),
+	}}
+	e := New(api, Options{Domains: []string{"example.com"}, ValueInfo: true})
+
+	if err := e.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	got := scrape(t, e)
+	for _, want := range []string{
+		`alidns_records{domain="example.com",line="default",status="ENABLE",type="A"} 2`,
+		`alidns_records{domain="example.com",line="default",status="DISABLE",type="CNAME"} 1`,
+		`alidns_record_value_info{domain="example.com",line="default",rr="www",status="ENABLE",type="A",value="1.2.3.5"} 1`,
+		`alidns_last_sync_timestamp_seconds{domain="example.com"}`,
+		`alidns_api_request_duration_seconds_count{action="DescribeDomainRecords"} 1`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("metrics missing %s:\n%s", want, got)
+		}
+	}
+
+	api.records = api.records[:1]
+	if err := e.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	got = scrape(t, e)
+	if !strings.Contains(got, `alidns_records{domain="example.com",line="default",status="DISABLE",type="CNAME"} 0`) {
+		t.Fatalf("vanished records should drop to zero:\n%s", got)
+	}
+	if strings.Contains(got, `value="a.example.net"`) {
+		t.Fatalf("value_info of a deleted record should be removed:\n%s", got)
+	}
+}
+
+func TestSyncCountsAPIErrorsByCode(t *testing.T) {
+	api := &fakeAPI{err: &tea.SDKError{Code: tea.String("Throttling.User"), Message: tea.String("Request was denied due to user flow control.")}}
+	e := New(api, Options{Domains: []string{"example.com"}})
+
+	if err := e.Sync(context.Background()); err == nil {
+		t.Fatal("expected Sync to fail")
+	}
+	got := scrape(t, e)
+	for _, want := range []string{
+		`alidns_api_errors_total{action="DescribeDomainRecords",code="Throttling.User"} 1`,
+		`alidns_sync_errors_total{domain="example.com"} 1`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("metrics missing %s:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "alidns_last_sync_timestamp_seconds{") {
+		t.Fatalf("a failed poll should not set the last sync time:\n%s", got)
+	}
+}
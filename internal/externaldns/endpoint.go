@@ -0,0 +1,78 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package externaldns serves Alidns records to external-dns through its
+// webhook provider protocol.
+package externaldns
+
+// MediaType is the content type of every webhook request and response.
+const MediaType = "application/external.dns.webhook+json;version=1"
+
+// Provider-specific properties understood on endpoints. external-dns derives
+// them from the external-dns.alpha.kubernetes.io/webhook-alidns-line and
+// webhook-alidns-weight annotations.
+const (
+	PropertyLine   = "webhook/alidns-line"
+	PropertyWeight = "webhook/alidns-weight"
+)
+
+// Endpoint is the external-dns view of a record set: one name, type and set
+// identifier with all of its targets.
+type Endpoint struct {
+	DNSName          string                     `json:"dnsName,omitempty"`
+	Targets          []string                   `json:"targets,omitempty"`
+	RecordType       string                     `json:"recordType,omitempty"`
+	SetIdentifier    string                     `json:"setIdentifier,omitempty"`
+	RecordTTL        int64                      `json:"recordTTL,omitempty"`
+	Labels           map[string]string          `json:"labels,omitempty"`
+	ProviderSpecific []ProviderSpecificProperty `json:"providerSpecific,omitempty"`
+}
+
+type ProviderSpecificProperty struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Changes is the body of POST /records. UpdateOld and UpdateNew pair up by
+// index.
+type Changes struct {
+	Create    []*Endpoint `json:"Create"`
+	UpdateOld []*Endpoint `json:"UpdateOld"`
+	UpdateNew []*Endpoint `json:"UpdateNew"`
+	Delete    []*Endpoint `json:"Delete"`
+}
+
+// DomainFilter is returned on negotiation and limits external-dns to the
+// served domains.
+type DomainFilter struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Property returns the value of a provider-specific property.
+func (e *Endpoint) Property(name string) (string, bool) {
+	for _, p := range e.ProviderSpecific {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+func (e *Endpoint) deleteProperty(name string) {
+	kept := e.ProviderSpecific[:0]
+	for _, p := range e.ProviderSpecific {
+		if p.Name != name {
+			kept = append(kept, p)
+		}
+	}
+	e.ProviderSpecific = kept
+}
@@ -0,0 +1,506 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package externaldns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const (
+	defaultLine = "default"
+	// setRemarkPrefix marks the remark of records that belong to an
+	// endpoint with a set identifier; the identifier follows it.
+	setRemarkPrefix = "external-dns-set:"
+)
+
+type Options struct {
+	// Domains are the Alidns domains served to external-dns.
+	Domains []string
+	// OnChange, if set, is called after each endpoint that ApplyChanges
+	// handled, with the error the endpoint failed with.
+	OnChange func(ctx context.Context, change Change, err error)
+}
+
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+// Change is one endpoint of an ApplyChanges call. Old is the previous
+// endpoint of an update.
+type Change struct {
+	Action   string
+	Endpoint *Endpoint
+	Old      *Endpoint
+}
+
+// Provider maps external-dns endpoints to Alidns records. Every target of an
+// endpoint is one record at the same RR, type and line; the set identifier
+// of weighted sets is kept in the record remark and the weight in the
+// record's weighted round-robin weight.
+type Provider struct {
+	svc      *alidns.Service
+	domains  []string
+	onChange func(context.Context, Change, error)
+}
+
+func New(api alidns.DNSAPI, opts Options) *Provider {
+	domains := make([]string, 0, len(opts.Domains))
+	for _, domain := range opts.Domains {
+		domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+		if ascii, err := alidns.ToASCII(domain); err == nil {
+			domain = ascii
+		}
+		domains = append(domains, domain)
+	}
+	return &Provider{svc: alidns.NewService(api), domains: domains, onChange: opts.OnChange}
+}
+
+// DomainFilter limits external-dns to the served domains.
+func (p *Provider) DomainFilter() DomainFilter {
+	return DomainFilter{Include: p.domains}
+}
+
+// Records returns the current records of all served domains as endpoints.
+func (p *Provider) Records(ctx context.Context) ([]*Endpoint, error) {
+	var endpoints []*Endpoint
+	for _, domain := range p.domains {
+		records, err := p.svc.Records(ctx, domain)
+		if err != nil {
+			return nil, fmt.Errorf("list records of %s: %w", domain, err)
+		}
+		sets := map[setKey]*Endpoint{}
+		var keys []setKey
+		for _, r := range records {
+			key := keyOf(domain, r)
+			ep, ok := sets[key]
+			if !ok {
+				ep = &Endpoint{
+					DNSName:       alidns.JoinFQDN(domain, key.RR),
+					RecordType:    key.Type,
+					SetIdentifier: key.Set,
+					RecordTTL:     tea.Int64Value(r.TTL),
+				}
+				if key.Line != defaultLine {
+					ep.ProviderSpecific = append(ep.ProviderSpecific, ProviderSpecificProperty{Name: PropertyLine, Value: key.Line})
+				}
+				if key.Set != "" && r.Weight != nil {
+					ep.ProviderSpecific = append(ep.ProviderSpecific, ProviderSpecificProperty{Name: PropertyWeight, Value: strconv.Itoa(int(*r.Weight))})
+				}
+				sets[key] = ep
+				keys = append(keys, key)
+			}
+			ep.Targets = append(ep.Targets, targetOf(r))
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+		for _, key := range keys {
+			endpoints = append(endpoints, sets[key])
+		}
+	}
+	return endpoints, nil
+}
+
+// AdjustEndpoints normalizes desired endpoints to the form Records reports,
+// so that external-dns does not plan changes for equivalent endpoints.
+func (p *Provider) AdjustEndpoints(endpoints []*Endpoint) []*Endpoint {
+	for _, ep := range endpoints {
+		ep.DNSName = strings.ToLower(strings.TrimSuffix(ep.DNSName, "."))
+		if line, ok := ep.Property(PropertyLine); ok && (line == "" || line == defaultLine) {
+			ep.deleteProperty(PropertyLine)
+		}
+		if ep.SetIdentifier == "" {
+			ep.deleteProperty(PropertyWeight)
+		}
+	}
+	return endpoints
+}
+
+// ApplyChanges applies deletions, then updates, then creations. A failed
+// endpoint does not stop the others; all failures are returned together and
+// external-dns retries them on its next sync.
+func (p *Provider) ApplyChanges(ctx context.Context, changes *Changes) error {
+	if len(changes.UpdateOld) != len(changes.UpdateNew) {
+		return fmt.Errorf("UpdateOld has %d endpoints but UpdateNew has %d", len(changes.UpdateOld), len(changes.UpdateNew))
+	}
+	a := &applier{p: p, records: map[string][]*alidns.Record{}, slb: map[setKey]bool{}}
+	var errs []error
+	done := func(change Change, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s %s: %w", change.Action, change.Endpoint.DNSName, change.Endpoint.RecordType, err))
+		}
+		if p.onChange != nil {
+			p.onChange(ctx, change, err)
+		}
+	}
+	for _, ep := range changes.Delete {
+		done(Change{Action: ChangeDelete, Endpoint: ep}, a.delete(ctx, ep))
+	}
+	for i, ep := range changes.UpdateNew {
+		done(Change{Action: ChangeUpdate, Endpoint: ep, Old: changes.UpdateOld[i]}, a.update(ctx, changes.UpdateOld[i], ep))
+	}
+	for _, ep := range changes.Create {
+		done(Change{Action: ChangeCreate, Endpoint: ep}, a.create(ctx, ep))
+	}
+	return errors.Join(errs...)
+}
+
+// setKey identifies the records behind one endpoint.
+type setKey struct {
+	Domain string
+	RR     string
+	Type   string
+	Line   string
+	Set    string
+}
+
+func (k setKey) less(o setKey) bool {
+	a := []string{k.RR, k.Type, k.Line, k.Set}
+	b := []string{o.RR, o.Type, o.Line, o.Set}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func keyOf(domain string, r *alidns.Record) setKey {
+	line := tea.StringValue(r.Line)
+	if line == "" {
+		line = defaultLine
+	}
+	set, ok := strings.CutPrefix(tea.StringValue(r.Remark), setRemarkPrefix)
+	if !ok {
+		set = ""
+	}
+	return setKey{
+		Domain: domain,
+		RR:     strings.ToLower(tea.StringValue(r.RR)),
+		Type:   strings.ToUpper(tea.StringValue(r.Type)),
+		Line:   line,
+		Set:    set,
+	}
+}
+
+func setRemark(set string) string {
+	if set == "" {
+		return ""
+	}
+	return setRemarkPrefix + set
+}
+
+// targetOf renders a record value the way external-dns writes targets: MX
+// with its preference in front, and TXT ownership records quoted.
+func targetOf(r *alidns.Record) string {
+	value := tea.StringValue(r.Value)
+	switch strings.ToUpper(tea.StringValue(r.Type)) {
+	case "MX":
+		return fmt.Sprintf("%d %s", tea.Int64Value(r.Priority), value)
+	case "TXT":
+		if strings.HasPrefix(value, "heritage=") {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
+
+// target is an endpoint target in Alidns terms.
+type target struct {
+	Value    string
+	Priority int64
+}
+
+func targetsOf(rType string, targets []string) ([]target, error) {
+	out := make([]target, 0, len(targets))
+	for _, t := range targets {
+		switch rType {
+		case "MX":
+			fields := strings.Fields(t)
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("MX target %q is not \"<preference> <host>\"", t)
+			}
+			priority, err := strconv.ParseInt(fields[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("MX target %q: %w", t, err)
+			}
+			out = append(out, target{Value: strings.TrimSuffix(fields[1], "."), Priority: priority})
+		case "TXT":
+			if len(t) >= 2 && strings.HasPrefix(t, `"`) && strings.HasSuffix(t, `"`) {
+				t = t[1 : len(t)-1]
+			}
+			out = append(out, target{Value: t})
+		case "CNAME", "NS":
+			out = append(out, target{Value: strings.TrimSuffix(t, ".")})
+		default:
+			out = append(out, target{Value: t})
+		}
+	}
+	return out, nil
+}
+
+func (t target) matches(rType string, r *alidns.Record) bool {
+	value := tea.StringValue(r.Value)
+	switch rType {
+	case "TXT":
+		return strings.Trim(value, `"`) == t.Value
+	case "MX":
+		if tea.Int64Value(r.Priority) != t.Priority {
+			return false
+		}
+	}
+	return strings.EqualFold(strings.TrimSuffix(value, "."), t.Value)
+}
+
+func weightOf(ep *Endpoint) (int32, bool, error) {
+	raw, ok := ep.Property(PropertyWeight)
+	if !ok || raw == "" {
+		return 0, false, nil
+	}
+	weight, err := strconv.Atoi(raw)
+	if err != nil || weight < 1 || weight > 100 {
+		return 0, false, fmt.Errorf("%s must be between 1 and 100, got %q", PropertyWeight, raw)
+	}
+	return int32(weight), true, nil
+}
+
+// applier carries the state of one ApplyChanges call.
+type applier struct {
+	p *Provider
+	// records caches the records of a domain until one of them changes.
+	records map[string][]*alidns.Record
+	// slb holds the RR sets whose weighted round-robin is already on; only
+	// Domain, RR, Type and Line of the key are used.
+	slb map[setKey]bool
+}
+
+func (a *applier) key(ep *Endpoint) (setKey, error) {
+	domain, rr, ok := alidns.MatchFQDN(ep.DNSName, a.p.domains)
+	if !ok {
+		return setKey{}, fmt.Errorf("%s is not in the served domains", ep.DNSName)
+	}
+	line, _ := ep.Property(PropertyLine)
+	if line == "" {
+		line = defaultLine
+	}
+	return setKey{Domain: domain, RR: rr, Type: strings.ToUpper(ep.RecordType), Line: line, Set: ep.SetIdentifier}, nil
+}
+
+func (a *applier) find(ctx context.Context, key setKey) ([]*alidns.Record, error) {
+	records, ok := a.records[key.Domain]
+	if !ok {
+		var err error
+		if records, err = a.p.svc.Records(ctx, key.Domain); err != nil {
+			return nil, err
+		}
+		a.records[key.Domain] = records
+	}
+	var matched []*alidns.Record
+	for _, r := range records {
+		if keyOf(key.Domain, r) == key {
+			matched = append(matched, r)
+		}
+	}
+	return matched, nil
+}
+
+func (a *applier) create(ctx context.Context, ep *Endpoint) error {
+	key, err := a.key(ep)
+	if err != nil {
+		return err
+	}
+	weight, weighted, err := weightOf(ep)
+	if err != nil {
+		return err
+	}
+	targets, err := targetsOf(key.Type, ep.Targets)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(targets))
+	for _, t := range targets {
+		id, err := a.add(ctx, key, ep.RecordTTL, t)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if !weighted {
+		return nil
+	}
+	return a.setWeights(ctx, key, ids, weight)
+}
+
+func (a *applier) delete(ctx context.Context, ep *Endpoint) error {
+	key, err := a.key(ep)
+	if err != nil {
+		return err
+	}
+	targets, err := targetsOf(key.Type, ep.Targets)
+	if err != nil {
+		return err
+	}
+	records, err := a.find(ctx, key)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if indexOf(key.Type, targets, r) < 0 {
+			continue
+		}
+		delete(a.records, key.Domain)
+		if _, err := a.p.svc.DeleteRecord(ctx, tea.StringValue(r.RecordId)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update reconciles the records of an endpoint in place: targets that are
+// gone are deleted, new ones added and kept ones get the new TTL and weight.
+// A change of line or set identifier recreates the endpoint.
+func (a *applier) update(ctx context.Context, old, ep *Endpoint) error {
+	oldKey, err := a.key(old)
+	if err != nil {
+		return err
+	}
+	key, err := a.key(ep)
+	if err != nil {
+		return err
+	}
+	if oldKey != key {
+		if err := a.delete(ctx, old); err != nil {
+			return err
+		}
+		return a.create(ctx, ep)
+	}
+
+	weight, weighted, err := weightOf(ep)
+	if err != nil {
+		return err
+	}
+	targets, err := targetsOf(key.Type, ep.Targets)
+	if err != nil {
+		return err
+	}
+	records, err := a.find(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	kept := make([]bool, len(targets))
+	var reweigh []string
+	for _, r := range records {
+		id := tea.StringValue(r.RecordId)
+		i := indexOf(key.Type, targets, r)
+		if i < 0 || kept[i] {
+			delete(a.records, key.Domain)
+			if _, err := a.p.svc.DeleteRecord(ctx, id); err != nil {
+				return err
+			}
+			continue
+		}
+		kept[i] = true
+		if ep.RecordTTL != 0 && ep.RecordTTL != tea.Int64Value(r.TTL) {
+			delete(a.records, key.Domain)
+			if _, err := a.p.svc.Update(ctx, alidns.UpdateInput{
+				RecordID: id,
+				Name:     key.RR,
+				Type:     key.Type,
+				Value:    tea.StringValue(r.Value),
+				TTL:      ep.RecordTTL,
+				Priority: tea.Int64Value(r.Priority),
+				Line:     key.Line,
+			}); err != nil {
+				return err
+			}
+		}
+		if weighted && tea.Int32Value(r.Weight) != weight {
+			reweigh = append(reweigh, id)
+		}
+	}
+	for i, t := range targets {
+		if kept[i] {
+			continue
+		}
+		id, err := a.add(ctx, key, ep.RecordTTL, t)
+		if err != nil {
+			return err
+		}
+		if weighted {
+			reweigh = append(reweigh, id)
+		}
+	}
+	if len(reweigh) == 0 {
+		return nil
+	}
+	return a.setWeights(ctx, key, reweigh, weight)
+}
+
+func (a *applier) add(ctx context.Context, key setKey, ttl int64, t target) (string, error) {
+	delete(a.records, key.Domain)
+	resp, err := a.p.svc.Add(ctx, alidns.AddInput{
+		DomainName: key.Domain,
+		Name:       key.RR,
+		Type:       key.Type,
+		Value:      t.Value,
+		TTL:        ttl,
+		Priority:   t.Priority,
+		Line:       key.Line,
+		Remark:     setRemark(key.Set),
+	})
+	if err != nil {
+		return "", err
+	}
+	return tea.StringValue(resp.RecordId), nil
+}
+
+// setWeights turns on weighted round-robin for the RR set once per call and
+// gives the records their weight.
+func (a *applier) setWeights(ctx context.Context, key setKey, ids []string, weight int32) error {
+	slbKey := setKey{Domain: key.Domain, RR: key.RR, Type: key.Type, Line: key.Line}
+	if !a.slb[slbKey] {
+		if _, err := a.p.svc.SetSLBStatus(ctx, alidns.SLBStatusInput{
+			DomainName: key.Domain,
+			Name:       key.RR,
+			Type:       key.Type,
+			Line:       key.Line,
+			Open:       true,
+		}); err != nil {
+			return err
+		}
+		a.slb[slbKey] = true
+	}
+	for _, id := range ids {
+		if _, err := a.p.svc.SetWeight(ctx, alidns.WeightInput{RecordID: id, Weight: weight}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indexOf(rType string, targets []target, r *alidns.Record) int {
+	for i, t := range targets {
+		if t.matches(rType, r) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package externaldns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Handler serves the webhook protocol: negotiation on /, the records on
+// GET /records, changes on POST /records, /adjustendpoints and /healthz.
+// onError, if not nil, is told about failed requests.
+func (p *Provider) Handler(onError func(error)) http.Handler {
+	fail := func(w http.ResponseWriter, status int, err error) {
+		if onError != nil {
+			onError(err)
+		}
+		http.Error(w, err.Error(), status)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, p.DomainFilter())
+	})
+	mux.HandleFunc("GET /records", func(w http.ResponseWriter, r *http.Request) {
+		endpoints, err := p.Records(r.Context())
+		if err != nil {
+			fail(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, endpoints)
+	})
+	mux.HandleFunc("POST /records", func(w http.ResponseWriter, r *http.Request) {
+		var changes Changes
+		if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+			fail(w, http.StatusBadRequest, fmt.Errorf("decode changes: %w", err))
+			return
+		}
+		if err := p.ApplyChanges(r.Context(), &changes); err != nil {
+			fail(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /adjustendpoints", func(w http.ResponseWriter, r *http.Request) {
+		var endpoints []*Endpoint
+		if err := json.NewDecoder(r.Body).Decode(&endpoints); err != nil {
+			fail(w, http.StatusBadRequest, fmt.Errorf("decode endpoints: %w", err))
+			return
+		}
+		writeJSON(w, p.AdjustEndpoints(endpoints))
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", MediaType)
+	w.Header().Set("Vary", "Content-Type")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,230 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package externaldns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// fakeAPI keeps the records of example.com in memory.
+type fakeAPI struct {
+	alidns.DNSAPI
+
+	records []*alidns.Record
+	nextID  int
+	slb     []string
+}
+
+func (f *fakeAPI) DescribeDomainRecords(_ context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*alidns.Record, error) {
+	if tea.Int64Value(req.PageNumber) > 1 {
+		return nil, nil
+	}
+	return f.records, nil
+}
+
+func (f *fakeAPI) AddDomainRecord(_ context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	f.nextID++
+	id := fmt.Sprintf("r-%d", f.nextID)
+	f.records = append(f.records, &alidns.Record{
+		RecordId: tea.String(id), RR: req.RR, Type: req.Type, Value: req.Value,
+		TTL: req.TTL, Priority: req.Priority, Line: req.Line, Weight: tea.Int32(1),
+	})
+	return &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String(id)}, nil
+}
+
+func (f *fakeAPI) record(id *string) *alidns.Record {
+	for _, r := range f.records {
+		if tea.StringValue(r.RecordId) == tea.StringValue(id) {
+			return r
+		}
+	}
+	panic("unknown record " + tea.StringValue(id))
+}
+
+func (f *fakeAPI) UpdateDomainRecordRemark(_ context.Context, req *alidns20150109.UpdateDomainRecordRemarkRequest) (*alidns20150109.UpdateDomainRecordRemarkResponseBody, error) {
+	f.record(req.RecordId).Remark = req.Remark
+	return &alidns20150109.UpdateDomainRecordRemarkResponseBody{}, nil
+}
+
+func (f *fakeAPI) UpdateDomainRecord(_ context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+	r := f.record(req.RecordId)
+	r.Value, r.TTL = req.Value, req.TTL
+	return &alidns20150109.UpdateDomainRecordResponseBody{}, nil
+}
+
+func (f *fakeAPI) DeleteDomainRecord(_ context.Context, req *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	kept := f.records[:0]
+	for _, r := range f.records {
+		if tea.StringValue(r.RecordId) != tea.StringValue(req.RecordId) {
+			kept = append(kept, r)
+		}
+	}
+	f.records = kept
+	return &alidns20150109.DeleteDomainRecordResponseBody{}, nil
+}
+
+func (f *fakeAPI) SetDNSSLBStatus(_ context.Context, req *alidns20150109.SetDNSSLBStatusRequest) (*alidns20150109.SetDNSSLBStatusResponseBody, error) {
+	f.slb = append(f.slb, tea.StringValue(req.SubDomain)+" "+tea.StringValue(req.Type))
+	return &alidns20150109.SetDNSSLBStatusResponseBody{}, nil
+}
+
+func (f *fakeAPI) UpdateDNSSLBWeight(_ context.Context, req *alidns20150109.UpdateDNSSLBWeightRequest) (*alidns20150109.UpdateDNSSLBWeightResponseBody, error) {
+	f.record(req.RecordId).Weight = req.Weight
+	return &alidns20150109.UpdateDNSSLBWeightResponseBody{}, nil
+}
+
+func do(t *testing.T, srv *httptest.Server, method, path string, body, out any) int {
+	t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&payload).Encode(body)
+	}
+	req, _ := http.NewRequest(method, srv.URL+path, &payload)
+	req.Header.Set("Accept", MediaType)
+	req.Header.Set("Content-Type", MediaType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if ct := resp.Header.Get("Content-Type"); ct != MediaType {
+			t.Fatalf("%s %s: content type %q", method, path, ct)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func weighted(name, set, weight string, targets ...string) *Endpoint {
+	return &Endpoint{
+		DNSName: name, RecordType: "A", SetIdentifier: set, Targets: targets, RecordTTL: 600,
+		ProviderSpecific: []ProviderSpecificProperty{{Name: PropertyWeight, Value: weight}},
+	}
+}
+
+func TestWebhookServesRecordsAndAppliesChanges(t *testing.T) {
+	api := &fakeAPI{}
+	srv := httptest.NewServer(New(api, Options{Domains: []string{"Example.com."}}).Handler(nil))
+	defer srv.Close()
+
+	var filter DomainFilter
+	if status := do(t, srv, "GET", "/", nil, &filter); status != http.StatusOK || !reflect.DeepEqual(filter.Include, []string{"example.com"}) {
+		t.Fatalf("negotiation = %d %+v", status, filter)
+	}
+	if status := do(t, srv, "GET", "/healthz", nil, nil); status != http.StatusOK {
+		t.Fatalf("healthz = %d", status)
+	}
+
+	owner := `"heritage=external-dns,external-dns/owner=k8s,external-dns/resource=ingress/default/web"`
+	create := &Changes{Create: []*Endpoint{
+		{DNSName: "www.example.com", RecordType: "A", Targets: []string{"1.1.1.1", "2.2.2.2"}, RecordTTL: 300,
+			ProviderSpecific: []ProviderSpecificProperty{{Name: PropertyLine, Value: "telecom"}}},
+		{DNSName: "a-www.example.com", RecordType: "TXT", Targets: []string{owner}},
+		{DNSName: "example.com", RecordType: "MX", Targets: []string{"10 mx.example.net."}},
+		weighted("api.example.com", "blue", "80", "10.0.0.1"),
+		weighted("api.example.com", "green", "20", "10.0.0.2"),
+	}}
+	if status := do(t, srv, "POST", "/records", create, nil); status != http.StatusNoContent {
+		t.Fatalf("create = %d", status)
+	}
+	if r := api.record(tea.String("r-3")); tea.StringValue(r.Value) != owner[1:len(owner)-1] {
+		t.Fatalf("TXT value should be stored unquoted, got %s", tea.StringValue(r.Value))
+	}
+	if !reflect.DeepEqual(api.slb, []string{"api.example.com A"}) {
+		t.Fatalf("expected weighted round-robin to be turned on once for api, got %v", api.slb)
+	}
+
+	var endpoints []*Endpoint
+	do(t, srv, "GET", "/records", nil, &endpoints)
+	want := []*Endpoint{
+		{DNSName: "example.com", RecordType: "MX", Targets: []string{"10 mx.example.net"}, RecordTTL: 600},
+		{DNSName: "a-www.example.com", RecordType: "TXT", Targets: []string{owner}, RecordTTL: 600},
+		weighted("api.example.com", "blue", "80", "10.0.0.1"),
+		weighted("api.example.com", "green", "20", "10.0.0.2"),
+		{DNSName: "www.example.com", RecordType: "A", Targets: []string{"1.1.1.1", "2.2.2.2"}, RecordTTL: 300,
+			ProviderSpecific: []ProviderSpecificProperty{{Name: PropertyLine, Value: "telecom"}}},
+	}
+	if !reflect.DeepEqual(endpoints, want) {
+		got, _ := json.Marshal(endpoints)
+		t.Fatalf("unexpected records: %s", got)
+	}
+
+	update := &Changes{
+		UpdateOld: []*Endpoint{want[4], want[2]},
+		UpdateNew: []*Endpoint{
+			{DNSName: "www.example.com", RecordType: "A", Targets: []string{"2.2.2.2", "3.3.3.3"}, RecordTTL: 60,
+				ProviderSpecific: []ProviderSpecificProperty{{Name: PropertyLine, Value: "telecom"}}},
+			weighted("api.example.com", "blue", "50", "10.0.0.1"),
+		},
+		Delete: []*Endpoint{want[1]},
+	}
+	if status := do(t, srv, "POST", "/records", update, nil); status != http.StatusNoContent {
+		t.Fatalf("update = %d", status)
+	}
+	do(t, srv, "GET", "/records", nil, &endpoints)
+	if len(endpoints) != 4 {
+		got, _ := json.Marshal(endpoints)
+		t.Fatalf("unexpected records after update: %s", got)
+	}
+	if got := endpoints[3]; !reflect.DeepEqual(got.Targets, []string{"2.2.2.2", "3.3.3.3"}) || got.RecordTTL != 60 {
+		t.Fatalf("unexpected www endpoint: %+v", got)
+	}
+	if got, _ := endpoints[1].Property(PropertyWeight); got != "50" {
+		t.Fatalf("blue weight = %s", got)
+	}
+}
+
+func TestWebhookAdjustsEndpointsAndRejectsForeignNames(t *testing.T) {
+	api := &fakeAPI{}
+	var errs []error
+	var changed []string
+	onChange := func(_ context.Context, change Change, err error) {
+		changed = append(changed, fmt.Sprintf("%s %s %t", change.Action, change.Endpoint.DNSName, err == nil))
+	}
+	srv := httptest.NewServer(New(api, Options{Domains: []string{"example.com"}, OnChange: onChange}).Handler(func(err error) { errs = append(errs, err) }))
+	defer srv.Close()
+
+	var adjusted []*Endpoint
+	do(t, srv, "POST", "/adjustendpoints", []*Endpoint{
+		{DNSName: "WWW.example.com.", RecordType: "A", Targets: []string{"1.1.1.1"}, ProviderSpecific: []ProviderSpecificProperty{
+			{Name: PropertyLine, Value: "default"}, {Name: PropertyWeight, Value: "10"},
+		}},
+	}, &adjusted)
+	if len(adjusted) != 1 || adjusted[0].DNSName != "www.example.com" || len(adjusted[0].ProviderSpecific) != 0 {
+		t.Fatalf("unexpected adjusted endpoints: %+v", adjusted[0])
+	}
+
+	status := do(t, srv, "POST", "/records", &Changes{Create: []*Endpoint{
+		{DNSName: "www.example.org", RecordType: "A", Targets: []string{"1.1.1.1"}},
+		{DNSName: "ok.example.com", RecordType: "A", Targets: []string{"1.1.1.1"}},
+	}}, nil)
+	if status != http.StatusInternalServerError || len(errs) != 1 || len(api.records) != 1 {
+		t.Fatalf("expected the foreign name to fail alone, got %d %v with %d records", status, errs, len(api.records))
+	}
+	if want := []string{"create www.example.org false", "create ok.example.com true"}; !reflect.DeepEqual(changed, want) {
+		t.Fatalf("OnChange saw %v, want %v", changed, want)
+	}
+}
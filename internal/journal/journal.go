@@ -0,0 +1,148 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package journal keeps a local, append-only log of record mutations so that
+// they can be reviewed and reverted later.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"time"
+
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+)
+
+const fileName = "journal.jsonl"
+
+const (
+	OpAdd    = "add"
+	OpDel    = "del"
+	OpUpdate = "update"
+)
+
+type Record = alidns20150109.DescribeDomainRecordsResponseBodyDomainRecordsRecord
+
+// Entry is one mutation as it was sent to the API.
+type Entry struct {
+	ID      string
+	Time    time.Time
+	Profile string
+	// Backend is empty for alidns and pvtz for PrivateZone.
+	Backend   string `json:",omitempty"`
+	Domain    string
+	Operation string
+	Request   any
+	RequestId string
+	// Before is the state of the affected records before the change.
+	Before []*Record
+	// RecordIds lists records the change created.
+	RecordIds []string `json:",omitempty"`
+	// UndoOf is the ID of the entry this change reverted.
+	UndoOf string `json:",omitempty"`
+}
+
+type Journal struct {
+	path string
+}
+
+func Open(dir string) *Journal {
+	return &Journal{path: filepath.Join(dir, fileName)}
+}
+
+// Append assigns an ID and timestamp to e and writes it as one line.
+func (j *Journal) Append(e *Entry) error {
+	now := time.Now().UTC()
+	e.Time = now
+	e.ID = fmt.Sprintf("%s-%04x", now.Format("20060102T150405"), rand.IntN(0x10000))
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// List returns all entries, oldest first.
+func (j *Journal) List() ([]*Entry, error) {
+	f, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []*Entry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []*Entry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("corrupt journal %s: %w", j.path, err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Undoable returns the entry with id, or when id is empty the newest entry
+// that is neither undone nor itself an undo.
+func (j *Journal) Undoable(id string) (*Entry, error) {
+	entries, err := j.List()
+	if err != nil {
+		return nil, err
+	}
+
+	undone := map[string]bool{}
+	for _, e := range entries {
+		if e.UndoOf != "" {
+			undone[e.UndoOf] = true
+		}
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if id == "" && e.UndoOf == "" && !undone[e.ID] {
+			return e, nil
+		}
+		if e.ID == id {
+			if undone[e.ID] {
+				return nil, fmt.Errorf("entry %s has already been undone", id)
+			}
+			return e, nil
+		}
+	}
+	if id == "" {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+	return nil, fmt.Errorf("entry %s not found", id)
+}
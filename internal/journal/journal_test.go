@@ -0,0 +1,74 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package journal
+
+import (
+	"testing"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestAppendAndList(t *testing.T) {
+	j := Open(t.TempDir())
+
+	entries, err := j.List()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("empty journal: entries=%v err=%v", entries, err)
+	}
+
+	if err := j.Append(&Entry{Domain: "example.com", Operation: OpDel, Before: []*Record{{RecordId: tea.String("r-1"), Value: tea.String("1.2.3.4")}}}); err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+	if err := j.Append(&Entry{Domain: "example.com", Operation: OpAdd, RecordIds: []string{"r-2"}}); err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+
+	entries, err = j.List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Operation != OpDel || entries[1].Operation != OpAdd {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if entries[0].ID == "" || entries[0].Time.IsZero() {
+		t.Fatalf("entry should be stamped: %+v", entries[0])
+	}
+	if got := tea.StringValue(entries[0].Before[0].Value); got != "1.2.3.4" {
+		t.Fatalf("before state not preserved: %q", got)
+	}
+}
+
+func TestUndoableSkipsUndoneEntries(t *testing.T) {
+	j := Open(t.TempDir())
+	first := &Entry{Operation: OpAdd}
+	second := &Entry{Operation: OpDel}
+	for _, e := range []*Entry{first, second} {
+		if err := j.Append(e); err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+	}
+	if err := j.Append(&Entry{Operation: OpAdd, UndoOf: second.ID}); err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+
+	e, err := j.Undoable("")
+	if err != nil {
+		t.Fatalf("Undoable returned error: %v", err)
+	}
+	if e.ID != first.ID {
+		t.Fatalf("expected %s, got %s", first.ID, e.ID)
+	}
+
+	if _, err := j.Undoable(second.ID); err == nil {
+		t.Fatal("expected error for an entry that was already undone")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package notify posts record changes to webhooks and chat robots, keeping
+// deliveries that keep failing in a local outbox until they go through.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SinkWebhook  = "webhook"
+	SinkSlack    = "slack"
+	SinkDingTalk = "dingtalk"
+	SinkFeishu   = "feishu"
+)
+
+const (
+	defaultRetries = 2
+	defaultBackoff = 500 * time.Millisecond
+	defaultTimeout = 5 * time.Second
+)
+
+// Config is the notification file, e.g.
+//
+//	sinks:
+//	  - name: ops
+//	    type: webhook
+//	    url: https://hooks.example.com/alidns
+//	    secret: s3cret
+//	  - type: dingtalk
+//	    url: https://oapi.dingtalk.com/robot/send?access_token=TOKEN
+//	    secret: SEC123
+//	    profiles: [LTAI****1234]
+type Config struct {
+	// Retries is how often a failed delivery is retried before it goes to
+	// the outbox.
+	Retries *int          `yaml:"retries"`
+	Backoff time.Duration `yaml:"backoff"`
+	Timeout time.Duration `yaml:"timeout"`
+	Sinks   []Sink        `yaml:"sinks"`
+}
+
+type Sink struct {
+	// Name identifies the sink in the outbox. It defaults to Type.
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	URL  string `yaml:"url"`
+	// Secret signs webhook bodies with HMAC-SHA256 and is the signing key
+	// of DingTalk and Feishu robots.
+	Secret string `yaml:"secret"`
+	// Profiles limits the sink to changes made with these credentials, as
+	// shown in the journal. Empty means all.
+	Profiles []string `yaml:"profiles"`
+}
+
+// LoadConfig reads path. A missing file yields nil: notifications are off.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid notification config %s: %w", path, err)
+	}
+	names := map[string]bool{}
+	for i := range cfg.Sinks {
+		sink := &cfg.Sinks[i]
+		switch sink.Type {
+		case SinkWebhook, SinkSlack, SinkDingTalk, SinkFeishu:
+		default:
+			return nil, fmt.Errorf("invalid notification config %s: sink %d: unsupported type %q, expected %s|%s|%s|%s", path, i+1, sink.Type, SinkWebhook, SinkSlack, SinkDingTalk, SinkFeishu)
+		}
+		if sink.URL == "" {
+			return nil, fmt.Errorf("invalid notification config %s: sink %d: url is required", path, i+1)
+		}
+		if sink.Name == "" {
+			sink.Name = sink.Type
+		}
+		if names[sink.Name] {
+			return nil, fmt.Errorf("invalid notification config %s: duplicate sink name %q", path, sink.Name)
+		}
+		names[sink.Name] = true
+	}
+	return &cfg, nil
+}
+
+func (s *Sink) accepts(profile string) bool {
+	return len(s.Profiles) == 0 || slices.Contains(s.Profiles, profile)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// Event is one record mutation, successful or not. It is the body of webhook
+// deliveries and the source of chat messages.
+type Event struct {
+	Time      time.Time
+	Operation string
+	Success   bool
+	Error     string `json:",omitempty"`
+	Domain    string
+	// Record is a one-line summary of the target, e.g. "www.example.com A 1.2.3.4".
+	Record string
+	// Before is the state of the affected records before the change.
+	Before []*alidns.Record `json:",omitempty"`
+	// After is the requested state, if the operation has one.
+	After any `json:",omitempty"`
+	// Actor is the local user that ran the command; Profile identifies the
+	// credentials.
+	Actor     string
+	Profile   string
+	RequestId string `json:",omitempty"`
+	JournalId string `json:",omitempty"`
+	UndoOf    string `json:",omitempty"`
+}
+
+// Text renders the event for chat robots.
+func (e *Event) Text() string {
+	var b strings.Builder
+	result := "成功"
+	if !e.Success {
+		result = "失败"
+	}
+	fmt.Fprintf(&b, "[alidns] %s %s %s\n", e.Operation, e.Record, result)
+	fmt.Fprintf(&b, "域名: %s\n", e.Domain)
+	fmt.Fprintf(&b, "操作人: %s (%s)\n", e.Actor, e.Profile)
+	if e.RequestId != "" {
+		fmt.Fprintf(&b, "RequestId: %s\n", e.RequestId)
+	}
+	if e.UndoOf != "" {
+		fmt.Fprintf(&b, "撤销: %s\n", e.UndoOf)
+	}
+	for _, r := range e.Before {
+		fmt.Fprintf(&b, "变更前: %s %s %s TTL=%d %s\n", tea.StringValue(r.RR), tea.StringValue(r.Type), tea.StringValue(r.Value), tea.Int64Value(r.TTL), tea.StringValue(r.Status))
+	}
+	if e.After != nil {
+		after, _ := json.Marshal(e.After)
+		fmt.Fprintf(&b, "变更后: %s\n", after)
+	}
+	if e.Error != "" {
+		fmt.Fprintf(&b, "错误: %s\n", e.Error)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Notifier delivers events to the configured sinks. A delivery that still
+// fails after the retries is written to the outbox directory and retried by
+// Flush, which Notify runs first.
+type Notifier struct {
+	cfg    *Config
+	outbox string
+	client *http.Client
+	now    func() time.Time
+}
+
+// pending is one undelivered event in the outbox.
+type pending struct {
+	Sink      string
+	Event     *Event
+	Attempts  int
+	LastError string
+}
+
+// New returns a notifier for cfg. Failed deliveries are kept under outbox;
+// an empty outbox drops them.
+func New(cfg *Config, outbox string) *Notifier {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Notifier{
+		cfg:    cfg,
+		outbox: outbox,
+		client: &http.Client{Timeout: timeout},
+		now:    time.Now,
+	}
+}
+
+// Notify flushes the outbox and then delivers ev to every sink that accepts
+// its profile. The returned error lists the sinks that failed; their
+// deliveries are in the outbox by then.
+func (n *Notifier) Notify(ctx context.Context, ev *Event) error {
+	var errs []error
+	if _, err := n.Flush(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	for i := range n.cfg.Sinks {
+		sink := &n.cfg.Sinks[i]
+		if !sink.accepts(ev.Profile) {
+			continue
+		}
+		attempts, err := n.deliverWithRetry(ctx, sink, ev)
+		if err == nil {
+			continue
+		}
+		errs = append(errs, err)
+		if qerr := n.enqueue(&pending{Sink: sink.Name, Event: ev, Attempts: attempts, LastError: err.Error()}); qerr != nil {
+			errs = append(errs, qerr)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Flush tries every outbox entry once, oldest first, and returns how many
+// are still pending.
+func (n *Notifier) Flush(ctx context.Context) (int, error) {
+	if n.outbox == "" {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(n.outbox)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	left := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(n.outbox, entry.Name())
+		p, err := readPending(path)
+		if err != nil {
+			errs = append(errs, err)
+			left++
+			continue
+		}
+		idx := slices.IndexFunc(n.cfg.Sinks, func(s Sink) bool { return s.Name == p.Sink })
+		if idx < 0 {
+			// The sink was removed from the config; keep the entry for
+			// when it comes back rather than losing it.
+			left++
+			continue
+		}
+		p.Attempts++
+		if err := n.deliver(ctx, &n.cfg.Sinks[idx], p.Event); err != nil {
+			p.LastError = err.Error()
+			errs = append(errs, err)
+			left++
+			if werr := writePending(path, p); werr != nil {
+				errs = append(errs, werr)
+			}
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return left, errors.Join(errs...)
+}
+
+func (n *Notifier) deliverWithRetry(ctx context.Context, sink *Sink, ev *Event) (int, error) {
+	retries := defaultRetries
+	if n.cfg.Retries != nil {
+		retries = *n.cfg.Retries
+	}
+	backoff := n.cfg.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = n.deliver(ctx, sink, ev); err == nil || attempt > retries {
+			return attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff << (attempt - 1)):
+		}
+	}
+}
+
+func (n *Notifier) deliver(ctx context.Context, sink *Sink, ev *Event) error {
+	req, err := sink.request(ctx, ev, n.now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", sink.Name, err)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", sink.Name, err)
+	}
+	defer resp.Body.Close()
+	return sink.checkResponse(resp)
+}
+
+func (n *Notifier) enqueue(p *pending) error {
+	if n.outbox == "" {
+		return nil
+	}
+	if err := os.MkdirAll(n.outbox, 0o700); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%04x.json", n.now().UTC().Format("20060102T150405.000000000"), rand.IntN(0x10000))
+	return writePending(filepath.Join(n.outbox, name), p)
+}
+
+func readPending(path string) (*pending, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p pending
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("corrupt outbox entry %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+func writePending(path string, p *pending) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
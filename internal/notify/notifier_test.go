@@ -0,0 +1,167 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// receiver is a local HTTP endpoint that records deliveries and fails the
+// first failures requests.
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, _ := io.ReadAll(req.Body)
+	if r.failures > 0 {
+		r.failures--
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	_, _ = w.Write([]byte(`{"errcode":0,"code":0}`))
+}
+
+func testEvent() *Event {
+	return &Event{
+		Time:      time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+		Operation: "add",
+		Success:   true,
+		Domain:    "example.com",
+		Record:    "www.example.com A 1.2.3.4",
+		Actor:     "alice",
+		Profile:   "LTAI****1234",
+		RequestId: "req-1",
+	}
+}
+
+func noRetries() *int {
+	n := 0
+	return &n
+}
+
+func TestNotifySignsWebhookAndRobotMessages(t *testing.T) {
+	recv := &receiver{}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+	n := New(&Config{Retries: noRetries(), Sinks: []Sink{
+		{Name: "hook", Type: SinkWebhook, URL: srv.URL + "/hook", Secret: "s3cret"},
+		{Name: "slack", Type: SinkSlack, URL: srv.URL + "/slack"},
+		{Name: "ding", Type: SinkDingTalk, URL: srv.URL + "/ding?access_token=t", Secret: "SEC1"},
+		{Name: "feishu", Type: SinkFeishu, URL: srv.URL + "/feishu", Secret: "SEC2"},
+		{Name: "other", Type: SinkSlack, URL: srv.URL + "/other", Profiles: []string{"LTAI****9999"}},
+	}}, "")
+
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if len(recv.requests) != 4 {
+		t.Fatalf("expected 4 deliveries, got %d", len(recv.requests))
+	}
+
+	if got, want := recv.requests[0].Header.Get(SignatureHeader), Sign("s3cret", recv.bodies[0]); got != want {
+		t.Fatalf("webhook signature = %q, want %q", got, want)
+	}
+	var ev Event
+	if err := json.Unmarshal(recv.bodies[0], &ev); err != nil || ev.RequestId != "req-1" || ev.Actor != "alice" {
+		t.Fatalf("unexpected webhook body %s: %v", recv.bodies[0], err)
+	}
+
+	if !strings.Contains(string(recv.bodies[1]), "www.example.com A 1.2.3.4") {
+		t.Fatalf("unexpected slack body %s", recv.bodies[1])
+	}
+
+	q := recv.requests[2].URL.Query()
+	mac := hmac.New(sha256.New, []byte("SEC1"))
+	mac.Write([]byte(q.Get("timestamp") + "\n" + "SEC1"))
+	if q.Get("access_token") != "t" || q.Get("sign") != base64.StdEncoding.EncodeToString(mac.Sum(nil)) {
+		t.Fatalf("unexpected dingtalk query %v", q)
+	}
+
+	var feishu struct{ Timestamp, Sign string }
+	_ = json.Unmarshal(recv.bodies[3], &feishu)
+	mac = hmac.New(sha256.New, []byte(feishu.Timestamp+"\n"+"SEC2"))
+	if feishu.Sign != base64.StdEncoding.EncodeToString(mac.Sum(nil)) {
+		t.Fatalf("unexpected feishu body %s", recv.bodies[3])
+	}
+}
+
+func TestNotifyKeepsFailedDeliveriesInOutbox(t *testing.T) {
+	recv := &receiver{failures: 2}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+	outbox := filepath.Join(t.TempDir(), "outbox")
+	retries := 1
+	n := New(&Config{Retries: &retries, Backoff: time.Millisecond, Sinks: []Sink{
+		{Name: "hook", Type: SinkWebhook, URL: srv.URL},
+	}}, outbox)
+
+	if err := n.Notify(context.Background(), testEvent()); err == nil {
+		t.Fatal("expected Notify to report the failed delivery")
+	}
+	files, _ := os.ReadDir(outbox)
+	if len(files) != 1 || len(recv.requests) != 0 {
+		t.Fatalf("expected one outbox entry and no delivery, got %d files and %d deliveries", len(files), len(recv.requests))
+	}
+
+	left, err := n.Flush(context.Background())
+	if err != nil || left != 0 {
+		t.Fatalf("Flush = %d, %v", left, err)
+	}
+	files, _ = os.ReadDir(outbox)
+	if len(files) != 0 || len(recv.requests) != 1 {
+		t.Fatalf("expected the outbox to be delivered, got %d files and %d deliveries", len(files), len(recv.requests))
+	}
+}
+
+func TestLoadConfigValidatesSinks(t *testing.T) {
+	dir := t.TempDir()
+	if cfg, err := LoadConfig(filepath.Join(dir, "missing.yaml")); cfg != nil || err != nil {
+		t.Fatalf("missing config should disable notifications, got %v, %v", cfg, err)
+	}
+
+	path := filepath.Join(dir, "notify.yaml")
+	if err := os.WriteFile(path, []byte("sinks:\n  - type: email\n    url: x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "unsupported type") {
+		t.Fatalf("expected unsupported type error, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("retries: 0\nbackoff: 2s\nsinks:\n  - type: slack\n    url: https://hooks.slack.com/x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil || *cfg.Retries != 0 || cfg.Backoff != 2*time.Second || cfg.Sinks[0].Name != "slack" {
+		t.Fatalf("unexpected config %+v, %v", cfg, err)
+	}
+}
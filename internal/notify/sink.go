@@ -0,0 +1,119 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of a webhook body, keyed with
+// the sink secret, as "sha256=<hex>".
+const SignatureHeader = "X-Alidns-Signature"
+
+// Sign returns the SignatureHeader value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// request builds the HTTP request that delivers ev to the sink.
+func (s *Sink) request(ctx context.Context, ev *Event, now time.Time) (*http.Request, error) {
+	target := s.URL
+	var payload any
+	switch s.Type {
+	case SinkWebhook:
+		payload = ev
+	case SinkSlack:
+		payload = map[string]any{"text": ev.Text()}
+	case SinkDingTalk:
+		payload = map[string]any{"msgtype": "text", "text": map[string]string{"content": ev.Text()}}
+		if s.Secret != "" {
+			u, err := url.Parse(s.URL)
+			if err != nil {
+				return nil, err
+			}
+			timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+			mac := hmac.New(sha256.New, []byte(s.Secret))
+			mac.Write([]byte(timestamp + "\n" + s.Secret))
+			q := u.Query()
+			q.Set("timestamp", timestamp)
+			q.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+			u.RawQuery = q.Encode()
+			target = u.String()
+		}
+	case SinkFeishu:
+		body := map[string]any{"msg_type": "text", "content": map[string]string{"text": ev.Text()}}
+		if s.Secret != "" {
+			timestamp := strconv.FormatInt(now.Unix(), 10)
+			// Feishu keys the HMAC with the string to sign over an empty message.
+			mac := hmac.New(sha256.New, []byte(timestamp+"\n"+s.Secret))
+			body["timestamp"] = timestamp
+			body["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		}
+		payload = body
+	default:
+		return nil, fmt.Errorf("unsupported sink type %q", s.Type)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Type == SinkWebhook && s.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.Secret, body))
+	}
+	return req, nil
+}
+
+// checkResponse treats non-2xx statuses as failures, as well as robot
+// replies whose error code is set despite a 200.
+func (s *Sink) checkResponse(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: HTTP %d: %s", s.Name, resp.StatusCode, bytes.TrimSpace(body))
+	}
+	var reply struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+		Code    int    `json:"code"`
+		Msg     string `json:"msg"`
+	}
+	switch s.Type {
+	case SinkDingTalk:
+		if json.Unmarshal(body, &reply) == nil && reply.ErrCode != 0 {
+			return fmt.Errorf("%s: errcode %d: %s", s.Name, reply.ErrCode, reply.ErrMsg)
+		}
+	case SinkFeishu:
+		if json.Unmarshal(body, &reply) == nil && reply.Code != 0 {
+			return fmt.Errorf("%s: code %d: %s", s.Name, reply.Code, reply.Msg)
+		}
+	}
+	return nil
+}
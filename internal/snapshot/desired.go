@@ -0,0 +1,113 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+	"gopkg.in/yaml.v3"
+)
+
+// desiredFile is the hand-written zone description, e.g.
+//
+//	domain: example.com
+//	records:
+//	  - name: www
+//	    type: A
+//	    value: 1.2.3.4
+//	    ttl: 600
+type desiredFile struct {
+	Domain  string          `yaml:"domain"`
+	Records []desiredRecord `yaml:"records"`
+}
+
+type desiredRecord struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Value    string `yaml:"value"`
+	TTL      int64  `yaml:"ttl"`
+	Priority int64  `yaml:"priority"`
+	Line     string `yaml:"line"`
+	Status   string `yaml:"status"`
+	Remark   string `yaml:"remark"`
+}
+
+// Load reads a snapshot, or a desired-state YAML file when path ends in
+// .yaml or .yml. Fields omitted in YAML take the defaults add would use.
+func Load(path string) (*Snapshot, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	default:
+		return Read(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file desiredFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid desired state %s: %w", path, err)
+	}
+
+	records := make([]*alidns.Record, 0, len(file.Records))
+	for i, r := range file.Records {
+		if r.Name == "" || r.Type == "" || r.Value == "" {
+			return nil, fmt.Errorf("%s: record %d needs name, type and value", path, i+1)
+		}
+		record, err := r.record()
+		if err != nil {
+			return nil, fmt.Errorf("%s: record %d: %w", path, i+1, err)
+		}
+		records = append(records, record)
+	}
+	return &Snapshot{Version: Version, Domain: file.Domain, Records: records}, nil
+}
+
+func (r desiredRecord) record() (*alidns.Record, error) {
+	record := &alidns.Record{
+		RR:    tea.String(r.Name),
+		Type:  tea.String(strings.ToUpper(r.Type)),
+		Value: tea.String(r.Value),
+		TTL:   tea.Int64(600),
+		Line:  tea.String("default"),
+	}
+	if r.TTL > 0 {
+		record.TTL = tea.Int64(r.TTL)
+	}
+	if r.Line != "" {
+		record.Line = tea.String(r.Line)
+	}
+	if r.Remark != "" {
+		record.Remark = tea.String(r.Remark)
+	}
+	if r.Priority > 0 {
+		record.Priority = tea.Int64(r.Priority)
+	} else if strings.EqualFold(r.Type, "MX") {
+		record.Priority = tea.Int64(1)
+	}
+
+	switch strings.ToLower(r.Status) {
+	case "", "enable":
+		record.Status = tea.String("ENABLE")
+	case "disable":
+		record.Status = tea.String("DISABLE")
+	default:
+		return nil, fmt.Errorf("invalid status %q, expected enable|disable", r.Status)
+	}
+	return record, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package snapshot reads and writes point-in-time copies of a zone.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+// Version is the snapshot format written by this build. Read rejects newer
+// formats.
+const Version = 1
+
+type Snapshot struct {
+	Version   int
+	CreatedAt time.Time
+	Domain    string
+	Records   []*alidns.Record
+}
+
+func New(domain string, records []*alidns.Record) *Snapshot {
+	if records == nil {
+		records = []*alidns.Record{}
+	}
+	return &Snapshot{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Domain:    domain,
+		Records:   records,
+	}
+}
+
+// WriteDir stores s in dir as DOMAIN-TIMESTAMP.json and returns the path.
+func (s *Snapshot) WriteDir(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%s.json", s.Domain, s.CreatedAt.Format("20060102T150405Z"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func Read(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if s.Version < 1 || s.Version > Version {
+		return nil, fmt.Errorf("snapshot %s has unsupported version %d", path, s.Version)
+	}
+	if s.Domain == "" {
+		return nil, fmt.Errorf("snapshot %s has no domain", path)
+	}
+	return &s, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+func TestWriteDirAndReadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	records := []*alidns.Record{{RecordId: tea.String("1"), RR: tea.String("www"), Type: tea.String("A"), Value: tea.String("1.2.3.4")}}
+
+	path, err := New("example.com", records).WriteDir(dir)
+	if err != nil {
+		t.Fatalf("WriteDir returned error: %v", err)
+	}
+	if !strings.HasPrefix(filepath.Base(path), "example.com-") {
+		t.Fatalf("unexpected snapshot path %q", path)
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if got.Version != Version || got.Domain != "example.com" || len(got.Records) != 1 || tea.StringValue(got.Records[0].Value) != "1.2.3.4" {
+		t.Fatalf("unexpected snapshot: %+v", got)
+	}
+}
+
+func TestReadRejectsUnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "s.json")
+	if err := os.WriteFile(path, []byte(`{"Version":99,"Domain":"example.com"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path); err == nil || !strings.Contains(err.Error(), "unsupported version") {
+		t.Fatalf("expected version error, got %v", err)
+	}
+}
+
+func TestLoadDesiredYAMLAppliesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desired.yaml")
+	data := "domain: example.com\nrecords:\n  - name: \"@\"\n    type: mx\n    value: mx.example.com\n  - name: old\n    type: A\n    value: 1.2.3.4\n    ttl: 60\n    status: disable\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got.Domain != "example.com" || len(got.Records) != 2 {
+		t.Fatalf("unexpected desired state: %+v", got)
+	}
+	mx, a := got.Records[0], got.Records[1]
+	if tea.StringValue(mx.Type) != "MX" || tea.Int64Value(mx.TTL) != 600 || tea.Int64Value(mx.Priority) != 1 || tea.StringValue(mx.Line) != "default" || tea.StringValue(mx.Status) != "ENABLE" {
+		t.Fatalf("unexpected defaults: %+v", mx)
+	}
+	if tea.Int64Value(a.TTL) != 60 || tea.StringValue(a.Status) != "DISABLE" || a.Priority != nil {
+		t.Fatalf("unexpected record: %+v", a)
+	}
+}
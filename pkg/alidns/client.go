@@ -0,0 +1,248 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package alidns is a Go library for Alidns records, independent of the
+// alidns command. It works with the provider-neutral Record type instead
+// of the SDK structs used inside the command.
+//
+// The exported API is versioned by Version and follows semantic
+// versioning: within major version 1, identifiers are only added, never
+// changed or removed.
+package alidns
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	core "github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// Version is the version of this package's API.
+const Version = "1.0.0"
+
+// Option configures New.
+type Option func(*config)
+
+type config struct {
+	accessKeyID     string
+	accessKeySecret string
+	client          core.ClientConfig
+	middleware      []Middleware
+	// api replaces the SDK client, for tests.
+	api core.DNSAPI
+}
+
+// WithCredentials sets the access key. Without it New reads
+// ALIBABA_CLOUD_ACCESS_KEY_ID and ALIBABA_CLOUD_ACCESS_KEY_SECRET (or
+// ALICLOUD_ACCESS_KEY and ALICLOUD_SECRET_KEY), like the command's ACME hook
+// mode.
+func WithCredentials(accessKeyID, accessKeySecret string) Option {
+	return func(c *config) {
+		c.accessKeyID, c.accessKeySecret = accessKeyID, accessKeySecret
+	}
+}
+
+// WithEndpoint overrides the API endpoint, alidns.cn-hangzhou.aliyuncs.com
+// by default.
+func WithEndpoint(endpoint string) Option {
+	return func(c *config) {
+		c.client.Endpoint = endpoint
+	}
+}
+
+// WithTimeouts sets the connect and read timeouts of API requests. Zero
+// keeps the SDK default.
+func WithTimeouts(connect, read time.Duration) Option {
+	return func(c *config) {
+		c.client.ConnectTimeout, c.client.ReadTimeout = connect, read
+	}
+}
+
+// WithMiddleware passes every API call of the client through mws, the
+// first middleware outermost. Options add to the chain in order.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(c *config) {
+		c.middleware = append(c.middleware, mws...)
+	}
+}
+
+func withAPI(api core.DNSAPI) Option {
+	return func(c *config) {
+		c.api = api
+	}
+}
+
+// Client manages the records of the domains in one account. It is safe for
+// concurrent use.
+type Client struct {
+	svc *core.Service
+}
+
+func New(opts ...Option) (*Client, error) {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	api := c.api
+	if api == nil {
+		ak := firstNonEmpty(c.accessKeyID, os.Getenv("ALIBABA_CLOUD_ACCESS_KEY_ID"), os.Getenv("ALICLOUD_ACCESS_KEY"))
+		sk := firstNonEmpty(c.accessKeySecret, os.Getenv("ALIBABA_CLOUD_ACCESS_KEY_SECRET"), os.Getenv("ALICLOUD_SECRET_KEY"))
+		client, err := core.CreateClientWithConfig(ak, sk, c.client)
+		if err != nil {
+			return nil, err
+		}
+		api = core.NewSDKClient(client)
+	}
+	return &Client{svc: core.NewService(core.Wrap(api, c.middleware...))}, nil
+}
+
+// Domains returns the names of every domain in the account.
+func (c *Client) Domains(ctx context.Context) ([]string, error) {
+	domains, err := c.svc.Domains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(domains))
+	for _, d := range domains {
+		names = append(names, normalizeDomain(tea.StringValue(d.DomainName)))
+	}
+	return names, nil
+}
+
+// Records returns every record of a domain, including disabled ones.
+func (c *Client) Records(ctx context.Context, domain string) ([]Record, error) {
+	domain = normalizeDomain(domain)
+	records, err := c.svc.Records(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return fromCoreAll(domain, records), nil
+}
+
+// Find returns the records of one name and type. name is relative to domain
+// or, with a trailing dot, fully qualified.
+func (c *Client) Find(ctx context.Context, domain, name, rType string) ([]Record, error) {
+	domain = normalizeDomain(domain)
+	records, err := c.svc.Find(ctx, core.FindInput{DomainName: domain, Name: normalizeName(domain, name), Type: strings.ToUpper(rType)})
+	if err != nil {
+		return nil, err
+	}
+	return fromCoreAll(domain, records), nil
+}
+
+// Get returns a record by ID.
+func (c *Client) Get(ctx context.Context, id string) (Record, error) {
+	info, err := c.svc.RecordInfo(ctx, id)
+	if err != nil {
+		return Record{}, err
+	}
+	return fromCore("", core.RecordFromInfo(info)), nil
+}
+
+// Add creates a record and returns it with its ID. Enabled, Weight and ID
+// are ignored; new records are enabled.
+func (c *Client) Add(ctx context.Context, r Record) (Record, error) {
+	r = normalize(r)
+	value, priority := r.data()
+	resp, err := c.svc.Add(ctx, core.AddInput{
+		DomainName: r.Domain,
+		Name:       r.Name,
+		Type:       r.Type,
+		Value:      value,
+		TTL:        seconds(r.TTL),
+		Priority:   priority,
+		Line:       r.Line,
+		Remark:     r.Remark,
+	})
+	if err != nil {
+		return Record{}, err
+	}
+	r.ID = tea.StringValue(resp.RecordId)
+	r.Value, r.Priority, r.Enabled = value, priority, true
+	return r, nil
+}
+
+// Update replaces the name, type, value, TTL, priority and line of the
+// record with ID r.ID. A zero TTL, priority or line keeps the current one.
+// Remark and Enabled are changed with SetRemark and SetEnabled.
+func (c *Client) Update(ctx context.Context, r Record) (Record, error) {
+	if r.ID == "" {
+		return Record{}, fmt.Errorf("record ID is required")
+	}
+	r = normalize(r)
+	value, priority := r.data()
+	if _, err := c.svc.Update(ctx, core.UpdateInput{
+		RecordID: r.ID,
+		Name:     r.Name,
+		Type:     r.Type,
+		Value:    value,
+		TTL:      seconds(r.TTL),
+		Priority: priority,
+		Line:     r.Line,
+	}); err != nil {
+		return Record{}, err
+	}
+	r.Value, r.Priority = value, priority
+	return r, nil
+}
+
+func (c *Client) SetRemark(ctx context.Context, id, remark string) error {
+	_, err := c.svc.SetRemark(ctx, id, remark)
+	return err
+}
+
+func (c *Client) SetEnabled(ctx context.Context, id string, enabled bool) error {
+	_, err := c.svc.SetStatus(ctx, id, enabled)
+	return err
+}
+
+func (c *Client) Delete(ctx context.Context, id string) error {
+	_, err := c.svc.DeleteRecord(ctx, id)
+	return err
+}
+
+// ErrorCode returns the Alidns error code carried by err, such as
+// Throttling.User, or "" when err did not come from the API.
+func ErrorCode(err error) string {
+	return core.ErrorCode(err)
+}
+
+func normalize(r Record) Record {
+	r.Domain = normalizeDomain(r.Domain)
+	r.Name = normalizeName(r.Domain, r.Name)
+	r.Type = strings.ToUpper(r.Type)
+	return r
+}
+
+func fromCoreAll(domain string, records []*core.Record) []Record {
+	out := make([]Record, 0, len(records))
+	for _, r := range records {
+		out = append(out, fromCore(domain, r))
+	}
+	return out
+}
+
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	core "github.com/Joey-Kot/alidns/internal/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+type fakeAPI struct {
+	core.DNSAPI
+
+	records []*core.Record
+	addReq  *alidns20150109.AddDomainRecordRequest
+	update  *alidns20150109.UpdateDomainRecordRequest
+}
+
+func (f *fakeAPI) DescribeDomainRecords(_ context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*core.Record, error) {
+	if tea.Int64Value(req.PageNumber) > 1 {
+		return nil, nil
+	}
+	return f.records, nil
+}
+
+func (f *fakeAPI) AddDomainRecord(_ context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	f.addReq = req
+	return &alidns20150109.AddDomainRecordResponseBody{RecordId: tea.String("r-new")}, nil
+}
+
+func (f *fakeAPI) DescribeDomainRecordInfo(_ context.Context, req *alidns20150109.DescribeDomainRecordInfoRequest) (*alidns20150109.DescribeDomainRecordInfoResponseBody, error) {
+	return &alidns20150109.DescribeDomainRecordInfoResponseBody{
+		RecordId: req.RecordId, DomainName: tea.String("example.com"), RR: tea.String("@"), Type: tea.String("CAA"),
+		Value: tea.String(`0 issue "example.net"`), TTL: tea.Int64(300), Line: tea.String("unicom"),
+	}, nil
+}
+
+func (f *fakeAPI) UpdateDomainRecord(_ context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+	f.update = req
+	return &alidns20150109.UpdateDomainRecordResponseBody{}, nil
+}
+
+func record(id, rr, rType, value string, priority int64, status string) *core.Record {
+	return &core.Record{
+		RecordId: tea.String(id), DomainName: tea.String("example.com"), RR: tea.String(rr), Type: tea.String(rType),
+		Value: tea.String(value), TTL: tea.Int64(600), Priority: tea.Int64(priority), Line: tea.String("default"),
+		Status: tea.String(status), Weight: tea.Int32(1),
+	}
+}
+
+func TestRecordsParsesTypedFields(t *testing.T) {
+	api := &fakeAPI{records: []*core.Record{
+		record("r-1", "WWW", "A", "192.0.2.1", 0, "ENABLE"),
+		record("r-2", "@", "MX", "mx.example.net", 10, "DISABLE"),
+		record("r-3", "_sip._tcp", "SRV", "10 60 5060 sip.example.com", 0, "ENABLE"),
+		record("r-4", "@", "CAA", `0 issue "letsencrypt.org"`, 0, "ENABLE"),
+	}}
+	c, err := New(withAPI(api))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.Records(context.Background(), "Example.COM.")
+	if err != nil {
+		t.Fatalf("Records returned error: %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(got))
+	}
+	if r := got[0]; r.Name != "www" || r.FQDN() != "www.example.com" || r.TTL != 10*time.Minute || !r.Enabled || r.Weight != 1 {
+		t.Fatalf("unexpected A record: %+v", r)
+	}
+	if r := got[1]; r.Enabled || !reflect.DeepEqual(r.MX, &MX{Preference: 10, Host: "mx.example.net"}) || r.FQDN() != "example.com" {
+		t.Fatalf("unexpected MX record: %+v", r)
+	}
+	if r := got[2]; !reflect.DeepEqual(r.SRV, &SRV{Priority: 10, Weight: 60, Port: 5060, Target: "sip.example.com"}) {
+		t.Fatalf("unexpected SRV record: %+v", r.SRV)
+	}
+	if r := got[3]; !reflect.DeepEqual(r.CAA, &CAA{Flags: 0, Tag: "issue", Value: "letsencrypt.org"}) {
+		t.Fatalf("unexpected CAA record: %+v", r.CAA)
+	}
+}
+
+func TestAddAndUpdateFormatTypedFields(t *testing.T) {
+	api := &fakeAPI{}
+	c, err := New(withAPI(api))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := c.Add(context.Background(), Record{
+		Domain: "example.com.",
+		Name:   "Mail.Example.com.",
+		Type:   "mx",
+		TTL:    time.Hour,
+		MX:     &MX{Preference: 5, Host: "mx.example.net."},
+	})
+	if err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	req := api.addReq
+	if tea.StringValue(req.RR) != "mail" || tea.StringValue(req.Type) != "MX" || tea.StringValue(req.Value) != "mx.example.net" ||
+		tea.Int64Value(req.Priority) != 5 || tea.Int64Value(req.TTL) != 3600 {
+		t.Fatalf("unexpected add request: %+v", req)
+	}
+	if added.ID != "r-new" || added.Name != "mail" || added.Priority != 5 || !added.Enabled {
+		t.Fatalf("unexpected added record: %+v", added)
+	}
+
+	if _, err := c.Update(context.Background(), Record{Domain: "example.com", Name: "@", Type: "CAA", CAA: &CAA{Tag: "issue", Value: "letsencrypt.org"}}); err == nil || !strings.Contains(err.Error(), "ID") {
+		t.Fatalf("expected missing ID error, got %v", err)
+	}
+	if _, err := c.Update(context.Background(), Record{ID: "r-4", Domain: "example.com", Type: "CAA", CAA: &CAA{Tag: "issue", Value: "letsencrypt.org"}}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if got := tea.StringValue(api.update.Value); got != `0 issue "letsencrypt.org"` || tea.StringValue(api.update.RR) != "@" {
+		t.Fatalf("unexpected update request: %+v", api.update)
+	}
+	if tea.Int64Value(api.update.TTL) != 300 || tea.StringValue(api.update.Line) != "unicom" {
+		t.Fatalf("update without TTL and line should keep the current ones: %+v", api.update)
+	}
+}
+
+func TestNewRequiresCredentials(t *testing.T) {
+	for _, key := range []string{"ALIBABA_CLOUD_ACCESS_KEY_ID", "ALIBABA_CLOUD_ACCESS_KEY_SECRET", "ALICLOUD_ACCESS_KEY", "ALICLOUD_SECRET_KEY"} {
+		t.Setenv(key, "")
+	}
+	if _, err := New(); err == nil || !strings.Contains(err.Error(), "AccessKeyId") {
+		t.Fatalf("expected missing credentials error, got %v", err)
+	}
+	if _, err := New(WithCredentials("ak", "sk"), WithEndpoint("alidns.cn-shanghai.aliyuncs.com"), WithTimeouts(time.Second, 5*time.Second)); err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+}
+
+func TestWithMiddlewareWrapsCalls(t *testing.T) {
+	api := &fakeAPI{records: []*core.Record{record("r-1", "www", "A", "1.2.3.4", 0, "ENABLE")}}
+	var ops []string
+	c, err := New(withAPI(api), WithMiddleware(
+		Observe(func(op string, _ time.Duration, _ error) { ops = append(ops, op) }),
+		ReadOnly(),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Records(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Records returned error: %v", err)
+	}
+	if _, err := c.Add(context.Background(), Record{Domain: "example.com", Name: "www", Type: "A", Value: "5.6.7.8"}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	if api.addReq != nil {
+		t.Fatal("Add should not reach the API")
+	}
+	if want := []string{"DescribeDomainRecords", "AddDomainRecord"}; !reflect.DeepEqual(ops, want) {
+		t.Fatalf("observed %v, want %v", ops, want)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	core "github.com/Joey-Kot/alidns/internal/alidns"
+)
+
+// Call is one API operation on its way through the middleware chain: the
+// operation name, such as AddDomainRecord, and its SDK request.
+type Call = core.Call
+
+// Handler performs a call and returns the SDK response of the operation.
+type Handler = core.Handler
+
+// Middleware decorates a Handler. The alidns command accepts the same
+// middlewares, so behavior written once applies to both.
+type Middleware = core.Middleware
+
+// ErrReadOnly is returned by ReadOnly for operations that would change
+// something.
+var ErrReadOnly = core.ErrReadOnly
+
+// Observe reports the operation, duration and error of every call.
+func Observe(fn func(op string, d time.Duration, err error)) Middleware {
+	return core.Observe(fn)
+}
+
+// Log writes reads at debug level, changes with their request at info level
+// and failures with the API error code at warn level.
+func Log(logger *slog.Logger) Middleware {
+	return core.Log(logger)
+}
+
+// Policy runs check before every call and refuses the call with its error.
+func Policy(check func(ctx context.Context, call *Call) error) Middleware {
+	return core.Policy(check)
+}
+
+// ReadOnly refuses every operation that would change something.
+func ReadOnly() Middleware {
+	return core.ReadOnly()
+}
+
+// RateLimit spaces calls at least 1/perSecond apart.
+func RateLimit(perSecond float64) Middleware {
+	return core.RateLimit(perSecond)
+}
+
+// Retry runs a call up to attempts times while it is throttled or the
+// service is unavailable, doubling backoff between attempts.
+func Retry(attempts int, backoff time.Duration) Middleware {
+	return core.Retry(attempts, backoff)
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package alidns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	core "github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// Record is a DNS record with plain value fields. Domain is lower-case ASCII
+// without a trailing dot and Name is relative to it, "@" for the apex.
+type Record struct {
+	ID     string
+	Domain string
+	Name   string
+	Type   string
+	// Value is the record data as Alidns stores it. For MX it is the host
+	// only; the preference is Priority.
+	Value    string
+	TTL      time.Duration
+	Priority int64
+	// Line is the resolution line, "default" unless set.
+	Line string
+	// Weight is the weighted round-robin weight, 0 when not reported.
+	Weight  int
+	Enabled bool
+	Remark  string
+
+	// MX, SRV and CAA hold Value parsed for records of that type and are nil
+	// otherwise. When adding or updating a record they take precedence over
+	// Value and Priority.
+	MX  *MX
+	SRV *SRV
+	CAA *CAA
+}
+
+type MX struct {
+	Preference uint16
+	Host       string
+}
+
+type SRV struct {
+	Priority uint16
+	Weight   uint16
+	Port     uint16
+	Target   string
+}
+
+type CAA struct {
+	Flags uint8
+	Tag   string
+	Value string
+}
+
+// FQDN returns the full name of the record.
+func (r Record) FQDN() string {
+	return core.JoinFQDN(r.Domain, r.Name)
+}
+
+// data returns the value and priority to send to Alidns.
+func (r Record) data() (string, int64) {
+	switch {
+	case r.MX != nil:
+		return strings.TrimSuffix(r.MX.Host, "."), int64(r.MX.Preference)
+	case r.SRV != nil:
+		return fmt.Sprintf("%d %d %d %s", r.SRV.Priority, r.SRV.Weight, r.SRV.Port, strings.TrimSuffix(r.SRV.Target, ".")), r.Priority
+	case r.CAA != nil:
+		return fmt.Sprintf("%d %s %q", r.CAA.Flags, r.CAA.Tag, r.CAA.Value), r.Priority
+	}
+	return r.Value, r.Priority
+}
+
+func fromCore(domain string, r *core.Record) Record {
+	if d := tea.StringValue(r.DomainName); d != "" {
+		domain = d
+	}
+	rec := Record{
+		ID:       tea.StringValue(r.RecordId),
+		Domain:   normalizeDomain(domain),
+		Name:     strings.ToLower(tea.StringValue(r.RR)),
+		Type:     strings.ToUpper(tea.StringValue(r.Type)),
+		Value:    tea.StringValue(r.Value),
+		TTL:      time.Duration(tea.Int64Value(r.TTL)) * time.Second,
+		Priority: tea.Int64Value(r.Priority),
+		Line:     tea.StringValue(r.Line),
+		Weight:   int(tea.Int32Value(r.Weight)),
+		Enabled:  r.Status == nil || strings.EqualFold(tea.StringValue(r.Status), "ENABLE"),
+		Remark:   tea.StringValue(r.Remark),
+	}
+	switch rec.Type {
+	case "MX":
+		rec.MX = &MX{Preference: uint16(rec.Priority), Host: rec.Value}
+	case "SRV":
+		rec.SRV = parseSRV(rec.Value)
+	case "CAA":
+		rec.CAA = parseCAA(rec.Value)
+	}
+	return rec
+}
+
+// parseSRV reads "priority weight port target", or returns nil.
+func parseSRV(value string) *SRV {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return nil
+	}
+	var nums [3]uint16
+	for i := range nums {
+		n, err := strconv.ParseUint(fields[i], 10, 16)
+		if err != nil {
+			return nil
+		}
+		nums[i] = uint16(n)
+	}
+	return &SRV{Priority: nums[0], Weight: nums[1], Port: nums[2], Target: fields[3]}
+}
+
+// parseCAA reads "flags tag value" with the value quoted or not, or returns
+// nil.
+func parseCAA(value string) *CAA {
+	fields := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(fields) != 3 {
+		return nil
+	}
+	flags, err := strconv.ParseUint(fields[0], 10, 8)
+	if err != nil {
+		return nil
+	}
+	v := strings.TrimSpace(fields[2])
+	if unquoted, err := strconv.Unquote(v); err == nil {
+		v = unquoted
+	}
+	return &CAA{Flags: uint8(flags), Tag: fields[1], Value: v}
+}
+
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if ascii, err := core.ToASCII(domain); err == nil {
+		return ascii
+	}
+	return domain
+}
+
+// normalizeName makes name relative to domain. An empty name is the apex;
+// a name with a trailing dot is taken as fully qualified.
+func normalizeName(domain, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "@" {
+		return "@"
+	}
+	if !strings.HasSuffix(name, ".") {
+		return name
+	}
+	if _, rr, ok := core.MatchFQDN(name, []string{domain}); ok {
+		return rr
+	}
+	return strings.TrimSuffix(name, ".")
+}
@@ -0,0 +1,441 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+// Package libdns implements the libdns interfaces on top of the pkg/alidns
+// client of this module, so that Caddy, certmagic and other libdns consumers
+// manage records exactly the way the alidns command does.
+package libdns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Joey-Kot/alidns/pkg/alidns"
+	"github.com/libdns/libdns"
+)
+
+// Provider manages the records of Alidns domains. Zones are domain names;
+// a trailing dot is accepted. Changes are made one record at a time, so a
+// failed SetRecords may leave part of its changes applied.
+//
+// libdns has no notion of resolution lines: records are added to the default
+// line, and SetRecords and DeleteRecords leave the records of other lines
+// (split-horizon answers for telecom, overseas and so on) alone.
+//
+// The client is built with alidns.New from the fields below on first use,
+// so the credential chain and endpoint are those of the alidns package.
+type Provider struct {
+	// AccessKeyID and AccessKeySecret default to ALIBABA_CLOUD_ACCESS_KEY_ID
+	// and ALIBABA_CLOUD_ACCESS_KEY_SECRET (or ALICLOUD_ACCESS_KEY and
+	// ALICLOUD_SECRET_KEY), like the ACME hook mode of the command.
+	AccessKeyID     string `json:"access_key_id,omitempty"`
+	AccessKeySecret string `json:"access_key_secret,omitempty"`
+	// Endpoint overrides the API endpoint, alidns.cn-hangzhou.aliyuncs.com
+	// by default.
+	Endpoint string `json:"endpoint,omitempty"`
+	// ConnectTimeout and ReadTimeout bound each API request. Zero keeps the
+	// SDK defaults. In JSON they are nanoseconds.
+	ConnectTimeout time.Duration `json:"connect_timeout,omitempty"`
+	ReadTimeout    time.Duration `json:"read_timeout,omitempty"`
+	// Retries is how often a throttled or unavailable call is sent again,
+	// waiting RetryBackoff (1s by default) before the first retry and
+	// doubling it after. Zero disables retries, as in the command.
+	Retries      int           `json:"retries,omitempty"`
+	RetryBackoff time.Duration `json:"retry_backoff,omitempty"`
+	// Middleware wraps every API call inside the retries, so it sees each
+	// attempt. It can only be set from Go.
+	Middleware []alidns.Middleware `json:"-"`
+
+	mu     sync.Mutex
+	client *alidns.Client
+}
+
+const (
+	// defaultLine is the line libdns records live on.
+	defaultLine         = "default"
+	defaultRetryBackoff = time.Second
+)
+
+var (
+	_ libdns.RecordGetter   = (*Provider)(nil)
+	_ libdns.RecordAppender = (*Provider)(nil)
+	_ libdns.RecordSetter   = (*Provider)(nil)
+	_ libdns.RecordDeleter  = (*Provider)(nil)
+	_ libdns.ZoneLister     = (*Provider)(nil)
+)
+
+// GetRecords returns every record of the zone, including disabled ones.
+func (p *Provider) GetRecords(ctx context.Context, zone string) ([]libdns.Record, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+	records, err := client.Records(ctx, domainOf(zone))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]libdns.Record, 0, len(records))
+	for _, r := range records {
+		out = append(out, fromAlidns(r))
+	}
+	return out, nil
+}
+
+// AppendRecords adds the records and returns them as created.
+func (p *Provider) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+	added := make([]libdns.Record, 0, len(recs))
+	for _, rec := range recs {
+		s, err := specOf(rec.RR(), zone)
+		if err != nil {
+			return added, err
+		}
+		if err := s.add(ctx, client, domainOf(zone)); err != nil {
+			return added, err
+		}
+		added = append(added, s.record())
+	}
+	return added, nil
+}
+
+// SetRecords makes the input records the only ones of their name and type.
+// Existing records are updated in place where possible, so their IDs and
+// remarks survive; surplus ones are deleted and missing ones added.
+func (p *Provider) SetRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+	domain := domainOf(zone)
+	existing, err := client.Records(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make([]spec, 0, len(recs))
+	sets := map[string]bool{}
+	for _, rec := range recs {
+		s, err := specOf(rec.RR(), zone)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, s)
+		sets[s.set()] = true
+	}
+
+	// Keep the records that already match, then reuse the others of the
+	// same set for the inputs that are left.
+	done := make([]bool, len(specs))
+	var spare []alidns.Record
+	for _, r := range existing {
+		if !onDefaultLine(r) || !sets[setOf(r)] {
+			continue
+		}
+		i := indexOf(specs, done, r)
+		if i < 0 {
+			spare = append(spare, r)
+			continue
+		}
+		done[i] = true
+		if specs[i].TTL != 0 && specs[i].TTL != seconds(r.TTL) {
+			if err := specs[i].update(ctx, client, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, s := range specs {
+		if done[i] {
+			continue
+		}
+		if j := spareFor(spare, s); j >= 0 {
+			if err := s.update(ctx, client, spare[j]); err != nil {
+				return nil, err
+			}
+			spare = append(spare[:j], spare[j+1:]...)
+			continue
+		}
+		if err := s.add(ctx, client, domain); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range spare {
+		if err := client.Delete(ctx, r.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	set := make([]libdns.Record, 0, len(specs))
+	for _, s := range specs {
+		set = append(set, s.record())
+	}
+	return set, nil
+}
+
+// DeleteRecords deletes the default-line records matching the input. An
+// empty type, zero TTL or empty data matches any.
+func (p *Provider) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := client.Records(ctx, domainOf(zone))
+	if err != nil {
+		return nil, err
+	}
+	var deleted []libdns.Record
+	for _, r := range existing {
+		if !onDefaultLine(r) {
+			continue
+		}
+		for _, rec := range recs {
+			s, err := specOf(rec.RR(), zone)
+			if err != nil {
+				return deleted, err
+			}
+			if !s.deletes(r) {
+				continue
+			}
+			if err := client.Delete(ctx, r.ID); err != nil {
+				return deleted, err
+			}
+			deleted = append(deleted, fromAlidns(r))
+			break
+		}
+	}
+	return deleted, nil
+}
+
+// ListZones returns every domain of the account.
+func (p *Provider) ListZones(ctx context.Context) ([]libdns.Zone, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+	domains, err := client.Domains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]libdns.Zone, 0, len(domains))
+	for _, d := range domains {
+		zones = append(zones, libdns.Zone{Name: d + "."})
+	}
+	return zones, nil
+}
+
+// getClient creates the client on first use.
+func (p *Provider) getClient() (*alidns.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		return p.client, nil
+	}
+
+	var mws []alidns.Middleware
+	if p.Retries > 0 {
+		backoff := p.RetryBackoff
+		if backoff <= 0 {
+			backoff = defaultRetryBackoff
+		}
+		mws = append(mws, alidns.Retry(p.Retries+1, backoff))
+	}
+	mws = append(mws, p.Middleware...)
+	opts := []alidns.Option{
+		alidns.WithTimeouts(p.ConnectTimeout, p.ReadTimeout),
+		alidns.WithMiddleware(mws...),
+	}
+	if p.AccessKeyID != "" || p.AccessKeySecret != "" {
+		opts = append(opts, alidns.WithCredentials(p.AccessKeyID, p.AccessKeySecret))
+	}
+	if p.Endpoint != "" {
+		opts = append(opts, alidns.WithEndpoint(p.Endpoint))
+	}
+	client, err := alidns.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.client = client
+	return client, nil
+}
+
+func domainOf(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+// fromAlidns converts a record to the typed libdns record for its type, or
+// to a plain RR when it cannot be parsed.
+func fromAlidns(r alidns.Record) libdns.Record {
+	value := r.Value
+	switch r.Type {
+	case "MX":
+		value = fmt.Sprintf("%d %s", r.Priority, value)
+	case "TXT":
+		value = strings.Trim(value, `"`)
+	}
+	rr := libdns.RR{
+		Name: r.Name,
+		TTL:  r.TTL,
+		Type: r.Type,
+		Data: value,
+	}
+	parsed, err := rr.Parse()
+	if err != nil {
+		return rr
+	}
+	return parsed
+}
+
+// spec is a libdns record in Alidns terms.
+type spec struct {
+	Name     string
+	Type     string
+	Value    string
+	Priority int64
+	TTL      int64
+}
+
+func specOf(rr libdns.RR, zone string) (spec, error) {
+	s := spec{
+		Name: strings.ToLower(rr.Name),
+		Type: strings.ToUpper(rr.Type),
+		TTL:  int64(rr.TTL / time.Second),
+	}
+	switch {
+	case s.Name == "":
+		s.Name = "@"
+	case strings.HasSuffix(s.Name, "."):
+		s.Name = libdns.RelativeName(s.Name, strings.ToLower(zone))
+	}
+	switch s.Type {
+	case "MX":
+		if rr.Data == "" {
+			break
+		}
+		fields := strings.Fields(rr.Data)
+		if len(fields) != 2 {
+			return spec{}, fmt.Errorf("malformed MX data %q, want \"<preference> <target>\"", rr.Data)
+		}
+		priority, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return spec{}, fmt.Errorf("malformed MX preference %q: %w", fields[0], err)
+		}
+		s.Priority, s.Value = priority, strings.TrimSuffix(fields[1], ".")
+	case "CNAME", "NS":
+		s.Value = strings.TrimSuffix(rr.Data, ".")
+	default:
+		s.Value = rr.Data
+	}
+	return s, nil
+}
+
+func (s spec) set() string {
+	return s.Name + " " + s.Type
+}
+
+func setOf(r alidns.Record) string {
+	return strings.ToLower(r.Name) + " " + strings.ToUpper(r.Type)
+}
+
+func onDefaultLine(r alidns.Record) bool {
+	return r.Line == "" || r.Line == defaultLine
+}
+
+func (s spec) sameValue(r alidns.Record) bool {
+	value := r.Value
+	switch s.Type {
+	case "TXT":
+		return strings.Trim(value, `"`) == s.Value
+	case "MX":
+		if r.Priority != s.Priority {
+			return false
+		}
+	}
+	return strings.EqualFold(strings.TrimSuffix(value, "."), s.Value)
+}
+
+// deletes reports whether r matches s as a DeleteRecords input.
+func (s spec) deletes(r alidns.Record) bool {
+	if !strings.EqualFold(r.Name, s.Name) {
+		return false
+	}
+	if s.Type != "" && !strings.EqualFold(r.Type, s.Type) {
+		return false
+	}
+	if s.TTL != 0 && seconds(r.TTL) != s.TTL {
+		return false
+	}
+	return s.Value == "" || s.sameValue(r)
+}
+
+func (s spec) record() libdns.Record {
+	return fromAlidns(alidns.Record{
+		Name:     s.Name,
+		Type:     s.Type,
+		Value:    s.Value,
+		Priority: s.Priority,
+		TTL:      time.Duration(s.TTL) * time.Second,
+	})
+}
+
+func (s spec) add(ctx context.Context, client *alidns.Client, domain string) error {
+	_, err := client.Add(ctx, alidns.Record{
+		Domain:   domain,
+		Name:     s.Name,
+		Type:     s.Type,
+		Value:    s.Value,
+		TTL:      time.Duration(s.TTL) * time.Second,
+		Priority: s.Priority,
+	})
+	return err
+}
+
+// update rewrites r with the value and TTL of s.
+func (s spec) update(ctx context.Context, client *alidns.Client, r alidns.Record) error {
+	_, err := client.Update(ctx, alidns.Record{
+		ID:       r.ID,
+		Domain:   r.Domain,
+		Name:     s.Name,
+		Type:     s.Type,
+		Value:    s.Value,
+		TTL:      time.Duration(s.TTL) * time.Second,
+		Priority: s.Priority,
+		Line:     r.Line,
+	})
+	return err
+}
+
+func indexOf(specs []spec, done []bool, r alidns.Record) int {
+	for i, s := range specs {
+		if !done[i] && s.set() == setOf(r) && s.sameValue(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+func spareFor(spare []alidns.Record, s spec) int {
+	for i, r := range spare {
+		if setOf(r) == s.set() {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,235 @@
+// Copyright (C) 2026 Joey Kot <joey.kot.x@gmail.com>
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed WITHOUT ANY WARRANTY; without even the
+// implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+// See <https://www.gnu.org/licenses/> for more details.
+
+package libdns
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	core "github.com/Joey-Kot/alidns/internal/alidns"
+	"github.com/Joey-Kot/alidns/pkg/alidns"
+	alidns20150109 "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/libdns/libdns"
+)
+
+// fakeAPI keeps the records of one domain in memory.
+type fakeAPI struct {
+	core.DNSAPI
+
+	records   []*core.Record
+	nextID    int
+	calls     []string
+	throttled int
+}
+
+// newProvider returns a provider whose API calls are answered by api
+// instead of being sent.
+func newProvider(api core.DNSAPI) *Provider {
+	return &Provider{AccessKeyID: "ak", AccessKeySecret: "sk", Middleware: []alidns.Middleware{serve(api)}}
+}
+
+// serve is a terminal middleware that hands each call to the method of
+// api named by its operation.
+func serve(api core.DNSAPI) alidns.Middleware {
+	return func(alidns.Handler) alidns.Handler {
+		return func(ctx context.Context, call *alidns.Call) (any, error) {
+			out := reflect.ValueOf(api).MethodByName(call.Operation).Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(call.Request)})
+			err, _ := out[1].Interface().(error)
+			return out[0].Interface(), err
+		}
+	}
+}
+
+func (f *fakeAPI) DescribeDomainRecords(_ context.Context, req *alidns20150109.DescribeDomainRecordsRequest) ([]*core.Record, error) {
+	if tea.Int64Value(req.PageNumber) > 1 {
+		return nil, nil
+	}
+	return append([]*core.Record(nil), f.records...), nil
+}
+
+func (f *fakeAPI) DescribeDomains(context.Context, *alidns20150109.DescribeDomainsRequest) ([]*alidns20150109.DescribeDomainsResponseBodyDomainsDomain, error) {
+	f.calls = append(f.calls, "domains")
+	if f.throttled > 0 {
+		f.throttled--
+		return nil, &tea.SDKError{Code: tea.String("Throttling.User"), Message: tea.String("slow down")}
+	}
+	return []*alidns20150109.DescribeDomainsResponseBodyDomainsDomain{{DomainName: tea.String("example.com")}}, nil
+}
+
+func (f *fakeAPI) add(rr, rType, value string, ttl int64) {
+	f.nextID++
+	f.records = append(f.records, &core.Record{
+		RecordId: tea.String(fmt.Sprintf("r-%d", f.nextID)), RR: tea.String(rr), Type: tea.String(rType),
+		Value: tea.String(value), TTL: tea.Int64(ttl), Line: tea.String("default"),
+	})
+}
+
+func (f *fakeAPI) AddDomainRecord(_ context.Context, req *alidns20150109.AddDomainRecordRequest) (*alidns20150109.AddDomainRecordResponseBody, error) {
+	f.calls = append(f.calls, "add "+tea.StringValue(req.Value))
+	f.add(tea.StringValue(req.RR), tea.StringValue(req.Type), tea.StringValue(req.Value), tea.Int64Value(req.TTL))
+	f.records[len(f.records)-1].Priority = req.Priority
+	return &alidns20150109.AddDomainRecordResponseBody{RecordId: f.records[len(f.records)-1].RecordId}, nil
+}
+
+func (f *fakeAPI) UpdateDomainRecord(_ context.Context, req *alidns20150109.UpdateDomainRecordRequest) (*alidns20150109.UpdateDomainRecordResponseBody, error) {
+	f.calls = append(f.calls, "update "+tea.StringValue(req.RecordId)+" "+tea.StringValue(req.Value))
+	for _, r := range f.records {
+		if tea.StringValue(r.RecordId) == tea.StringValue(req.RecordId) {
+			r.Value, r.TTL = req.Value, req.TTL
+		}
+	}
+	return &alidns20150109.UpdateDomainRecordResponseBody{}, nil
+}
+
+func (f *fakeAPI) DeleteDomainRecord(_ context.Context, req *alidns20150109.DeleteDomainRecordRequest) (*alidns20150109.DeleteDomainRecordResponseBody, error) {
+	f.calls = append(f.calls, "delete "+tea.StringValue(req.RecordId))
+	kept := f.records[:0]
+	for _, r := range f.records {
+		if tea.StringValue(r.RecordId) != tea.StringValue(req.RecordId) {
+			kept = append(kept, r)
+		}
+	}
+	f.records = kept
+	return &alidns20150109.DeleteDomainRecordResponseBody{}, nil
+}
+
+func TestGetRecordsReturnsTypedRecords(t *testing.T) {
+	api := &fakeAPI{}
+	api.add("www", "A", "192.0.2.1", 600)
+	api.add("_acme-challenge", "TXT", `"token"`, 600)
+	api.add("@", "MX", "mx.example.net", 600)
+	api.records[2].Priority = tea.Int64(10)
+	p := newProvider(api)
+
+	got, err := p.GetRecords(context.Background(), "example.com.")
+	if err != nil {
+		t.Fatalf("GetRecords returned error: %v", err)
+	}
+	want := []libdns.Record{
+		libdns.Address{Name: "www", TTL: 10 * time.Minute, IP: netip.MustParseAddr("192.0.2.1")},
+		libdns.TXT{Name: "_acme-challenge", TTL: 10 * time.Minute, Text: "token"},
+		libdns.MX{Name: "@", TTL: 10 * time.Minute, Preference: 10, Target: "mx.example.net"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetRecords = %#v", got)
+	}
+
+	zones, err := p.ListZones(context.Background())
+	if err != nil || !reflect.DeepEqual(zones, []libdns.Zone{{Name: "example.com."}}) {
+		t.Fatalf("ListZones = %v, %v", zones, err)
+	}
+}
+
+func TestSetRecordsReplacesOnlyTheGivenSets(t *testing.T) {
+	api := &fakeAPI{}
+	api.add("@", "A", "192.0.2.1", 3600)
+	api.add("@", "A", "192.0.2.2", 3600)
+	api.add("@", "TXT", "hello world", 3600)
+	p := newProvider(api)
+
+	set, err := p.SetRecords(context.Background(), "example.com.", []libdns.Record{
+		libdns.Address{Name: "@", TTL: time.Hour, IP: netip.MustParseAddr("192.0.2.3")},
+	})
+	if err != nil {
+		t.Fatalf("SetRecords returned error: %v", err)
+	}
+	if len(set) != 1 {
+		t.Fatalf("SetRecords returned %v", set)
+	}
+	if want := []string{"update r-1 192.0.2.3", "delete r-2"}; !reflect.DeepEqual(api.calls, want) {
+		t.Fatalf("calls = %v, want %v", api.calls, want)
+	}
+	if len(api.records) != 2 || tea.StringValue(api.records[1].Type) != "TXT" {
+		t.Fatalf("unexpected zone after SetRecords: %v", api.records)
+	}
+}
+
+func TestAppendAndDeleteRecords(t *testing.T) {
+	api := &fakeAPI{}
+	p := newProvider(api)
+	ctx := context.Background()
+
+	added, err := p.AppendRecords(ctx, "example.com", []libdns.Record{
+		libdns.TXT{Name: "_acme-challenge", TTL: 10 * time.Minute, Text: "one"},
+		libdns.TXT{Name: "_acme-challenge.example.com.", TTL: 10 * time.Minute, Text: "two"},
+		libdns.MX{Name: "@", Preference: 5, Target: "mx.example.net."},
+	})
+	if err != nil || len(added) != 3 || len(api.records) != 3 {
+		t.Fatalf("AppendRecords = %v, %v", added, err)
+	}
+	if r := api.records[1]; tea.StringValue(r.RR) != "_acme-challenge" || tea.Int64Value(api.records[2].Priority) != 5 {
+		t.Fatalf("unexpected records: %v", api.records)
+	}
+
+	deleted, err := p.DeleteRecords(ctx, "example.com", []libdns.Record{
+		libdns.TXT{Name: "_acme-challenge", Text: "two"},
+		libdns.RR{Name: "@"},
+	})
+	if err != nil || len(deleted) != 2 {
+		t.Fatalf("DeleteRecords = %v, %v", deleted, err)
+	}
+	if len(api.records) != 1 || tea.StringValue(api.records[0].Value) != "one" {
+		t.Fatalf("unexpected zone after DeleteRecords: %v", api.records)
+	}
+}
+
+func TestSetAndDeleteRecordsKeepOtherLines(t *testing.T) {
+	api := &fakeAPI{}
+	api.add("www", "A", "192.0.2.1", 600)
+	api.add("www", "A", "198.51.100.1", 600)
+	api.add("www", "A", "203.0.113.1", 600)
+	api.records[1].Line = tea.String("telecom")
+	api.records[2].Line = tea.String("oversea")
+	p := newProvider(api)
+	ctx := context.Background()
+
+	if _, err := p.SetRecords(ctx, "example.com", []libdns.Record{
+		libdns.Address{Name: "www", TTL: 10 * time.Minute, IP: netip.MustParseAddr("192.0.2.9")},
+	}); err != nil {
+		t.Fatalf("SetRecords returned error: %v", err)
+	}
+	if want := []string{"update r-1 192.0.2.9"}; !reflect.DeepEqual(api.calls, want) {
+		t.Fatalf("calls = %v, want %v", api.calls, want)
+	}
+
+	deleted, err := p.DeleteRecords(ctx, "example.com", []libdns.Record{libdns.RR{Name: "www"}})
+	if err != nil || len(deleted) != 1 {
+		t.Fatalf("DeleteRecords = %v, %v", deleted, err)
+	}
+	if len(api.records) != 2 || tea.StringValue(api.records[0].Line) != "telecom" || tea.StringValue(api.records[1].Line) != "oversea" {
+		t.Fatalf("records of other lines should survive: %v", api.records)
+	}
+}
+
+func TestProviderRetriesThrottledCalls(t *testing.T) {
+	api := &fakeAPI{throttled: 2}
+	p := newProvider(api)
+	p.Retries, p.RetryBackoff = 2, time.Nanosecond
+
+	zones, err := p.ListZones(context.Background())
+	if err != nil || len(zones) != 1 {
+		t.Fatalf("ListZones = %v, %v", zones, err)
+	}
+	if want := []string{"domains", "domains", "domains"}; !reflect.DeepEqual(api.calls, want) {
+		t.Fatalf("calls = %v, want %v", api.calls, want)
+	}
+
+	api = &fakeAPI{throttled: 1}
+	if _, err := newProvider(api).ListZones(context.Background()); alidns.ErrorCode(err) != "Throttling.User" {
+		t.Fatalf("ListZones without retries returned %v", err)
+	}
+}